@@ -8,7 +8,10 @@ import (
 	"github.com/francodesource/propositional_tableaux/formula"
 	"github.com/francodesource/propositional_tableaux/tableaux"
 	"io"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 )
 
 type nopWriteCloser struct {
@@ -37,6 +40,22 @@ func checkFormat(flag string) error {
 	return nil
 }
 
+var strategies = map[string]tableaux.Strategy{
+	"insertion-order":      tableaux.InsertionOrder,
+	"alpha-first":          tableaux.AlphaFirst,
+	"smallest-first":       tableaux.SmallestFirst,
+	"most-frequent-letter": tableaux.MostFrequentLetter,
+	"closing-beta-first":   tableaux.ClosingBetaFirst,
+}
+
+func checkStrategy(flag string) (tableaux.Strategy, error) {
+	strategy, ok := strategies[flag]
+	if !ok {
+		return nil, fmt.Errorf("error: invalid value for -strategy: '%s'", flag)
+	}
+	return strategy, nil
+}
+
 func checkInput(flag string) (io.ReadCloser, error) {
 	if flag == "stdin" {
 		return nopReadCloser{os.Stdin}, nil
@@ -69,6 +88,20 @@ func try(err error) {
 	}
 }
 
+// verdict describes the validity of a sequent given the assignments of the open branches of its tableau, which are
+// its counterexamples.
+func verdict(counterexamples []tableaux.Assignment) string {
+	if len(counterexamples) == 0 {
+		return "The sequent is valid: the tableau is closed"
+	}
+
+	var values []string
+	for _, letter := range slices.Sorted(maps.Keys(counterexamples[0])) {
+		values = append(values, fmt.Sprintf("%s = %t", letter, counterexamples[0][letter]))
+	}
+	return "The sequent is not valid, a counterexample is: " + strings.Join(values, ", ")
+}
+
 func main() {
 	//flags
 	var (
@@ -76,12 +109,20 @@ func main() {
 		format       = flag.String("format", "default", "default | ascii-tree | ascii-tree-unicode | tex-forest")
 		in           = flag.String("in", "stdin", "the name of the input file")
 		out          = flag.String("out", "stdout", "the name of the output file")
+		abbreviate   = flag.Bool("abbreviate", false, "print the names of the definitions instead of their expansions")
+		strategyName = flag.String("strategy", "alpha-first",
+			"insertion-order | alpha-first | smallest-first | most-frequent-letter | closing-beta-first")
+		maxNodes = flag.Int("max-nodes", 0, "stop the construction before the tableau has more nodes, 0 for no limit")
+		maxDepth = flag.Int("max-depth", 0, "do not expand the nodes at this depth, 0 for no limit")
+		timeout  = flag.Duration("timeout", 0, "stop the construction after this time, like 10s, 0 for no timeout")
 	)
 
 	flag.Parse()
 
 	try(checkType(*tableauxType))
 	try(checkFormat(*format))
+	strategy, err := checkStrategy(*strategyName)
+	try(err)
 
 	input, err := checkInput(*in)
 	if err != nil {
@@ -107,13 +148,13 @@ func main() {
 	}(output)
 
 	s := bufio.NewScanner(input)
-	var str string
+	var lines []string
 	var printPrompt = input == nopReadCloser{os.Stdin}
 
 	var prompt = ">> "
 
 	if printPrompt {
-		fmt.Println("Write a propositional formula, empty input to stop writing")
+		fmt.Println("Write a propositional formula or a sequent like p, p -> q |- q, empty input to stop writing")
 		fmt.Print(prompt)
 	} else {
 		fmt.Println("Reading from file")
@@ -121,26 +162,58 @@ func main() {
 	for s.Scan() {
 		line := s.Text()
 
-		if line == "" {
+		// files can contain blank lines, for example between the premises of a sequent.
+		if line == "" && printPrompt {
 			break
 		}
 
-		str += line
+		lines = append(lines, line)
 
 		if printPrompt {
 			fmt.Print(prompt)
 		}
 	}
-
-	f := formula.Parse(str)
+	str := strings.Join(lines, "\n")
+
+	// a sequent is decided with the tableau of its premises and negated conclusions.
+	var f formula.Formula
+	var abbreviations formula.Abbreviations
+	isSequent := formula.IsSequent(str)
+	if isSequent {
+		var sequent formula.Sequent
+		sequent, abbreviations, err = formula.ParseSequentAbbreviated(str)
+		f = sequent.Formula()
+	} else {
+		f, abbreviations, err = formula.ParseAbbreviated(str)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 
 	var tab tableaux.Node
+	var buildErr error
+	opts := []tableaux.Option{tableaux.WithStrategy(strategy), tableaux.WithMaxNodes(*maxNodes),
+		tableaux.WithMaxDepth(*maxDepth), tableaux.WithTimeout(*timeout)}
 
 	switch *tableauxType {
 	case "semantic":
-		tab = tableaux.BuildSemanticTableaux(f)
+		tab, buildErr = tableaux.BuildSemanticTableaux(f, opts...)
 	case "analytic":
-		tab = tableaux.BuildBufferTableaux(f)
+		tab, buildErr = tableaux.BuildBufferTableaux(f, opts...)
+	}
+
+	// a stopped construction is printed anyway, with its unfinished leaves.
+	if buildErr != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "the tableau is unfinished: %v\n", buildErr)
+	}
+
+	// the drawers print the formulas as they are, unless the definitions must be abbreviated.
+	draw := func(fd tableaux.FormulaDrawer) tableaux.FormulaDrawer {
+		if *abbreviate {
+			return tableaux.Abbreviated(fd, abbreviations)
+		}
+		return fd
 	}
 
 	var stringRep string
@@ -149,11 +222,15 @@ func main() {
 	case "default":
 		stringRep = fmt.Sprint(tab)
 	case "ascii-tree":
-		stringRep = fmt.Sprint(tableaux.DefaultAsciiTree(tab))
+		stringRep = fmt.Sprint(tableaux.AsciiTree(tab, draw(tableaux.AsciiFormula), tableaux.AsciiMark))
 	case "ascii-tree-unicode":
-		stringRep = fmt.Sprint(tableaux.UnicodeAsciiTree(tab))
+		stringRep = fmt.Sprint(tableaux.AsciiTree(tab, draw(tableaux.UnicodeFormula), tableaux.UnicodeMark))
 	case "tex-forest":
-		stringRep = tableaux.TexForestTree(tab)
+		stringRep = tableaux.TexForestTreeWith(tab, draw(tableaux.TexFormula))
+	}
+
+	if isSequent && buildErr == nil {
+		stringRep += "\n" + verdict(tab.Eval()) + "\n"
 	}
 
 	_, err = output.Write([]byte(stringRep))
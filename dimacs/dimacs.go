@@ -0,0 +1,328 @@
+// Package dimacs reads and writes propositional problems in the DIMACS CNF format used by SAT solvers:
+//
+//	c a comment
+//	p cnf 3 2
+//	1 -2 0
+//	2 3 -1 0
+//
+// After the optional comment lines, the header declares the number of variables and of clauses. Every clause is a
+// list of non-zero integers terminated by 0, where v is the variable v and -v its negation; a clause can span more
+// lines and a line can contain more clauses. Variables are numbered from 1, and a Mapping gives them a letter name.
+package dimacs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/francodesource/propositional_tableaux/formula"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Problem is a CNF problem: a conjunction of clauses, where every clause is a disjunction of literals.
+type Problem struct {
+	Comments  []string // Comments are the comment lines, without the leading "c" and the following space.
+	Variables int      // Variables is the number of variables, numbered from 1.
+	Clauses   [][]int  // Clauses contains the clauses, each a list of non-zero literals: v or -v for the variable v.
+}
+
+// ErrShortMapping is returned, wrapped, by Problem.Literals and Problem.Formula when the mapping has no letter for
+// some variable of the problem.
+var ErrShortMapping = errors.New("dimacs: the mapping is shorter than the problem")
+
+// SyntaxError is the error returned by Read and ReadMapping when the input is not well-formed.
+type SyntaxError struct {
+	Line   int    // Line is the line of the error, starting from 1.
+	Column int    // Column is the position of the error in its line in bytes, starting from 0.
+	Msg    string // Msg describes the error.
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("dimacs: line %d:%d %s", e.Line, e.Column, e.Msg)
+}
+
+// field is a whitespace-separated field of a line with its column.
+type field struct {
+	text   string
+	column int
+}
+
+func fields(line string) []field {
+	var res []field
+	start := -1
+	for i := 0; i <= len(line); i++ {
+		if i == len(line) || line[i] == ' ' || line[i] == '\t' || line[i] == '\r' {
+			if start >= 0 {
+				res = append(res, field{line[start:i], start})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	return res
+}
+
+// Read reads a problem in DIMACS CNF format. Comment lines start with "c" and can appear anywhere, and a line
+// starting with "%" ends the problem, as in the SATLIB benchmarks. The last clause can omit the terminating 0.
+// It returns a *SyntaxError if the header is missing or malformed, if a literal is not an integer or refers to
+// a variable greater than the declared ones, or if the number of clauses differs from the declared one.
+func Read(r io.Reader) (*Problem, error) {
+	p := &Problem{}
+	reader := bufio.NewReader(r)
+	header, declared := false, 0
+	var clause []int
+	line, column := 0, 0
+
+	for done := false; !done; {
+		text, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		done = err != nil
+		if text == "" && done {
+			break
+		}
+		text = strings.TrimRight(text, "\r\n")
+		line, column = line+1, len(text)
+
+		trimmed := strings.TrimLeft(text, " \t")
+		indent := len(text) - len(trimmed)
+		switch {
+		case strings.HasPrefix(trimmed, "c") && (len(trimmed) == 1 || trimmed[1] == ' ' || trimmed[1] == '\t'):
+			p.Comments = append(p.Comments, strings.TrimPrefix(trimmed[1:], " "))
+		case strings.HasPrefix(trimmed, "%"):
+			done = true
+		case strings.HasPrefix(trimmed, "p"):
+			if header {
+				return nil, &SyntaxError{line, indent, "repeated header"}
+			}
+			fs := fields(text)
+			if len(fs) != 4 || fs[0].text != "p" || fs[1].text != "cnf" {
+				return nil, &SyntaxError{line, indent, `the header must be "p cnf <variables> <clauses>"`}
+			}
+			for i, target := range []*int{&p.Variables, &declared} {
+				n, err := strconv.Atoi(fs[i+2].text)
+				if err != nil || n < 0 {
+					return nil, &SyntaxError{line, fs[i+2].column, fmt.Sprintf("invalid number %q", fs[i+2].text)}
+				}
+				*target = n
+			}
+			header = true
+		default:
+			for _, f := range fields(text) {
+				if !header {
+					return nil, &SyntaxError{line, f.column, "clause before the header"}
+				}
+				lit, err := strconv.Atoi(f.text)
+				if err != nil {
+					return nil, &SyntaxError{line, f.column, fmt.Sprintf("invalid literal %q", f.text)}
+				}
+				if lit > p.Variables || -lit > p.Variables {
+					return nil, &SyntaxError{line, f.column,
+						fmt.Sprintf("literal %d exceeds the %d declared variables", lit, p.Variables)}
+				}
+				if lit == 0 {
+					p.Clauses = append(p.Clauses, append([]int{}, clause...))
+					clause = clause[:0]
+				} else {
+					clause = append(clause, lit)
+				}
+			}
+		}
+	}
+
+	if !header {
+		return nil, &SyntaxError{line, column, "missing header"}
+	}
+	if len(clause) > 0 {
+		p.Clauses = append(p.Clauses, clause)
+	}
+	if len(p.Clauses) != declared {
+		return nil, &SyntaxError{line, column, fmt.Sprintf("%d clauses, but %d declared", len(p.Clauses), declared)}
+	}
+	return p, nil
+}
+
+// Write writes the problem in DIMACS CNF format, with the comments, the header and a clause per line.
+func Write(w io.Writer, p *Problem) error {
+	bw := bufio.NewWriter(w)
+	for _, comment := range p.Comments {
+		_, _ = fmt.Fprintf(bw, "c %s\n", comment)
+	}
+	_, _ = fmt.Fprintf(bw, "p cnf %d %d\n", p.Variables, len(p.Clauses))
+	for _, clause := range p.Clauses {
+		for _, lit := range clause {
+			bw.WriteString(strconv.Itoa(lit))
+			bw.WriteByte(' ')
+		}
+		bw.WriteString("0\n")
+	}
+	return bw.Flush()
+}
+
+// Mapping gives a letter name to the variables of a problem: Mapping[v-1] is the letter of the variable v.
+type Mapping []string
+
+// DefaultMapping returns the mapping of n variables to the letters x1, ..., xn.
+func DefaultMapping(n int) Mapping {
+	res := make(Mapping, n)
+	for i := range res {
+		res[i] = "x" + strconv.Itoa(i+1)
+	}
+	return res
+}
+
+// Literal returns the formula.Literal of a DIMACS literal. It panics if the mapping has no letter for its variable.
+func (m Mapping) Literal(lit int) formula.Literal {
+	if lit < 0 {
+		return formula.Literal{Name: m[-lit-1], Neg: true}
+	}
+	return formula.Literal{Name: m[lit-1]}
+}
+
+// Literals returns the clauses of the problem as lists of formula.Literal, named with the mapping. It returns an error
+// wrapping ErrShortMapping if the mapping has fewer letters than the variables of the problem, or than the variable of
+// one of its literals.
+func (p *Problem) Literals(m Mapping) ([][]formula.Literal, error) {
+	if len(m) < p.Variables {
+		return nil, fmt.Errorf("%w: %d letters for %d variables", ErrShortMapping, len(m), p.Variables)
+	}
+
+	res := make([][]formula.Literal, len(p.Clauses))
+	for i, clause := range p.Clauses {
+		res[i] = make([]formula.Literal, len(clause))
+		for j, lit := range clause {
+			if lit == 0 || lit > len(m) || -lit > len(m) {
+				return nil, fmt.Errorf("%w: no letter for the literal %d", ErrShortMapping, lit)
+			}
+			res[i][j] = m.Literal(lit)
+		}
+	}
+	return res, nil
+}
+
+// Formula returns the problem as a conjunction of disjunctions, named with the mapping and built like the parser
+// does, so that the clauses with more than two literals and the problems with more than two clauses are n-ary
+// formulas. A problem without clauses is ⊤ and an empty clause is ⊥. It returns the errors of Literals.
+func (p *Problem) Formula(m Mapping) (formula.Formula, error) {
+	clauses, err := p.Literals(m)
+	if err != nil {
+		return nil, err
+	}
+	conjuncts := make([]formula.Formula, len(clauses))
+	for i, clause := range clauses {
+		disjuncts := make([]formula.Formula, len(clause))
+		for j, lit := range clause {
+			disjuncts[j] = formula.NewLetter(lit.Name)
+			if lit.Neg {
+				disjuncts[j] = formula.NewNot(disjuncts[j])
+			}
+		}
+		conjuncts[i] = formula.Disjunction(disjuncts...)
+	}
+	return formula.Conjunction(conjuncts...), nil
+}
+
+// FromClauses returns the problem of the given clauses and the mapping of its variables. The given letters are
+// numbered first, in order, and the other letters of the clauses follow in order of first occurrence.
+func FromClauses(clauses [][]formula.Literal, letters ...string) (*Problem, Mapping) {
+	var m Mapping
+	variables := make(map[string]int)
+	variable := func(name string) int {
+		if v, ok := variables[name]; ok {
+			return v
+		}
+		m = append(m, name)
+		variables[name] = len(m)
+		return len(m)
+	}
+	for _, name := range letters {
+		variable(name)
+	}
+
+	p := &Problem{Clauses: make([][]int, len(clauses))}
+	for i, clause := range clauses {
+		p.Clauses[i] = make([]int, len(clause))
+		for j, lit := range clause {
+			p.Clauses[i][j] = variable(lit.Name)
+			if lit.Neg {
+				p.Clauses[i][j] = -p.Clauses[i][j]
+			}
+		}
+	}
+	p.Variables = len(m)
+	return p, m
+}
+
+// FromCNF converts the formula to CNF with formula.ToCNF and returns its problem, where the letters of the formula
+// are the first variables in order of first occurrence. The limit on the number of clauses is the one of ToCNF.
+func FromCNF(f formula.Formula, limit int) (*Problem, Mapping, error) {
+	clauses, err := formula.ToCNF(f, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	p, m := FromClauses(clauses, formula.Letters(f)...)
+	return p, m, nil
+}
+
+// FromTseitin returns the problem of the Tseitin encoding of the formula, which is equisatisfiable with it and
+// linear in its size. The letters of the formula are the first variables in order of first occurrence, followed by
+// the fresh letters of the encoding.
+func FromTseitin(f formula.Formula) (*Problem, Mapping) {
+	return FromClauses(formula.Tseitin(f).Clauses, formula.Letters(f)...)
+}
+
+// WriteMapping writes the mapping with a line "<variable> <letter>" for every variable.
+func WriteMapping(w io.Writer, m Mapping) error {
+	bw := bufio.NewWriter(w)
+	for i, name := range m {
+		_, _ = fmt.Fprintf(bw, "%d %s\n", i+1, name)
+	}
+	return bw.Flush()
+}
+
+// ReadMapping reads a mapping written by WriteMapping. The variables can be in any order, but they must be
+// exactly 1, ..., n for some n, and every letter can appear once. Empty lines are ignored.
+func ReadMapping(r io.Reader) (Mapping, error) {
+	letters := make(map[int]string)
+	used := make(map[string]bool)
+	s := bufio.NewScanner(r)
+
+	line := 0
+	for s.Scan() {
+		line++
+		fs := fields(s.Text())
+		if len(fs) == 0 {
+			continue
+		}
+		if len(fs) != 2 {
+			return nil, &SyntaxError{line, fs[0].column, `expected "<variable> <letter>"`}
+		}
+		v, err := strconv.Atoi(fs[0].text)
+		if err != nil || v <= 0 {
+			return nil, &SyntaxError{line, fs[0].column, fmt.Sprintf("invalid variable %q", fs[0].text)}
+		}
+		if _, ok := letters[v]; ok {
+			return nil, &SyntaxError{line, fs[0].column, fmt.Sprintf("repeated variable %d", v)}
+		}
+		if used[fs[1].text] {
+			return nil, &SyntaxError{line, fs[1].column, fmt.Sprintf("repeated letter %q", fs[1].text)}
+		}
+		letters[v], used[fs[1].text] = fs[1].text, true
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	m := make(Mapping, len(letters))
+	for v := 1; v <= len(m); v++ {
+		name, ok := letters[v]
+		if !ok {
+			return nil, &SyntaxError{line, 0, fmt.Sprintf("missing variable %d", v)}
+		}
+		m[v-1] = name
+	}
+	return m, nil
+}
@@ -0,0 +1,267 @@
+package dimacs
+
+import (
+	"bytes"
+	"errors"
+	"github.com/francodesource/propositional_tableaux/formula"
+	"github.com/francodesource/propositional_tableaux/tableaux"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *Problem
+	}{
+		{
+			name:  "simple",
+			input: "c a comment\np cnf 3 2\n1 -2 0\n2 3 -1 0\n",
+			want:  &Problem{Comments: []string{"a comment"}, Variables: 3, Clauses: [][]int{{1, -2}, {2, 3, -1}}},
+		},
+		{
+			name:  "clauses over more lines",
+			input: "p cnf 3 2\n1\n-2\n0 2 3\n-1 0\n",
+			want:  &Problem{Variables: 3, Clauses: [][]int{{1, -2}, {2, 3, -1}}},
+		},
+		{
+			name:  "comments between clauses and blank lines",
+			input: "p  cnf 2 2\r\n\r\n  1 0\nc\nc second\n\t-2 0",
+			want:  &Problem{Comments: []string{"", "second"}, Variables: 2, Clauses: [][]int{{1}, {-2}}},
+		},
+		{
+			name:  "SATLIB end marker",
+			input: "p cnf 2 1\n1 2 0\n%\n0\n",
+			want:  &Problem{Variables: 2, Clauses: [][]int{{1, 2}}},
+		},
+		{
+			name:  "last clause without 0",
+			input: "p cnf 2 2\n1 0\n-1 2",
+			want:  &Problem{Variables: 2, Clauses: [][]int{{1}, {-1, 2}}},
+		},
+		{
+			name:  "empty clause",
+			input: "p cnf 1 2\n0\n1 0\n",
+			want:  &Problem{Variables: 1, Clauses: [][]int{{}, {1}}},
+		},
+		{
+			name:  "no clauses",
+			input: "p cnf 0 0\n",
+			want:  &Problem{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.input))
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  SyntaxError
+	}{
+		{"missing header", "c only comments\n", SyntaxError{1, 15, "missing header"}},
+		{"clause before the header", "1 2 0\np cnf 2 1\n", SyntaxError{1, 0, "clause before the header"}},
+		{"wrong format", "p dnf 2 1\n", SyntaxError{1, 0, `the header must be "p cnf <variables> <clauses>"`}},
+		{"invalid number", "p cnf 2 x\n", SyntaxError{1, 8, `invalid number "x"`}},
+		{"repeated header", "p cnf 1 1\n p cnf 1 1\n", SyntaxError{2, 1, "repeated header"}},
+		{"invalid literal", "p cnf 2 1\n1 a2 0\n", SyntaxError{2, 2, `invalid literal "a2"`}},
+		{"undeclared variable", "p cnf 2 1\n1 -3 0\n", SyntaxError{2, 2, "literal -3 exceeds the 2 declared variables"}},
+		{"too few clauses", "p cnf 2 2\n1 0\n", SyntaxError{2, 3, "1 clauses, but 2 declared"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.input))
+			var se *SyntaxError
+			if !errors.As(err, &se) || *se != tt.want {
+				t.Errorf("Read() error = %v, want %v", err, &tt.want)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	p := &Problem{Comments: []string{"generated"}, Variables: 3, Clauses: [][]int{{1, -2}, {}, {3}}}
+	want := "c generated\np cnf 3 3\n1 -2 0\n0\n3 0\n"
+
+	var buf bytes.Buffer
+	if err := Write(&buf, p); err != nil || buf.String() != want {
+		t.Errorf("Write() = %q, %v, want %q", buf.String(), err, want)
+	}
+
+	got, err := Read(&buf)
+	if err != nil || !reflect.DeepEqual(got, p) {
+		t.Errorf("Read(Write(%+v)) = %+v, %v", p, got, err)
+	}
+}
+
+func TestProblem_Formula(t *testing.T) {
+	tests := []struct {
+		name    string
+		problem *Problem
+		mapping Mapping
+		want    string
+	}{
+		{"default mapping", &Problem{Variables: 2, Clauses: [][]int{{1, -2}, {2}}}, DefaultMapping(2), "((x1 | !x2) & x2)"},
+		{"letters", &Problem{Variables: 2, Clauses: [][]int{{-1, 2, 1}}}, Mapping{"p", "q"}, "(!p | q | p)"},
+		{"no clauses", &Problem{}, nil, "⊤"},
+		{"empty clause", &Problem{Variables: 1, Clauses: [][]int{{1}, {}}}, Mapping{"p"}, "(p & ⊥)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.problem.Formula(tt.mapping); err != nil || got.String() != tt.want {
+				t.Errorf("Formula() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestProblem_Formula_ShortMapping(t *testing.T) {
+	p, err := Read(strings.NewReader("p cnf 3 2\n1 -2 0\n3 0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := ReadMapping(strings.NewReader("1 p\n2 q\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		problem *Problem
+		want    string
+	}{
+		{"read", p, "dimacs: the mapping is shorter than the problem: 2 letters for 3 variables"},
+		{"undeclared variable", &Problem{Variables: 2, Clauses: [][]int{{1}, {-3}}},
+			"dimacs: the mapping is shorter than the problem: no letter for the literal -3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.problem.Formula(m); !errors.Is(err, ErrShortMapping) || err.Error() != tt.want {
+				t.Errorf("Formula() = %v, %v, want error %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromClauses(t *testing.T) {
+	clauses := [][]formula.Literal{{{Name: "q"}, {Name: "p", Neg: true}}, {{Name: "r"}, {Name: "q", Neg: true}}}
+
+	p, m := FromClauses(clauses, "p")
+	if want := (Mapping{"p", "q", "r"}); !reflect.DeepEqual(m, want) {
+		t.Errorf("mapping = %v, want %v", m, want)
+	}
+	if want := (&Problem{Variables: 3, Clauses: [][]int{{2, -1}, {3, -2}}}); !reflect.DeepEqual(p, want) {
+		t.Errorf("problem = %+v, want %+v", p, want)
+	}
+	if got, err := p.Literals(m); err != nil || !reflect.DeepEqual(got, clauses) {
+		t.Errorf("Literals() = %v, %v, want %v", got, err, clauses)
+	}
+}
+
+func TestMapping(t *testing.T) {
+	m := Mapping{"p", "q", "_d1"}
+
+	var buf bytes.Buffer
+	if err := WriteMapping(&buf, m); err != nil || buf.String() != "1 p\n2 q\n3 _d1\n" {
+		t.Errorf("WriteMapping() = %q, %v", buf.String(), err)
+	}
+	if got, err := ReadMapping(&buf); err != nil || !reflect.DeepEqual(got, m) {
+		t.Errorf("ReadMapping() = %v, %v, want %v", got, err, m)
+	}
+
+	errorTests := []struct {
+		input string
+		want  SyntaxError
+	}{
+		{"1 p\n1 q\n", SyntaxError{2, 0, "repeated variable 1"}},
+		{"1 p\n2 p\n", SyntaxError{2, 2, `repeated letter "p"`}},
+		{"1 p\n\n3 q\n", SyntaxError{3, 0, "missing variable 2"}},
+		{"x p\n", SyntaxError{1, 0, `invalid variable "x"`}},
+		{"1 p q\n", SyntaxError{1, 0, `expected "<variable> <letter>"`}},
+	}
+	for _, tt := range errorTests {
+		_, err := ReadMapping(strings.NewReader(tt.input))
+		var se *SyntaxError
+		if !errors.As(err, &se) || *se != tt.want {
+			t.Errorf("ReadMapping(%q) error = %v, want %v", tt.input, err, &tt.want)
+		}
+	}
+}
+
+// roundTrip writes the problem and the mapping and reads them back as a formula.
+func roundTrip(t *testing.T, p *Problem, m Mapping) formula.Formula {
+	var problem, mapping bytes.Buffer
+	if err := Write(&problem, p); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteMapping(&mapping, m); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := Read(&problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readMapping, err := ReadMapping(&mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := read.Formula(readMapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+// TestFromFormula checks that the CNF written to DIMACS is equivalent to the formula and that the Tseitin encoding is
+// equisatisfiable with it, deciding both with the buffered tableaux.
+func TestFromFormula(t *testing.T) {
+	f := func(f formula.Formula) bool {
+		p, m, err := FromCNF(f, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cnf := roundTrip(t, p, m)
+		// f and its CNF are equivalent if (f <-> cnf) is valid, so if its negation is unsatisfiable.
+		if tab, _ := tableaux.BuildBufferTableaux(formula.NewNot(formula.NewBiconditional(f, cnf))); len(tab.Eval()) > 0 {
+			t.Errorf("%v: the CNF %v is not equivalent", f, cnf)
+			return false
+		}
+
+		p, m = FromTseitin(f)
+		encoding := roundTrip(t, p, m)
+		tab, _ := tableaux.BuildBufferTableaux(f)
+		satisfiable := len(tab.Eval()) > 0
+		if tab, _ := tableaux.BuildBufferTableaux(encoding); len(tab.Eval()) > 0 != satisfiable {
+			t.Errorf("%v: the Tseitin encoding %v is not equisatisfiable", f, encoding)
+			return false
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		MaxCount: 50,
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(formula.GenerateRandom(r, r.Intn(12)+1))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
@@ -12,14 +12,77 @@ NOR:  '!|' ;
 NAND:  '!&' ;
 XOR:  '^' ;
 NOT: '!' ;
+TOP: 'T' ;
+BOTTOM: 'F' ;
+COMMA: ',' ;
+TURNSTILE: '|-' ;
+LET: 'let' ;
+DEF: 'def' ;
+EQUALS: '=' ;
+SEMICOLON: ';' ;
+CONVERSE: '<-' ;
+NONIMPLIES: '!->' ;
+ITE: 'ite' ;
+ATMOST: 'atmost' ;
+ATLEAST: 'atleast' ;
+EXACTLY: 'exactly' ;
+
+// Alternative spellings: Unicode symbols, ASCII alternates, keywords and LaTeX macros.
+// They must precede VARIABLE so that keywords like 'and' or 'true' are not read as letters.
+OP_ALT: '\\left(' -> type(OP) ;
+CP_ALT: '\\right)' -> type(CP) ;
+AND_ALT: ('∧' | '/\\' | 'and' | '\\land' | '\\wedge') -> type(AND) ;
+OR_ALT: ('∨' | '\\/' | 'or' | '\\lor' | '\\vee') -> type(OR) ;
+IMPLIES_ALT: ('→' | '⇒' | '=>' | '\\to' | '\\rightarrow' | '\\Rightarrow' | '\\implies') -> type(IMPLIES) ;
+BICONDITIONAL_ALT: ('↔' | '⇔' | '<=>' | '\\leftrightarrow' | '\\Leftrightarrow' | '\\iff') -> type(BICONDITIONAL) ;
+NOR_ALT: ('↓' | 'nor' | '\\downarrow') -> type(NOR) ;
+NAND_ALT: ('↑' | 'nand' | '\\uparrow') -> type(NAND) ;
+XOR_ALT: ('⊕' | '⊻' | 'xor' | '\\oplus' | '\\veebar') -> type(XOR) ;
+NOT_ALT: ('¬' | '~' | '-' | 'not' | '\\neg' | '\\lnot') -> type(NOT) ;
+TOP_ALT: ('⊤' | 'true' | '\\top') -> type(TOP) ;
+BOTTOM_ALT: ('⊥' | 'false' | '\\bot') -> type(BOTTOM) ;
+CONVERSE_ALT: ('←' | '⇐' | '<=' | '\\leftarrow' | '\\Leftarrow') -> type(CONVERSE) ;
+NONIMPLIES_ALT: ('↛' | '\\nrightarrow') -> type(NONIMPLIES) ;
+ITE_ALT: ('\\mathrm{ite}' | '\\operatorname{ite}') -> type(ITE) ;
+ATMOST_ALT: ('\\mathrm{atmost}' | '\\operatorname{atmost}') -> type(ATMOST) ;
+ATLEAST_ALT: ('\\mathrm{atleast}' | '\\operatorname{atleast}') -> type(ATLEAST) ;
+EXACTLY_ALT: ('\\mathrm{exactly}' | '\\operatorname{exactly}') -> type(EXACTLY) ;
+TURNSTILE_ALT: ('⊢' | '∴' | 'therefore' | '\\vdash' | '\\therefore') -> type(TURNSTILE) ;
+
 VARIABLE: [a-zA-Z_0-9]+ ;
 WHITESPACE: [ \t\r\n]+ -> skip ;
+COMMENT: '#' ~[\r\n]* -> skip ;
 
 // Rules
-start : expression EOF;
+start : definition* expression EOF;
+
+// A sequent has the premises on the left of the turnstile and the conclusions on its right, both optional.
+sequent : definition* premises=formulas? TURNSTILE conclusions=formulas? EOF;
+
+formulas : expression (COMMA expression)* ;
+
+// A definition introduces an abbreviation, like let A = (p & q);, or a schema with parameters,
+// like def Pierce(X, Y) = (((X -> Y) -> X) -> X);, that the following formulas can use.
+definition
+    : LET name=VARIABLE EQUALS expression SEMICOLON                         #Abbreviation
+    | DEF name=VARIABLE OP parameters CP EQUALS expression SEMICOLON        #Schema
+    ;
+
+parameters : VARIABLE (COMMA VARIABLE)* ;
 
 expression
-    : OP left=expression op=(AND | OR | IMPLIES | BICONDITIONAL | NOR | NAND | XOR) right=expression CP #Binary
-    | NOT negated=expression                              #Negation
-    | VARIABLE                                            #Letter
+    : OP expression CP                                              #Parenthesized
+    | NOT negated=expression                                        #Negation
+    | left=expression op=(AND | NAND) right=expression              #Binary
+    | left=expression op=XOR right=expression                       #Binary
+    | left=expression op=(OR | NOR) right=expression                #Binary
+    | <assoc=right> left=expression op=IMPLIES right=expression     #Binary
+    | left=expression op=(CONVERSE | NONIMPLIES) right=expression   #Binary
+    | left=expression op=BICONDITIONAL right=expression             #Binary
+    | ITE OP condition=expression COMMA consequent=expression COMMA alternative=expression CP #Ite
+    | kind=(ATMOST | ATLEAST | EXACTLY) OP bound=VARIABLE SEMICOLON operands=formulas CP   #Cardinality
+    | name=VARIABLE OP arguments=formulas CP                         #Application
+    | VARIABLE                                                      #Letter
+    | TOP                                                           #Top
+    | BOTTOM                                                        #Bottom
     ;
\ No newline at end of file
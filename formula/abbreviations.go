@@ -0,0 +1,75 @@
+package formula
+
+import "strings"
+
+// Abbreviation is the name of an expanded definition: the name of an abbreviation, or the name of a schema and the
+// arguments it is applied to.
+type Abbreviation struct {
+	Name      string
+	Arguments []Formula
+}
+
+// String returns the abbreviation as it is written in the input, like A or Pierce(p, (q & r)).
+func (a Abbreviation) String() string {
+	return a.draw(func(f Formula) string { return f.String() })
+}
+
+func (a Abbreviation) draw(draw func(Formula) string) string {
+	if len(a.Arguments) == 0 {
+		return a.Name
+	}
+	args := make([]string, len(a.Arguments))
+	for i, arg := range a.Arguments {
+		args[i] = draw(arg)
+	}
+	return a.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Abbreviations maps formulas to their abbreviations, like the definitions expanded by ParseAbbreviated.
+// The formulas are compared structurally, so they must be plain formulas, not *Interned, while Abbreviate accepts
+// interned formulas too.
+type Abbreviations map[Formula]Abbreviation
+
+// Abbreviate replaces the subformulas that have an abbreviation, starting from the outermost ones, with a letter
+// named as the abbreviation: with the abbreviation A of (p & q), ((p & q) -> q) becomes (A -> q). The arguments of
+// schemas are abbreviated too and written with draw, so that a renderer can write them with its own symbols; if
+// draw is nil they are written with String. The result is meant for printing, since the abbreviation letters are not
+// equivalent to the formulas they replace.
+func (a Abbreviations) Abbreviate(formula Formula, draw func(Formula) string) Formula {
+	formula = plain(formula)
+	if draw == nil {
+		draw = func(f Formula) string { return f.String() }
+	}
+	if abbreviation, ok := a[formula]; ok {
+		return NewLetter(abbreviation.draw(func(arg Formula) string { return draw(a.Abbreviate(arg, draw)) }))
+	}
+
+	switch f := formula.(type) {
+	case Not:
+		return NewNot(a.Abbreviate(f.Negated(), draw))
+	case Binary:
+		return NewBinary(a.Abbreviate(f.Left(), draw), a.Abbreviate(f.Right(), draw), f.Op())
+	case NAry:
+		operands := f.Operands()
+		for i, operand := range operands {
+			operands[i] = a.Abbreviate(operand, draw)
+		}
+		return NewNAry(f.Op(), operands...)
+	case Ite:
+		return NewIte(a.Abbreviate(f.Condition(), draw), a.Abbreviate(f.Then(), draw), a.Abbreviate(f.Else(), draw))
+	case Cardinality:
+		operands := f.Operands()
+		for i, operand := range operands {
+			operands[i] = a.Abbreviate(operand, draw)
+		}
+		return NewCardinality(f.Kind(), f.Bound(), operands...)
+	case Custom:
+		operands := f.Operands()
+		for i, operand := range operands {
+			operands[i] = a.Abbreviate(operand, draw)
+		}
+		return f.with(operands)
+	default:
+		return f
+	}
+}
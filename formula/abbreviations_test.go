@@ -0,0 +1,59 @@
+package formula
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAbbreviated(t *testing.T) {
+	f, abbreviations, err := ParseAbbreviated(
+		"let A = (p & q); def Pierce(X, Y) = (((X -> Y) -> X) -> X); def S(X) = Pierce(X, X); Pierce(A, r) | S(p)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the application of Pierce in the body of S is not an abbreviation, as it contains the parameter X.
+	want := map[string]string{
+		"(p & q)": "A",
+		"((((p & q) -> r) -> (p & q)) -> (p & q))": "Pierce((p & q), r)",
+		"(((p -> p) -> p) -> p)":                   "S(p)",
+	}
+	if len(abbreviations) != len(want) {
+		t.Errorf("got %d abbreviations %v, want %d", len(abbreviations), abbreviations, len(want))
+	}
+	for formula, abbreviation := range abbreviations {
+		if want[formula.String()] != abbreviation.String() {
+			t.Errorf("abbreviation of %v = %v, want %v", formula, abbreviation, want[formula.String()])
+		}
+	}
+
+	if got := abbreviations.Abbreviate(f, nil).String(); got != "(Pierce(A, r) | S(p))" {
+		t.Errorf("Abbreviate(%v) = %v", f, got)
+	}
+	upper := func(f Formula) string { return strings.ToUpper(f.String()) }
+	if got := abbreviations.Abbreviate(f, upper).String(); got != "(Pierce(A, R) | S(P))" {
+		t.Errorf("Abbreviate(%v) with a drawer = %v", f, got)
+	}
+}
+
+func TestAbbreviations_Abbreviate(t *testing.T) {
+	abbreviations := Abbreviations{
+		NewAnd(p, q):           {Name: "A"},
+		NewOr(NewAnd(p, q), q): {Name: "B"},
+	}
+	tests := []struct {
+		formula Formula
+		want    string
+	}{
+		{NewOr(NewAnd(p, q), q), "B"},
+		{NewNot(NewImplies(NewAnd(p, q), NewOr(NewAnd(p, q), q))), "!(A -> B)"},
+		{NewAnd(q, p), "(q & p)"},
+		{p, "p"},
+	}
+
+	for _, tt := range tests {
+		if got := abbreviations.Abbreviate(tt.formula, nil); got.String() != tt.want {
+			t.Errorf("Abbreviate(%v) = %v, want %v", tt.formula, got, tt.want)
+		}
+	}
+}
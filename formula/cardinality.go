@@ -0,0 +1,311 @@
+package formula
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// CardinalityEncoding is a way to write cardinality constraints with Binary formulas.
+type CardinalityEncoding int
+
+const (
+	// Pairwise writes atmost(k; ...) as the conjunction of !(A1 & ... & Ak+1) for every k+1 operands, and
+	// atleast(k; ...) as the conjunction of the disjunctions of every n-k+1 operands. It introduces no letters, but
+	// its size grows with the binomial coefficients: it is quadratic for atmost(1; ...).
+	Pairwise CardinalityEncoding = iota
+	// SequentialCounter introduces a letter for "at least j of the first i operands", defined from the letters of
+	// the first i-1 operands, so that its size is O(n·k).
+	SequentialCounter
+	// Totalizer splits the operands in two halves recursively and introduces a letter for "at least j of the
+	// operands" of every half, defined from the letters of its two halves, so that its size is O(n·k²).
+	Totalizer
+)
+
+func (e CardinalityEncoding) String() string {
+	switch e {
+	case Pairwise:
+		return "pairwise"
+	case SequentialCounter:
+		return "sequential counter"
+	case Totalizer:
+		return "totalizer"
+	default:
+		panic(fmt.Errorf("unknown cardinality encoding %d", int(e)))
+	}
+}
+
+// CompileCardinality returns the formula with every cardinality constraint replaced by Binary formulas with the
+// given encoding, so that it can be exported to the formats and the tools that do not know the constraints.
+// The Pairwise encoding returns an equivalent formula. The other encodings introduce fresh letters, named like the
+// ones of Tseitin, and return the conjunction of the formula with the definitions (x <-> D) of the fresh letters,
+// which are also returned as a map from every fresh letter to the constraint atleast(j; ...) that it stands for.
+// Every model of the formula extends to exactly one model of the result, and the models of the result projected on
+// the original letters, like with Encoding.Project, are models of the formula.
+func CompileCardinality(formula Formula, encoding CardinalityEncoding) (Formula, map[string]Formula) {
+	cc := &cardinalityCompiler{
+		encoding:    encoding,
+		definitions: make(map[string]Formula),
+		letters:     make(map[Cardinality]Letter),
+		used:        make(map[string]bool),
+	}
+	for _, name := range Letters(formula) {
+		cc.used[name] = true
+	}
+
+	res := Transform(formula, func(f Formula) Formula {
+		if c, ok := f.(Cardinality); ok {
+			return cc.compile(c)
+		}
+		return f
+	})
+	return chain(And, NewTop(), append([]Formula{res}, cc.clauses...)), cc.definitions
+}
+
+// cardinalityCompiler holds the state of CompileCardinality.
+type cardinalityCompiler struct {
+	encoding    CardinalityEncoding
+	clauses     []Formula          // clauses contains the definitions (x <-> D) of the fresh letters, in order.
+	definitions map[string]Formula // definitions maps the fresh letters to the constraints they stand for.
+	letters     map[Cardinality]Letter
+	used        map[string]bool
+	next        int
+}
+
+// compile returns the formula that replaces the constraint.
+func (cc *cardinalityCompiler) compile(c Cardinality) Formula {
+	if cc.encoding == Pairwise {
+		return pairwise(c)
+	}
+
+	operands := c.Operands()
+	var atLeast func(j int) Formula
+	if cc.encoding == SequentialCounter {
+		atLeast = func(j int) Formula { return cc.counter(operands, len(operands), j) }
+	} else {
+		m := c.Bound() + 1 // the totalizer only needs the letters up to k+1 true operands.
+		atLeast = func(j int) Formula {
+			if j <= 0 {
+				return NewTop()
+			}
+			if outputs := cc.totalizer(operands, m); j <= len(outputs) {
+				return outputs[j-1]
+			}
+			return NewBottom()
+		}
+	}
+	return boundedBy(c, atLeast)
+}
+
+// boundedBy returns the formula of the constraint, given the formula of "at least j operands are true".
+func boundedBy(c Cardinality, atLeast func(j int) Formula) Formula {
+	switch c.Kind() {
+	case AtMost:
+		return negation(atLeast(c.Bound() + 1))
+	case AtLeast:
+		return atLeast(c.Bound())
+	default:
+		return conjunction(atLeast(c.Bound()), negation(atLeast(c.Bound()+1)))
+	}
+}
+
+// pairwise returns the Pairwise encoding of the constraint.
+func pairwise(c Cardinality) Formula {
+	if holds, decided := decide(c.Kind(), c.Bound(), 0, c.Len()); decided {
+		return constant(holds)
+	}
+	operands := c.Operands()
+	complements := make([]Formula, len(operands))
+	for i, operand := range operands {
+		complements[i] = Complement(operand)
+	}
+
+	// at most k operands are true if at least one of every k+1 operands is false, and at least k operands are true
+	// if at least one of every n-k+1 operands is true.
+	atLeast := func(j int) Formula {
+		if j <= 0 {
+			return NewTop()
+		}
+		var clauses []Formula
+		for _, subset := range combinations(len(operands), len(operands)-j+1) {
+			clauses = append(clauses, chain(Or, NewBottom(), pick(operands, subset)))
+		}
+		return chain(And, NewTop(), clauses)
+	}
+	atMost := func(k int) Formula {
+		var clauses []Formula
+		for _, subset := range combinations(len(operands), k+1) {
+			clauses = append(clauses, chain(Or, NewBottom(), pick(complements, subset)))
+		}
+		return chain(And, NewTop(), clauses)
+	}
+
+	switch c.Kind() {
+	case AtMost:
+		return atMost(c.Bound())
+	case AtLeast:
+		return atLeast(c.Bound())
+	default:
+		return conjunction(atLeast(c.Bound()), atMost(c.Bound()))
+	}
+}
+
+// complement returns the formula equivalent to the negation of the constraint: atmost(k; ...) is the negation of
+// atleast(k+1; ...) and exactly(k; ...) is the negation of (atmost(k-1; ...) | atleast(k+1; ...)).
+func (c Cardinality) complement() Formula {
+	operands := c.Operands()
+	switch c.Kind() {
+	case AtMost:
+		return cardinality(AtLeast, c.Bound()+1, operands)
+	case AtLeast:
+		return cardinality(AtMost, c.Bound()-1, operands)
+	default:
+		return disjunction(cardinality(AtMost, c.Bound()-1, operands), cardinality(AtLeast, c.Bound()+1, operands))
+	}
+}
+
+// combinations returns the sets of k indexes in [0, n), each one in increasing order. It returns no sets if k is
+// greater than n or if it is not positive.
+func combinations(n, k int) [][]int {
+	var res [][]int
+	var rec func(start int, current []int)
+	rec = func(start int, current []int) {
+		if len(current) == k {
+			res = append(res, append([]int(nil), current...))
+			return
+		}
+		for i := start; i <= n-(k-len(current)); i++ {
+			rec(i+1, append(current, i))
+		}
+	}
+	if k > 0 && k <= n {
+		rec(0, nil)
+	}
+	return res
+}
+
+func pick(formulas []Formula, indexes []int) []Formula {
+	res := make([]Formula, len(indexes))
+	for i, index := range indexes {
+		res[i] = formulas[index]
+	}
+	return res
+}
+
+// counter returns the formula of "at least j of the first i operands are true" of the SequentialCounter encoding.
+func (cc *cardinalityCompiler) counter(operands []Formula, i, j int) Formula {
+	if j <= 0 {
+		return NewTop()
+	}
+	if j > i {
+		return NewBottom()
+	}
+	if i == 1 {
+		return operands[0]
+	}
+	definition := NewAtLeast(j, operands[:i]...)
+	if letter, ok := cc.letters[definition]; ok {
+		return letter
+	}
+	// either j of the first i-1 operands are true, or the i-th operand and j-1 of the first i-1 operands are.
+	d := disjunction(cc.counter(operands, i-1, j), conjunction(operands[i-1], cc.counter(operands, i-1, j-1)))
+	return cc.define(definition, d)
+}
+
+// totalizer returns the formulas of "at least j of the operands are true" of the Totalizer encoding, for j from 1 to
+// the number of operands or to m if it is smaller.
+func (cc *cardinalityCompiler) totalizer(operands []Formula, m int) []Formula {
+	if len(operands) == 1 {
+		return operands
+	}
+	half := len(operands) / 2
+	left, right := cc.totalizer(operands[:half], m), cc.totalizer(operands[half:], m)
+
+	res := make([]Formula, min(len(operands), m))
+	for j := 1; j <= len(res); j++ {
+		definition := NewAtLeast(j, operands...)
+		if letter, ok := cc.letters[definition]; ok {
+			res[j-1] = letter
+			continue
+		}
+		// j operands are true if i of the left half and j-i of the right half are.
+		d := Formula(NewBottom())
+		for i := max(0, j-len(right)); i <= min(j, len(left)); i++ {
+			d = disjunction(d, conjunction(output(left, i), output(right, j-i)))
+		}
+		res[j-1] = cc.define(definition, d)
+	}
+	return res
+}
+
+// output returns the formula of "at least j operands are true" among the outputs of a totalizer.
+func output(outputs []Formula, j int) Formula {
+	if j == 0 {
+		return NewTop()
+	}
+	return outputs[j-1]
+}
+
+// define returns a fresh letter defined as d, which is equivalent to definition.
+func (cc *cardinalityCompiler) define(definition Cardinality, d Formula) Formula {
+	var letter Letter
+	for letter.name == "" || cc.used[letter.name] {
+		cc.next++
+		letter = NewLetter(FreshLetterPrefix + strconv.Itoa(cc.next))
+	}
+	cc.used[letter.Name()] = true
+	cc.letters[definition] = letter
+	cc.definitions[letter.Name()] = definition
+	cc.clauses = append(cc.clauses, NewBiconditional(letter, d))
+	return letter
+}
+
+// chain returns the left-associated chain of Binary formulas joining the operands with op, the operand itself if
+// there is only one and empty if there are none.
+func chain(op Operator, empty Formula, operands []Formula) Formula {
+	if len(operands) == 0 {
+		return empty
+	}
+	res := operands[0]
+	for _, operand := range operands[1:] {
+		res = NewBinary(res, operand, op)
+	}
+	return res
+}
+
+// conjunction returns (a & b), or the operand that decides it if one of them is a constant.
+func conjunction(a, b Formula) Formula {
+	switch {
+	case IsConstant(a):
+		if AsConstant(a) {
+			return b
+		}
+		return a
+	case IsConstant(b):
+		return conjunction(b, a)
+	default:
+		return NewAnd(a, b)
+	}
+}
+
+// disjunction returns (a | b), or the operand that decides it if one of them is a constant.
+func disjunction(a, b Formula) Formula {
+	switch {
+	case IsConstant(a):
+		if AsConstant(a) {
+			return a
+		}
+		return b
+	case IsConstant(b):
+		return disjunction(b, a)
+	default:
+		return NewOr(a, b)
+	}
+}
+
+// negation returns the complement of the formula, or the opposite constant if it is a constant.
+func negation(f Formula) Formula {
+	if IsConstant(f) {
+		return constant(!AsConstant(f))
+	}
+	return Complement(f)
+}
@@ -0,0 +1,150 @@
+package formula
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestCompileCardinality(t *testing.T) {
+	d := func(i int) Letter { return NewLetter(FreshLetterPrefix + string(rune('0'+i))) }
+	p, q, r := letters.p, letters.q, letters.r
+
+	tests := []struct {
+		name     string
+		input    string
+		encoding CardinalityEncoding
+		want     Formula
+		wantDefs map[string]Formula
+	}{
+		{
+			name:     "pairwise at most one",
+			input:    "atmost(1; p, q, r) & s",
+			encoding: Pairwise,
+			want: NewAnd(NewAnd(NewAnd(NewOr(NewNot(p), NewNot(q)), NewOr(NewNot(p), NewNot(r))),
+				NewOr(NewNot(q), NewNot(r))), letters.s),
+			wantDefs: map[string]Formula{},
+		},
+		{
+			name:     "pairwise exactly one",
+			input:    "exactly(1; p, !q)",
+			encoding: Pairwise,
+			want:     NewAnd(NewOr(p, NewNot(q)), NewOr(NewNot(p), q)),
+			wantDefs: map[string]Formula{},
+		},
+		{
+			name:     "pairwise decided by the bound",
+			input:    "atleast(3; p, q) | atmost(2; p, q)",
+			encoding: Pairwise,
+			want:     NewOr(NewBottom(), NewTop()),
+			wantDefs: map[string]Formula{},
+		},
+		{
+			name:     "sequential counter",
+			input:    "atmost(1; p, q, r)",
+			encoding: SequentialCounter,
+			want: NewAnd(NewAnd(NewAnd(NewNot(d(3)), NewBiconditional(d(1), NewAnd(q, p))),
+				NewBiconditional(d(2), NewOr(p, q))), NewBiconditional(d(3), NewOr(d(1), NewAnd(r, d(2))))),
+			wantDefs: map[string]Formula{
+				d(1).Name(): NewAtLeast(2, p, q),
+				d(2).Name(): NewAtLeast(1, p, q),
+				d(3).Name(): NewAtLeast(2, p, q, r),
+			},
+		},
+		{
+			name:     "totalizer",
+			input:    "atmost(1; p, q, r)",
+			encoding: Totalizer,
+			want: NewAnd(NewAnd(NewAnd(NewAnd(NewNot(d(4)), NewBiconditional(d(1), NewOr(r, q))),
+				NewBiconditional(d(2), NewAnd(q, r))), NewBiconditional(d(3), NewOr(d(1), p))),
+				NewBiconditional(d(4), NewOr(d(2), NewAnd(p, d(1))))),
+			wantDefs: map[string]Formula{
+				d(1).Name(): NewAtLeast(1, q, r),
+				d(2).Name(): NewAtLeast(2, q, r),
+				d(3).Name(): NewAtLeast(1, p, q, r),
+				d(4).Name(): NewAtLeast(2, p, q, r),
+			},
+		},
+		{
+			name:     "shared counter",
+			input:    "exactly(1; p, q)",
+			encoding: SequentialCounter,
+			want: NewAnd(NewAnd(NewAnd(d(1), NewNot(d(2))), NewBiconditional(d(1), NewOr(p, q))),
+				NewBiconditional(d(2), NewAnd(q, p))),
+			wantDefs: map[string]Formula{
+				d(1).Name(): NewAtLeast(1, p, q),
+				d(2).Name(): NewAtLeast(2, p, q),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, defs := CompileCardinality(Parse(tt.input), tt.encoding)
+			if got != tt.want {
+				t.Errorf("CompileCardinality(%v, %v) = %v, want %v", tt.input, tt.encoding, got, tt.want)
+			}
+			if !reflect.DeepEqual(defs, tt.wantDefs) {
+				t.Errorf("definitions = %v, want %v", defs, tt.wantDefs)
+			}
+		})
+	}
+}
+
+// TestCompileCardinality_Models checks that every model of a constraint extends to exactly one model of its encodings,
+// where the fresh letters have the values of their definitions, and that the other assignments extend to none.
+func TestCompileCardinality_Models(t *testing.T) {
+	f := func(f Formula) bool {
+		for _, encoding := range []CardinalityEncoding{Pairwise, SequentialCounter, Totalizer} {
+			compiled, defs := CompileCardinality(f, encoding)
+			models := make(map[string]int) // models counts the models of the encoding by their projection.
+			// the letters of the formula may disappear from a decided encoding, like atmost(2; p) that becomes T.
+			for _, a := range assignments(NewAnd(f, compiled)) {
+				if ok, _ := Eval(compiled, a); !ok {
+					continue
+				}
+				projected := Encoding{Definitions: defs}.Project(a)
+				if ok, _ := Eval(f, projected); !ok {
+					t.Errorf("%v: the %v model %v is not a model", f, encoding, a)
+					return false
+				}
+				for name, definition := range defs {
+					if value, _ := Eval(definition, projected); value != a[name] {
+						t.Errorf("%v: in the %v model %v, %s is not %v", f, encoding, a, name, definition)
+						return false
+					}
+				}
+				models[fmt.Sprint(projected)]++
+			}
+
+			for _, a := range assignments(f) {
+				want := 0
+				if ok, _ := Eval(f, a); ok {
+					want = 1
+				}
+				if got := models[fmt.Sprint(a)]; got != want {
+					t.Errorf("%v: %v has %d %v models, want %d", f, a, got, encoding, want)
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			operands := make([]Formula, r.Intn(3)+1)
+			for i := range operands {
+				operands[i] = GenerateRandom(r, r.Intn(2)+1)
+			}
+			kind := CardinalityKind(r.Intn(int(Exactly) + 1))
+			values[0] = reflect.ValueOf(NewCardinality(kind, r.Intn(len(operands)+2), operands...))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
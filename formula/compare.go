@@ -0,0 +1,167 @@
+package formula
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// kindRank returns the position of the kind of the formula in the order used by Compare.
+func kindRank(formula Formula) int {
+	switch f := formula.(type) {
+	case Top:
+		return 0
+	case Bottom:
+		return 1
+	case Letter:
+		return 2
+	case Not:
+		return 3
+	case Binary:
+		return 4
+	case NAry:
+		return 5
+	case Ite:
+		return 6
+	case Cardinality:
+		return 7
+	case Custom:
+		return 8
+	default:
+		panic(fmt.Errorf("%v: %T is not a Formula", f, f))
+	}
+}
+
+// Compare returns a negative number if a comes before b, zero if they are equal and a positive number if a comes
+// after b, in a total order that does not depend on the run or on how the formulas were built.
+// Formulas are ordered by kind: ⊤, ⊥, letters, negations, binary, n-ary, if-then-else formulas, cardinality
+// constraints and registered connectives. Letters are then ordered by name, negations by their operand and binary
+// formulas by operator, in the order of the Operator constants, and then by their left and right operands. N-ary
+// formulas are ordered by operator and then by their operands from left to right, a prefix coming first, and
+// if-then-else formulas by condition, then and else formulas. Cardinality constraints are ordered by kind, then by
+// bound and then by their operands like n-ary formulas, and registered connectives by name and then by their operands.
+// Interned formulas are compared as their plain formulas.
+func Compare(a, b Formula) int {
+	if a, ok := a.(*Interned); ok {
+		if b, ok := b.(*Interned); ok && a == b {
+			return 0
+		}
+	}
+	a, b = plain(a), plain(b)
+
+	if c := cmp.Compare(kindRank(a), kindRank(b)); c != 0 {
+		return c
+	}
+
+	switch a := a.(type) {
+	case Letter:
+		return strings.Compare(a.Name(), b.(Letter).Name())
+	case Not:
+		return Compare(a.Negated(), b.(Not).Negated())
+	case Binary:
+		b := b.(Binary)
+		if c := cmp.Compare(a.Op(), b.Op()); c != 0 {
+			return c
+		}
+		if c := Compare(a.Left(), b.Left()); c != 0 {
+			return c
+		}
+		return Compare(a.Right(), b.Right())
+	case NAry:
+		b := b.(NAry)
+		if c := cmp.Compare(a.Op(), b.Op()); c != 0 {
+			return c
+		}
+		return slices.CompareFunc(a.Operands(), b.Operands(), Compare)
+	case Ite:
+		return slices.CompareFunc(Operands(a), Operands(b), Compare)
+	case Cardinality:
+		b := b.(Cardinality)
+		if c := cmp.Compare(a.Kind(), b.Kind()); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Bound(), b.Bound()); c != 0 {
+			return c
+		}
+		return slices.CompareFunc(a.Operands(), b.Operands(), Compare)
+	case Custom:
+		b := b.(Custom)
+		if c := strings.Compare(a.Name(), b.Name()); c != 0 {
+			return c
+		}
+		return slices.CompareFunc(a.Operands(), b.Operands(), Compare)
+	default:
+		return 0
+	}
+}
+
+// Equal returns true if the formulas are structurally equal. Unlike ==, it also compares interned formulas with
+// plain ones.
+func Equal(a, b Formula) bool {
+	return Compare(a, b) == 0
+}
+
+const (
+	hashOffset = 14695981039346656037
+	hashPrime  = 1099511628211
+)
+
+// Hash returns a 64-bit structural hash of the formula: equal formulas, interned or not, have the same hash.
+// It is the FNV-1a hash of a prefix encoding of the formula, so it does not change between runs and can be stored.
+func Hash(formula Formula) uint64 {
+	return hash(hashOffset, formula)
+}
+
+func hashByte(h uint64, b byte) uint64 {
+	return (h ^ uint64(b)) * hashPrime
+}
+
+func hash(h uint64, formula Formula) uint64 {
+	switch f := plain(formula).(type) {
+	case Top:
+		return hashByte(h, 'T')
+	case Bottom:
+		return hashByte(h, 'F')
+	case Letter:
+		h = hashByte(h, 'l')
+		for i := 0; i < len(f.Name()); i++ {
+			h = hashByte(h, f.Name()[i])
+		}
+		return hashByte(h, 0) // the terminator separates the name from what follows.
+	case Not:
+		return hash(hashByte(h, '!'), f.Negated())
+	case Binary:
+		h = hashByte(hashByte(h, 'b'), byte(f.Op()))
+		return hash(hash(h, f.Left()), f.Right())
+	case NAry:
+		h = hashByte(hashByte(h, 'n'), byte(f.Op()))
+		for _, operand := range f.Operands() {
+			h = hash(h, operand)
+		}
+		return hashByte(h, 0) // the terminator separates the operands from what follows.
+	case Ite:
+		return hash(hash(hash(hashByte(h, 'i'), f.Condition()), f.Then()), f.Else())
+	case Cardinality:
+		h = hashByte(hashByte(h, 'c'), byte(f.Kind()))
+		for shift := 0; shift < 64; shift += 8 {
+			h = hashByte(h, byte(f.Bound()>>shift))
+		}
+		for _, operand := range f.Operands() {
+			h = hash(h, operand)
+		}
+		return hashByte(h, 0)
+	case Custom:
+		h = hashByte(h, 'u')
+		for i := 0; i < len(f.Name()); i++ {
+			h = hashByte(h, f.Name()[i])
+		}
+		h = hashByte(h, 0)
+		for _, operand := range f.Operands() {
+			h = hash(h, operand)
+		}
+		return hashByte(h, 0)
+	default:
+		panic(fmt.Errorf("%v: %T is not a Formula", f, f))
+	}
+}
@@ -0,0 +1,126 @@
+package formula
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestCompare(t *testing.T) {
+	// sorted is in increasing order.
+	sorted := []string{"T", "F", "p", "q", "!T", "!p", "!!p", "!(p & q)", "p & q", "p & !q", "q & p", "p | q", "p ^ q",
+		"p & q & r", "p & q & r & s", "p & q & s", "p & r & q", "p | q | r",
+		"p ^ q ^ r", "ite(p, q, r)", "ite(p, r, q)", "ite(q, p, r)", "atmost(1; p, q)", "atmost(2; p)", "atmost(2; p, q)",
+		"atleast(0; p)", "exactly(0; p)", "maj(p, q, r)", "maj(q, p, r)", "stroke(p, q)"}
+
+	for i, a := range sorted {
+		for j, b := range sorted {
+			got := Compare(Parse(a), Parse(b))
+			if i < j && got >= 0 || i == j && got != 0 || i > j && got <= 0 {
+				t.Errorf("Compare(%v, %v) = %v", a, b, got)
+			}
+		}
+	}
+}
+
+func TestCompare_Interned(t *testing.T) {
+	fac := NewFactory()
+	p, q := fac.Intern(Parse("p & !q")), Parse("p & !q")
+
+	if !Equal(p, q) || !Equal(q, p) {
+		t.Errorf("%v is not equal to its interned version", q)
+	}
+	if Compare(fac.Letter("p"), letters.q) >= 0 {
+		t.Errorf("Compare(p, q) >= 0 with an interned letter")
+	}
+	if Hash(p) != Hash(q) {
+		t.Errorf("the hash of %v changes when it is interned", q)
+	}
+}
+
+func TestHash(t *testing.T) {
+	// the hash must not change between runs and releases, since it can be stored.
+	tests := []struct {
+		input string
+		want  uint64
+	}{
+		{"p", 0x129efe191df261b3},
+		{"!p", 0x2fa4456ba81a0310},
+		{"(p -> q) & T", 0x8eb0696e3f14c8c6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Hash(Parse(tt.input)); got != tt.want {
+				t.Errorf("Hash(%v) = %#x, want %#x", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	distinct := []string{"p", "q", "pq", "!p", "T", "F", "p & q", "q & p", "p | q", "(p & q) & r", "p & (q & r)",
+		"p & q & r", "p | q | r", "p ^ q ^ r", "ite(p, q, r)", "ite(r, q, p)", "p <- q", "p !-> q",
+		"atmost(1; p, q)", "atleast(1; p, q)", "atmost(2; p, q)", "atmost(1; p, q, r)", "maj(p, q, r)", "stroke(p, q)"}
+	seen := make(map[uint64]string)
+	for _, input := range distinct {
+		h := Hash(Parse(input))
+		if other, ok := seen[h]; ok {
+			t.Errorf("%v and %v have the same hash", input, other)
+		}
+		seen[h] = input
+	}
+}
+
+// TestCompare_Order checks that Compare is a total order consistent with ==, and that equal formulas have the same
+// hash.
+func TestCompare_Order(t *testing.T) {
+	f := func(a, b, c Formula) bool {
+		if (Compare(a, b) == 0) != (a == b) || Equal(a, b) != (a == b) {
+			t.Errorf("Compare(%v, %v) = %v", a, b, Compare(a, b))
+			return false
+		}
+		if sign(Compare(a, b)) != -sign(Compare(b, a)) {
+			t.Errorf("Compare(%v, %v) is not antisymmetric", a, b)
+			return false
+		}
+		if a == b && Hash(a) != Hash(b) {
+			t.Errorf("%v has different hashes", a)
+			return false
+		}
+
+		fs := []Formula{a, b, c}
+		slices.SortFunc(fs, Compare)
+		if Compare(fs[0], fs[2]) > 0 {
+			t.Errorf("Compare is not transitive on %v", fs)
+			return false
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			// small formulas over few letters, so that equal formulas are generated as well.
+			for i := range values {
+				values[i] = reflect.ValueOf(Rename(generateRandomNAry(r, r.Intn(6)), map[string]string{
+					"r": "p", "s": "p", "t": "q", "u": "q", "v": "p", "w": "q", "x": "p", "y": "q", "z": "p",
+				}))
+			}
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
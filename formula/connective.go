@@ -0,0 +1,141 @@
+package formula
+
+import (
+	"fmt"
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/francodesource/propositional_tableaux/formula/parser"
+	"slices"
+	"sync"
+)
+
+// Connective describes a connective registered with RegisterConnective, like the majority of three formulas, so
+// that it can be parsed, evaluated, rendered and expanded by the tableaux without changing this package.
+type Connective struct {
+	Name    string   // Name is written before the operands, like maj(p, q, r), and identifies the connective.
+	Symbols []string // Symbols are the other names that the parser accepts for the connective, like majority.
+	Arity   int      // Arity is the number of operands of the connective, at least one.
+
+	// Definition returns a formula equivalent to the connective applied to the operands, like
+	// ((p & q) | (p & r) | (q & r)) for maj(p, q, r). It gives the meaning of the connective to Eval, ToNNF, Tseitin
+	// and the writers of the other formats, so it must not contain the connective itself.
+	Definition func(operands []Formula) Formula
+
+	// Positive is the tableau rule of the connective and Negative the one of its negation. A rule without Components
+	// is the alpha rule that replaces the formula with its Definition, or with the negation of its Definition.
+	Positive, Negative Decomposition
+
+	Unicode string // Unicode is the symbol written by the Unicode renderers, Name if it is empty.
+	LaTeX   string // LaTeX is the symbol written by the LaTeX renderers, \mathrm{Name} if it is empty.
+}
+
+// Decomposition is the tableau rule of a registered connective or of its negation.
+type Decomposition struct {
+	Class Classification // Class is Alpha or Beta.
+
+	// Components returns the formulas that the rule adds to the branch if it is an alpha rule, or the formulas of the
+	// new branches if it is a beta rule, which must be at least two. The operands may be *Interned: the results are
+	// built with the usual constructors, and the tableaux intern them again.
+	Components func(operands []Formula) []Formula
+}
+
+var connectives = struct {
+	sync.RWMutex
+	bySymbol map[string]*Connective
+}{bySymbol: make(map[string]*Connective)}
+
+// RegisterConnective registers the connective, so that NewCustom and the parser accept its name and its symbols.
+//
+// The parser reads a registered connective only in prefix form, applied to its operands like a schema, as in
+// maj(p, q, r) or majority(p, q, r), so its name and its symbols must be valid letter names: operator symbols like ⊼ or
+// ~&, written between the operands or not, cannot be registered, since the lexer only knows the built-in operators.
+// The Unicode and LaTeX symbols are only used to render the formulas, and they are not parsed back.
+//
+// It returns an error if a name or a symbol is not a valid letter name, like a keyword, an operator symbol or a name
+// with spaces, if it is already the name or a symbol of a registered connective, if the arity is not positive, if the
+// Definition is missing or if the Class of a Decomposition with Components is not Alpha or Beta.
+func RegisterConnective(c Connective) error {
+	if c.Arity < 1 {
+		return fmt.Errorf("the arity of %s must be positive, found %d", c.Name, c.Arity)
+	}
+	if c.Definition == nil {
+		return fmt.Errorf("%s has no definition", c.Name)
+	}
+	for _, d := range []Decomposition{c.Positive, c.Negative} {
+		if d.Components != nil && d.Class != Alpha && d.Class != Beta {
+			return fmt.Errorf("the rules of %s must be alpha or beta, found %v", c.Name, d.Class)
+		}
+	}
+	if c.Unicode == "" {
+		c.Unicode = c.Name
+	}
+	if c.LaTeX == "" {
+		c.LaTeX = `\mathrm{` + c.Name + `}`
+	}
+	c.Symbols = slices.Clone(c.Symbols)
+
+	connectives.Lock()
+	defer connectives.Unlock()
+	symbols := append([]string{c.Name}, c.Symbols...)
+	for i, symbol := range symbols {
+		if !isLetterName(symbol) {
+			return fmt.Errorf("%q cannot be the name or a symbol of a connective, since it is not a letter name: "+
+				"connectives are parsed in prefix form, like %s(p, q)", symbol, c.Name)
+		}
+		if _, ok := connectives.bySymbol[symbol]; ok || slices.Contains(symbols[:i], symbol) {
+			return fmt.Errorf("%s is already the name of a connective", symbol)
+		}
+	}
+	for _, symbol := range symbols {
+		connectives.bySymbol[symbol] = &c
+	}
+	return nil
+}
+
+// UnregisterConnective removes the connective with the given name and its symbols from the registry. The formulas
+// already built with the connective keep working, but it cannot be parsed anymore.
+func UnregisterConnective(name string) {
+	connectives.Lock()
+	defer connectives.Unlock()
+	c, ok := connectives.bySymbol[name]
+	if !ok || c.Name != name {
+		return
+	}
+	for _, symbol := range append([]string{c.Name}, c.Symbols...) {
+		delete(connectives.bySymbol, symbol)
+	}
+}
+
+// LookupConnective returns the registered connective with the given name or symbol.
+func LookupConnective(symbol string) (*Connective, bool) {
+	connectives.RLock()
+	defer connectives.RUnlock()
+	c, ok := connectives.bySymbol[symbol]
+	return c, ok
+}
+
+// isLetterName reports whether the parser reads the name as a letter.
+func isLetterName(name string) bool {
+	errListener := &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	tokens := newLexer(name, errListener).GetAllTokens()
+	return len(errListener.errors) == 0 && len(tokens) == 1 &&
+		tokens[0].GetTokenType() == parser.FormulaLexerVARIABLE && tokens[0].GetText() == name
+}
+
+// Decomposition returns the tableau rule of the connective, or of its negation if negated is true, replacing a rule
+// without Components with the alpha rule of the Definition.
+func (c *Connective) Decomposition(negated bool) Decomposition {
+	d := c.Positive
+	if negated {
+		d = c.Negative
+	}
+	if d.Components == nil {
+		// the formula is replaced with its definition.
+		return Decomposition{Class: Alpha, Components: func(operands []Formula) []Formula {
+			if negated {
+				return []Formula{NewNot(c.Definition(operands))}
+			}
+			return []Formula{c.Definition(operands)}
+		}}
+	}
+	return d
+}
@@ -0,0 +1,219 @@
+package formula
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// mustRegister registers the connective for the tests of the package and returns its name.
+func mustRegister(c Connective) string {
+	if err := RegisterConnective(c); err != nil {
+		panic(err)
+	}
+	return c.Name
+}
+
+// maj is the majority of three formulas, with a beta rule for both polarities.
+var maj = mustRegister(Connective{
+	Name:    "maj",
+	Symbols: []string{"majority"},
+	Arity:   3,
+	Definition: func(operands []Formula) Formula {
+		a, b, c := operands[0], operands[1], operands[2]
+		return NewNAry(Or, NewAnd(a, b), NewAnd(a, c), NewAnd(b, c))
+	},
+	Positive: Decomposition{Class: Beta, Components: func(operands []Formula) []Formula {
+		a, b, c := operands[0], operands[1], operands[2]
+		return []Formula{NewAnd(a, b), NewAnd(a, c), NewAnd(b, c)}
+	}},
+	Negative: Decomposition{Class: Beta, Components: func(operands []Formula) []Formula {
+		a, b, c := NewNot(operands[0]), NewNot(operands[1]), NewNot(operands[2])
+		return []Formula{NewAnd(a, b), NewAnd(a, c), NewAnd(b, c)}
+	}},
+	Unicode: "Maj",
+})
+
+// stroke is a binary connective defined as !(A & B), with the default rules.
+var stroke = mustRegister(Connective{
+	Name:       "stroke",
+	Arity:      2,
+	Definition: func(operands []Formula) Formula { return NewNot(NewAnd(operands[0], operands[1])) },
+	Unicode:    "⊼",
+	LaTeX:      `\barwedge`,
+})
+
+func TestRegisterConnective_Errors(t *testing.T) {
+	definition := func(operands []Formula) Formula { return operands[0] }
+	tests := []struct {
+		name       string
+		connective Connective
+		want       string
+	}{
+		{"keyword", Connective{Name: "and", Arity: 1, Definition: definition}, "not a letter name"},
+		{"spaces", Connective{Name: "my op", Arity: 1, Definition: definition}, "not a letter name"},
+		{"constant", Connective{Name: "T", Arity: 1, Definition: definition}, "not a letter name"},
+		{"unicode symbol", Connective{Name: "nand2", Symbols: []string{"⊼"}, Arity: 2, Definition: definition},
+			`"⊼" cannot be the name or a symbol of a connective, since it is not a letter name: connectives are ` +
+				"parsed in prefix form, like nand2(p, q)"},
+		{"infix symbol", Connective{Name: "~&", Arity: 2, Definition: definition}, "not a letter name"},
+		{"built-in operator", Connective{Name: "imp", Symbols: []string{"=>"}, Arity: 2, Definition: definition},
+			"not a letter name"},
+		{"registered name", Connective{Name: maj, Arity: 1, Definition: definition}, "already the name"},
+		{"registered symbol", Connective{Name: "majority", Arity: 1, Definition: definition}, "already the name"},
+		{"repeated symbol", Connective{Name: "id", Symbols: []string{"id"}, Arity: 1, Definition: definition},
+			"already the name"},
+		{"no operands", Connective{Name: "id", Definition: definition}, "must be positive"},
+		{"no definition", Connective{Name: "id", Arity: 1}, "no definition"},
+		{"literal rule", Connective{Name: "id", Arity: 1, Definition: definition,
+			Negative: Decomposition{Class: LiteralClass, Components: func([]Formula) []Formula { return nil }}},
+			"must be alpha or beta"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterConnective(tt.connective)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("RegisterConnective(%s) error = %v, want %q", tt.connective.Name, err, tt.want)
+			}
+		})
+	}
+
+	if _, ok := LookupConnective("id"); ok {
+		t.Errorf("a connective that failed to register is in the registry")
+	}
+}
+
+func TestUnregisterConnective(t *testing.T) {
+	mustRegister(Connective{Name: "first", Symbols: []string{"fst"}, Arity: 2,
+		Definition: func(operands []Formula) Formula { return operands[0] }})
+	f := NewCustom("fst", letters.p, letters.q)
+
+	UnregisterConnective("fst") // only the name unregisters a connective.
+	if _, ok := LookupConnective("fst"); !ok {
+		t.Fatalf("UnregisterConnective removed a connective by its symbol")
+	}
+	UnregisterConnective("first")
+	for _, name := range []string{"first", "fst"} {
+		if _, ok := LookupConnective(name); ok {
+			t.Errorf("LookupConnective(%s) found an unregistered connective", name)
+		}
+	}
+
+	// the formulas built before keep working.
+	if got, _ := Eval(f, map[string]bool{"p": true}); !got {
+		t.Errorf("Eval(%v) = false after unregistering", f)
+	}
+	if _, err := ParseE("first(p, q)"); err == nil {
+		t.Errorf("ParseE parsed an unregistered connective")
+	}
+}
+
+func TestCustom(t *testing.T) {
+	p, q, r := letters.p, letters.q, letters.r
+	f := NewCustom(maj, p, NewNot(q), r)
+
+	if f.Name() != maj || f.Len() != 3 || !reflect.DeepEqual(f.Operands(), []Formula{p, NewNot(q), r}) {
+		t.Errorf("getters of %v = %s, %d, %v", f, f.Name(), f.Len(), f.Operands())
+	}
+	if got := NewCustom("majority", p, NewNot(q), r); got != f {
+		t.Errorf("NewCustom with a symbol = %v, want %v", got, f)
+	}
+	if got, want := f.String(), "maj(p, !q, r)"; got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+	if got, want := f.Definition(), Parse("p & !q | p & r | !q & r"); got != want {
+		t.Errorf("Definition() = %v, want %v", got, want)
+	}
+	if f.Class() != Beta || NewNot(f).Class() != Beta {
+		t.Errorf("classes of %v = %v and %v, want Beta", f, f.Class(), NewNot(f).Class())
+	}
+	// the default rules replace the formula with its definition.
+	if s := NewCustom(stroke, p, q); s.Class() != Alpha || NewNot(s).Class() != Alpha {
+		t.Errorf("classes of %v = %v and %v, want Alpha", s, s.Class(), NewNot(s).Class())
+	}
+
+	for _, tt := range []struct {
+		name string
+		fn   func()
+	}{
+		{"unknown connective", func() { NewCustom("unknown", p) }},
+		{"wrong arity", func() { NewCustom(maj, p, q) }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewCustom did not panic")
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
+
+func TestParse_Custom(t *testing.T) {
+	p, q, r := letters.p, letters.q, letters.r
+	tests := []struct {
+		input string
+		want  Formula
+	}{
+		{"maj(p, q, r)", NewCustom(maj, p, q, r)},
+		{"majority(p, q & r, !r) -> stroke(q, p)", NewImplies(NewCustom(maj, p, NewAnd(q, r), NewNot(r)),
+			NewCustom(stroke, q, p))},
+		{"maj | stroke", NewOr(NewLetter("maj"), NewLetter("stroke"))},
+		{"def maj(X) = !X; maj(p)", NewNot(p)},
+		{"def Vote(X) = maj(X, q, r); Vote(p)", NewCustom(maj, p, q, r)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Parse(tt.input); got != tt.want {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	var parseErr *ParseError
+	_, err := ParseE("maj(p, q)")
+	if !errors.As(err, &parseErr) || parseErr.Errors[0].Msg != "maj expects 3 arguments, found 2" {
+		t.Errorf(`ParseE("maj(p, q)") error = %v`, err)
+	}
+}
+
+func TestCustom_Semantics(t *testing.T) {
+	f := func(f Formula) bool {
+		definition := Transform(f, func(f Formula) Formula {
+			if c, ok := f.(Custom); ok {
+				return c.Definition()
+			}
+			return f
+		})
+		simplified, _ := Simplify(f, WithCoreConnectives())
+		equivalents := []Formula{f, ToNNF(f), NewNot(ToNNF(NewNot(f))), simplified}
+		for _, a := range assignments(f) {
+			want, _ := Eval(definition, a)
+			for _, g := range equivalents {
+				if got, _ := Eval(g, a); got != want {
+					t.Errorf("%v: %v under %v = %v, want %v", f, g, a, got, want)
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			operands := []Formula{GenerateRandom(r, r.Intn(3)+1), GenerateRandom(r, r.Intn(3)+1),
+				GenerateRandom(r, r.Intn(3)+1)}
+			values[0] = reflect.ValueOf(NewNot(NewCustom(stroke, NewCustom(maj, operands...), operands[0])))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
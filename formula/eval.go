@@ -0,0 +1,200 @@
+package formula
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TruthValue is the value of a formula in a three-valued logic: a formula can be true, false or unknown when its value
+// depends on letters that are not assigned.
+type TruthValue int
+
+const (
+	False TruthValue = iota
+	True
+	Unknown
+)
+
+func (v TruthValue) String() string {
+	switch v {
+	case False:
+		return "false"
+	case True:
+		return "true"
+	case Unknown:
+		return "unknown"
+	default:
+		panic(fmt.Errorf("unknown TruthValue %d", int(v)))
+	}
+}
+
+// truthValueOf converts a bool into the corresponding TruthValue.
+func truthValueOf(b bool) TruthValue {
+	if b {
+		return True
+	}
+	return False
+}
+
+func (v TruthValue) not() TruthValue {
+	switch v {
+	case True:
+		return False
+	case False:
+		return True
+	default:
+		return Unknown
+	}
+}
+
+func (v TruthValue) and(w TruthValue) TruthValue {
+	if v == False || w == False {
+		return False
+	}
+	if v == True && w == True {
+		return True
+	}
+	return Unknown
+}
+
+func (v TruthValue) or(w TruthValue) TruthValue {
+	if v == True || w == True {
+		return True
+	}
+	if v == False && w == False {
+		return False
+	}
+	return Unknown
+}
+
+func (v TruthValue) equals(w TruthValue) TruthValue {
+	if v == Unknown || w == Unknown {
+		return Unknown
+	}
+	return truthValueOf(v == w)
+}
+
+// EvalError is the error returned by Eval when the value of a formula depends on letters that are not assigned.
+type EvalError struct {
+	Formula Formula
+	Missing []string // Missing contains the letters of the formula without a value, in order of first occurrence.
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("cannot evaluate %v: no value for %s", e.Formula, strings.Join(e.Missing, ", "))
+}
+
+// PartialEval evaluates the formula under a partial assignment using Kleene's strong three-valued logic.
+// Letters missing from the assignment are Unknown, and a formula is Unknown only if its value depends on them:
+// for example (p | q) is True when p is true, even if q is missing.
+// Note that some formulas, like (p | !p), are Unknown even though they are true under every completion of the assignment.
+func PartialEval(formula Formula, assignment map[string]bool) TruthValue {
+	switch f := plain(formula).(type) {
+	case Letter:
+		if value, ok := assignment[f.Name()]; ok {
+			return truthValueOf(value)
+		}
+		return Unknown
+	case Top:
+		return True
+	case Bottom:
+		return False
+	case Not:
+		return PartialEval(f.Negated(), assignment).not()
+	case Binary:
+		left, right := PartialEval(f.Left(), assignment), PartialEval(f.Right(), assignment)
+
+		switch f.Op() {
+		case And:
+			return left.and(right)
+		case Or:
+			return left.or(right)
+		case Implies:
+			return left.not().or(right)
+		case Nand:
+			return left.and(right).not()
+		case Nor:
+			return left.or(right).not()
+		case Biconditional:
+			return left.equals(right)
+		case Xor:
+			return left.equals(right).not()
+		case ConverseImplies:
+			return left.or(right.not())
+		case NonImplies:
+			return left.and(right.not())
+		default:
+			panic(fmt.Errorf("unknown operator %v", f.Op()))
+		}
+	case NAry:
+		res := truthValueOf(f.Op() == And) // the value of the empty conjunction, disjunction or exclusive disjunction.
+		for _, operand := range f.Operands() {
+			switch value := PartialEval(operand, assignment); f.Op() {
+			case And:
+				res = res.and(value)
+			case Or:
+				res = res.or(value)
+			default:
+				res = res.equals(value).not()
+			}
+		}
+		return res
+	case Ite:
+		then, els := PartialEval(f.Then(), assignment), PartialEval(f.Else(), assignment)
+		switch PartialEval(f.Condition(), assignment) {
+		case True:
+			return then
+		case False:
+			return els
+		default:
+			// the value does not depend on the condition if the two branches have the same known value.
+			if then == els {
+				return then
+			}
+			return Unknown
+		}
+	case Cardinality:
+		trues, unknown := 0, 0
+		for _, operand := range f.Operands() {
+			switch PartialEval(operand, assignment) {
+			case True:
+				trues++
+			case Unknown:
+				unknown++
+			}
+		}
+		if holds, decided := decide(f.Kind(), f.Bound(), trues, unknown); decided {
+			return truthValueOf(holds)
+		}
+		return Unknown
+	case Custom:
+		return PartialEval(f.Definition(), assignment)
+	default:
+		panic(fmt.Errorf("%v: %T is not a Formula", f, f))
+	}
+}
+
+// Eval evaluates the formula under the given assignment.
+// Letters that do not affect the value of the formula can be missing from the assignment, as in the assignments
+// produced by the tableaux; if the value depends on a missing letter it returns an *EvalError.
+func Eval(formula Formula, assignment map[string]bool) (bool, error) {
+	switch PartialEval(formula, assignment) {
+	case True:
+		return true, nil
+	case False:
+		return false, nil
+	default:
+		return false, &EvalError{Formula: formula, Missing: missingLetters(formula, assignment)}
+	}
+}
+
+// missingLetters returns the letters of the formula that are not in the assignment, in order of first occurrence.
+func missingLetters(formula Formula, assignment map[string]bool) []string {
+	var res []string
+	for _, name := range Letters(formula) {
+		if _, ok := assignment[name]; !ok {
+			res = append(res, name)
+		}
+	}
+	return res
+}
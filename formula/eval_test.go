@@ -0,0 +1,212 @@
+package formula
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestPartialEval_Operators(t *testing.T) {
+	values := []TruthValue{True, False, Unknown}
+
+	// want[op][i][j] is the value of (p op q) where p has the i-th value and q the j-th one of values.
+	want := map[Operator][3][3]TruthValue{
+		And:             {{True, False, Unknown}, {False, False, False}, {Unknown, False, Unknown}},
+		Or:              {{True, True, True}, {True, False, Unknown}, {True, Unknown, Unknown}},
+		Implies:         {{True, False, Unknown}, {True, True, True}, {True, Unknown, Unknown}},
+		Nand:            {{False, True, Unknown}, {True, True, True}, {Unknown, True, Unknown}},
+		Nor:             {{False, False, False}, {False, True, Unknown}, {False, Unknown, Unknown}},
+		Biconditional:   {{True, False, Unknown}, {False, True, Unknown}, {Unknown, Unknown, Unknown}},
+		Xor:             {{False, True, Unknown}, {True, False, Unknown}, {Unknown, Unknown, Unknown}},
+		ConverseImplies: {{True, True, True}, {False, True, Unknown}, {Unknown, True, Unknown}},
+		NonImplies:      {{False, True, Unknown}, {False, False, False}, {False, Unknown, Unknown}},
+	}
+
+	for op, table := range want {
+		for i, left := range values {
+			for j, right := range values {
+				t.Run(left.String()+" "+op.String()+" "+right.String(), func(t *testing.T) {
+					assignment := map[string]bool{}
+					if left != Unknown {
+						assignment["p"] = left == True
+					}
+					if right != Unknown {
+						assignment["q"] = right == True
+					}
+
+					f := NewBinary(letters.p, letters.q, op)
+					if got := PartialEval(f, assignment); got != table[i][j] {
+						t.Errorf("PartialEval(%v, %v) = %v, want %v", f, assignment, got, table[i][j])
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		name       string
+		formula    Formula
+		assignment map[string]bool
+		want       bool
+		missing    []string
+	}{
+		{
+			name:       "letter",
+			formula:    letters.p,
+			assignment: map[string]bool{"p": true},
+			want:       true,
+		},
+		{
+			name:       "negation",
+			formula:    NewNot(letters.p),
+			assignment: map[string]bool{"p": true},
+			want:       false,
+		},
+		{
+			name:       "constants",
+			formula:    NewImplies(NewTop(), NewBottom()),
+			assignment: map[string]bool{},
+			want:       false,
+		},
+		{
+			name:       "irrelevant missing letter",
+			formula:    NewAnd(NewOr(letters.p, NewNot(letters.q)), NewNot(letters.q)),
+			assignment: map[string]bool{"q": false},
+			want:       true,
+		},
+		{
+			name:       "relevant missing letter",
+			formula:    NewAnd(NewOr(letters.p, letters.q), NewNot(letters.r)),
+			assignment: map[string]bool{"r": false},
+			missing:    []string{"p", "q"},
+		},
+		{
+			name:       "n-ary xor",
+			formula:    NewNAry(Xor, letters.p, letters.q, NewTop()),
+			assignment: map[string]bool{"p": true, "q": true},
+			want:       true,
+		},
+		{
+			name:       "if-then-else",
+			formula:    NewIte(letters.p, letters.q, letters.r),
+			assignment: map[string]bool{"p": false, "r": true},
+			want:       true,
+		},
+		{
+			name:       "if-then-else with equal branches",
+			formula:    NewIte(letters.p, letters.q, NewNot(letters.r)),
+			assignment: map[string]bool{"q": true, "r": false},
+			want:       true,
+		},
+		{
+			name:       "if-then-else without its condition",
+			formula:    NewIte(letters.p, letters.q, letters.r),
+			assignment: map[string]bool{"q": true},
+			missing:    []string{"p", "r"},
+		},
+		{
+			name:       "cardinality",
+			formula:    NewExactly(1, letters.p, letters.q, NewNot(letters.r)),
+			assignment: map[string]bool{"p": false, "q": true, "r": true},
+			want:       true,
+		},
+		{
+			name:       "cardinality decided before its operands",
+			formula:    NewAtMost(1, letters.p, letters.q, letters.r),
+			assignment: map[string]bool{"p": true, "r": true},
+			want:       false,
+		},
+		{
+			name:       "cardinality without an operand",
+			formula:    NewAtLeast(2, letters.p, letters.q, letters.r),
+			assignment: map[string]bool{"p": true, "q": false},
+			missing:    []string{"r"},
+		},
+		{
+			name:       "excluded middle is not decided without its letter",
+			formula:    NewOr(letters.p, NewNot(letters.p)),
+			assignment: map[string]bool{},
+			missing:    []string{"p"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Eval(tt.formula, tt.assignment)
+
+			if tt.missing != nil {
+				var evalErr *EvalError
+				if !errors.As(err, &evalErr) {
+					t.Fatalf("expected an *EvalError, got %v", err)
+				}
+				if !slices.Equal(evalErr.Missing, tt.missing) {
+					t.Errorf("missing = %v, want %v", evalErr.Missing, tt.missing)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Eval(%v, %v) = %v, want %v", tt.formula, tt.assignment, got, tt.want)
+			}
+		})
+	}
+}
+
+// randomAssignment assigns a random value to some of the letters used by GenerateRandom.
+func randomAssignment(r *rand.Rand) map[string]bool {
+	res := make(map[string]bool)
+	for _, name := range "pqrstuvwxyz" {
+		if r.Intn(3) != 0 {
+			res[string(name)] = r.Intn(2) == 0
+		}
+	}
+	return res
+}
+
+// TestPartialEval_Completions checks that when PartialEval returns a known value, every completion of the assignment
+// gives the same value.
+func TestPartialEval_Completions(t *testing.T) {
+	f := func(f Formula, partial map[string]bool) bool {
+		value := PartialEval(f, partial)
+		if value == Unknown {
+			return true
+		}
+
+		missing := missingLetters(f, partial)
+		for mask := 0; mask < 1<<len(missing); mask++ {
+			complete := make(map[string]bool)
+			for k, v := range partial {
+				complete[k] = v
+			}
+			for i, name := range missing {
+				complete[name] = mask&(1<<i) != 0
+			}
+
+			got, err := Eval(f, complete)
+			if err != nil || truthValueOf(got) != value {
+				t.Errorf("%v: partial value %v, complete value %v (%v) under %v", f, value, got, err, complete)
+				return false
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(generateRandomNAry(r, r.Intn(20)))
+			values[1] = reflect.ValueOf(randomAssignment(r))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
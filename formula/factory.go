@@ -0,0 +1,291 @@
+package formula
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ID is the identifier given by a Factory to an interned formula. IDs are small integers assigned in order of
+// creation starting from 0, so they can be used as indexes of slices.
+type ID uint32
+
+// Interned is a formula interned by a Factory: structurally equal formulas interned by the same factory are the same
+// *Interned, so they can be compared with == and used as map keys in constant time, without walking the formula.
+//
+// Interned implements Formula, and the functions of this package accept it, even as an operand of a plain formula:
+// they work on the plain formula returned by Formula, except Complement that returns an interned formula.
+type Interned struct {
+	id       ID
+	formula  Formula
+	operands []*Interned
+	factory  *Factory
+}
+
+// ID returns the identifier of the formula in its factory.
+func (n *Interned) ID() ID {
+	return n.id
+}
+
+// Formula returns the plain formula, built with the usual constructors, that n represents.
+func (n *Interned) Formula() Formula {
+	return n.formula
+}
+
+// Operands returns the interned operands of the formula, in the order returned by the package function Operands.
+func (n *Interned) Operands() []*Interned {
+	return n.operands
+}
+
+// Factory returns the factory that interned the formula.
+func (n *Interned) Factory() *Factory {
+	return n.factory
+}
+
+// Complement returns the interned complement of the formula: its operand if it is a negation, otherwise its negation.
+func (n *Interned) Complement() *Interned {
+	if _, ok := n.formula.(Not); ok {
+		return n.operands[0]
+	}
+	return n.factory.Not(n)
+}
+
+// Class returns the Classification of the formula.
+func (n *Interned) Class() Classification {
+	return n.formula.Class()
+}
+
+func (n *Interned) String() string {
+	return n.formula.String()
+}
+
+// nodeKind is the kind of the root of an interned formula.
+type nodeKind int
+
+const (
+	letterNode nodeKind = iota
+	topNode
+	bottomNode
+	notNode
+	binaryNode
+	naryNode
+	iteNode
+	cardinalityNode
+	customNode
+)
+
+// internKey identifies a formula by its root and the IDs of its operands, which is enough since the operands are
+// already interned. The IDs of the operands of n-ary and if-then-else formulas, of cardinality constraints and of
+// registered connectives are encoded in ids.
+type internKey struct {
+	kind        nodeKind
+	op          Operator
+	name        string
+	left, right ID
+	ids         string
+	cardinality CardinalityKind
+	bound       int
+	connective  *Connective
+}
+
+// Factory interns formulas (hash-consing): every structurally distinct formula gets a single *Interned with a unique
+// ID, and its subformulas are shared. A Factory is safe for concurrent use.
+type Factory struct {
+	mu    sync.Mutex
+	nodes map[internKey]*Interned
+	byID  []*Interned
+}
+
+// NewFactory returns an empty Factory.
+func NewFactory() *Factory {
+	return &Factory{nodes: make(map[internKey]*Interned)}
+}
+
+// Len returns the number of distinct formulas interned by the factory.
+func (fac *Factory) Len() int {
+	fac.mu.Lock()
+	defer fac.mu.Unlock()
+	return len(fac.byID)
+}
+
+// Get returns the formula with the given ID. It panics if the factory has not interned a formula with that ID.
+func (fac *Factory) Get(id ID) *Interned {
+	fac.mu.Lock()
+	defer fac.mu.Unlock()
+	if int(id) >= len(fac.byID) {
+		panic(fmt.Errorf("no formula with ID %d", id))
+	}
+	return fac.byID[id]
+}
+
+// intern returns the formula with the given key, creating it if it does not exist. The operands are the ones used by
+// the kind of the key.
+func (fac *Factory) intern(key internKey, operands ...*Interned) *Interned {
+	for _, operand := range operands {
+		if operand.factory != fac {
+			panic(fmt.Errorf("the operands of a formula must be interned by the same factory"))
+		}
+	}
+
+	fac.mu.Lock()
+	defer fac.mu.Unlock()
+
+	if n, ok := fac.nodes[key]; ok {
+		return n
+	}
+
+	var formula Formula
+	switch key.kind {
+	case letterNode:
+		formula = NewLetter(key.name)
+	case topNode:
+		formula = NewTop()
+	case bottomNode:
+		formula = NewBottom()
+	case notNode:
+		formula = NewNot(operands[0].formula)
+	case binaryNode:
+		formula = NewBinary(operands[0].formula, operands[1].formula, key.op)
+	case naryNode:
+		formula = NewNAry(key.op, plainFormulas(operands)...)
+	case iteNode:
+		formula = NewIte(operands[0].formula, operands[1].formula, operands[2].formula)
+	case cardinalityNode:
+		formula = NewCardinality(key.cardinality, key.bound, plainFormulas(operands)...)
+	case customNode:
+		formula = newCustom(key.connective, plainFormulas(operands))
+	}
+
+	n := &Interned{id: ID(len(fac.byID)), formula: formula, operands: operands, factory: fac}
+	fac.nodes[key] = n
+	fac.byID = append(fac.byID, n)
+	return n
+}
+
+// Letter returns the interned letter with the given name.
+func (fac *Factory) Letter(name string) *Interned {
+	return fac.intern(internKey{kind: letterNode, name: name})
+}
+
+// Top returns the interned constant ⊤.
+func (fac *Factory) Top() *Interned {
+	return fac.intern(internKey{kind: topNode})
+}
+
+// Bottom returns the interned constant ⊥.
+func (fac *Factory) Bottom() *Interned {
+	return fac.intern(internKey{kind: bottomNode})
+}
+
+// Not returns the interned negation of n. It panics if n was interned by another factory.
+func (fac *Factory) Not(n *Interned) *Interned {
+	return fac.intern(internKey{kind: notNode, left: n.id}, n)
+}
+
+// Binary returns the interned binary formula with the given operator and operands.
+// It panics if the operands were interned by another factory.
+func (fac *Factory) Binary(op Operator, left, right *Interned) *Interned {
+	return fac.intern(internKey{kind: binaryNode, op: op, left: left.id, right: right.id}, left, right)
+}
+
+// NAry returns the interned n-ary formula with the given operator and operands. It panics if the operands were
+// interned by another factory, or if they cannot form an n-ary formula, as NewNAry does.
+func (fac *Factory) NAry(op Operator, operands ...*Interned) *Interned {
+	return fac.intern(internKey{kind: naryNode, op: op, ids: idsOf(operands)}, slices.Clone(operands)...)
+}
+
+// Ite returns the interned if-then-else formula with the given operands.
+// It panics if the operands were interned by another factory.
+func (fac *Factory) Ite(condition, then, els *Interned) *Interned {
+	operands := []*Interned{condition, then, els}
+	return fac.intern(internKey{kind: iteNode, ids: idsOf(operands)}, operands...)
+}
+
+// Cardinality returns the interned cardinality constraint with the given kind, bound and operands. It panics if the
+// operands were interned by another factory, or if they cannot form a constraint, as NewCardinality does.
+func (fac *Factory) Cardinality(kind CardinalityKind, bound int, operands ...*Interned) *Interned {
+	key := internKey{kind: cardinalityNode, cardinality: kind, bound: bound, ids: idsOf(operands)}
+	return fac.intern(key, slices.Clone(operands)...)
+}
+
+// Custom returns the interned formula joining the operands with the registered connective with the given name or
+// symbol. It panics if the operands were interned by another factory, or if they cannot form the formula, as
+// NewCustom does.
+func (fac *Factory) Custom(name string, operands ...*Interned) *Interned {
+	return fac.custom(NewCustom(name, plainFormulas(operands)...).Connective(), operands)
+}
+
+func (fac *Factory) custom(c *Connective, operands []*Interned) *Interned {
+	return fac.intern(internKey{kind: customNode, connective: c, ids: idsOf(operands)}, slices.Clone(operands)...)
+}
+
+// plainFormulas returns the plain formulas of the operands.
+func plainFormulas(operands []*Interned) []Formula {
+	res := make([]Formula, len(operands))
+	for i, operand := range operands {
+		res[i] = operand.formula
+	}
+	return res
+}
+
+// idsOf encodes the IDs of the operands in a string.
+func idsOf(operands []*Interned) string {
+	ids := make([]byte, 0, 4*len(operands))
+	for _, operand := range operands {
+		ids = binary.LittleEndian.AppendUint32(ids, uint32(operand.id))
+	}
+	return string(ids)
+}
+
+// Intern returns the interned version of the formula, interning all its subformulas.
+// Formulas already interned by this factory are returned as they are.
+func (fac *Factory) Intern(formula Formula) *Interned {
+	switch f := formula.(type) {
+	case *Interned:
+		if f.factory == fac {
+			return f
+		}
+		return fac.Intern(f.formula)
+	case Letter:
+		return fac.Letter(f.Name())
+	case Top:
+		return fac.Top()
+	case Bottom:
+		return fac.Bottom()
+	case Not:
+		return fac.Not(fac.Intern(f.Negated()))
+	case Binary:
+		return fac.Binary(f.Op(), fac.Intern(f.Left()), fac.Intern(f.Right()))
+	case NAry:
+		operands := make([]*Interned, f.Len())
+		for i, operand := range f.Operands() {
+			operands[i] = fac.Intern(operand)
+		}
+		return fac.NAry(f.Op(), operands...)
+	case Ite:
+		return fac.Ite(fac.Intern(f.Condition()), fac.Intern(f.Then()), fac.Intern(f.Else()))
+	case Cardinality:
+		operands := make([]*Interned, f.Len())
+		for i, operand := range f.Operands() {
+			operands[i] = fac.Intern(operand)
+		}
+		return fac.Cardinality(f.Kind(), f.Bound(), operands...)
+	case Custom:
+		operands := make([]*Interned, f.Len())
+		for i, operand := range f.Operands() {
+			operands[i] = fac.Intern(operand)
+		}
+		return fac.custom(f.Connective(), operands)
+	default:
+		panic(fmt.Errorf("%v: %T is not a Formula", f, f))
+	}
+}
+
+// plain returns the plain formula of an interned one, and any other formula as it is.
+func plain(formula Formula) Formula {
+	if n, ok := formula.(*Interned); ok {
+		return n.formula
+	}
+	return formula
+}
@@ -0,0 +1,187 @@
+package formula
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestFactory_Intern(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int // want is the number of distinct subformulas.
+	}{
+		{"letter", "p", 1},
+		{"constants", "T & F", 3},
+		{"shared subformula", "(p & q) | !(p & q)", 5},
+		{"repeated letter", "p -> (p -> p)", 3},
+		{"double negation", "!!p", 3},
+		{"n-ary", "p & q & r | p & q & r | (p & q) & r", 7},
+		{"if-then-else", "ite(p, q, r) & ite(p, q, r) & ite(q, p, r)", 6},
+		{"cardinality", "atmost(1; p, q) & atmost(1; p, q) & atleast(1; p, q)", 5},
+		{"registered connective", "maj(p, q, r) | majority(p, q, r) | stroke(p, q)", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fac := NewFactory()
+			n := fac.Intern(Parse(tt.input))
+
+			if got := fac.Len(); got != tt.want {
+				t.Errorf("Len() = %v, want %v", got, tt.want)
+			}
+			if n.Formula() != Parse(tt.input) {
+				t.Errorf("Intern(%v).Formula() = %v", tt.input, n.Formula())
+			}
+			if again := fac.Intern(Parse(tt.input)); again != n || fac.Len() != tt.want {
+				t.Errorf("interning %v again gives a new formula", tt.input)
+			}
+			if fac.Intern(n) != n {
+				t.Errorf("interning an interned formula gives a new formula")
+			}
+		})
+	}
+}
+
+func TestFactory_Sharing(t *testing.T) {
+	fac := NewFactory()
+	n := fac.Intern(Parse("(p & q) | !(p & q)"))
+	left, right := n.Operands()[0], n.Operands()[1]
+
+	if right.Operands()[0] != left {
+		t.Errorf("the operands of %v are not shared", n)
+	}
+	if fac.Binary(And, fac.Letter("p"), fac.Letter("q")) != left {
+		t.Errorf("the constructors do not return the interned formula")
+	}
+	if right.Complement() != left || left.Complement() != right {
+		t.Errorf("the complement of %v is not %v", left, right)
+	}
+	if Complement(left) != right {
+		t.Errorf("Complement(%v) = %v, want %v", left, Complement(left), right)
+	}
+}
+
+func TestFactory_Get(t *testing.T) {
+	fac := NewFactory()
+	fac.Intern(Parse("(p -> q) <-> (q -> F)"))
+
+	for i := 0; i < fac.Len(); i++ {
+		if n := fac.Get(ID(i)); n.ID() != ID(i) {
+			t.Errorf("Get(%d).ID() = %d", i, n.ID())
+		}
+	}
+	// operands are interned before the formulas that contain them.
+	if root := fac.Get(ID(fac.Len() - 1)); root.Formula() != Parse("(p -> q) <-> (q -> F)") {
+		t.Errorf("the last interned formula is %v", root)
+	}
+}
+
+func TestFactory_OtherFactory(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("mixing factories did not panic")
+		}
+	}()
+	NewFactory().Not(NewFactory().Letter("p"))
+}
+
+// TestInterned_Formula checks that interned formulas behave like the plain ones with the functions of the package
+// that accept them, and that structurally equal formulas are interned to the same formula.
+func TestInterned_Formula(t *testing.T) {
+	f := func(f Formula) bool {
+		fac := NewFactory()
+		n := fac.Intern(f)
+
+		if n.Formula() != f || n.String() != f.String() || n.Class() != f.Class() {
+			t.Errorf("%v: interned as %v", f, n.Formula())
+			return false
+		}
+		if fac.Intern(Parse(f.String())) != n {
+			t.Errorf("%v: parsing and interning it again gives a new formula", f)
+			return false
+		}
+		if Complement(n).(*Interned).Formula() != Complement(f) {
+			t.Errorf("%v: the complement is %v", f, Complement(n))
+			return false
+		}
+		if IsLiteral(n) != IsLiteral(f) || IsLiteral(f) && AsLiteral(n) != AsLiteral(f) {
+			t.Errorf("%v: the interned formula is a different literal", f)
+			return false
+		}
+		return IsConstant(n) == IsConstant(f)
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(generateRandomNAry(r, r.Intn(30)))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestInterned_EntryPoints checks that the functions of the package give the same results with an interned formula,
+// or with a plain formula with interned operands, as with the plain formula.
+func TestInterned_EntryPoints(t *testing.T) {
+	input := "(ite(p, q, !r) & atmost(1; p, q, s) | !(p -> T) & maj(p, q, r)) <-> (p ^ q ^ !s)"
+	f := Parse(input)
+	fac := NewFactory()
+	n := fac.Intern(f)
+	nested := NewBiconditional(fac.Intern(f.(Binary).Left()), f.(Binary).Right())
+
+	tests := []struct {
+		name string
+		fn   func(f Formula) any
+	}{
+		{"Eval", func(f Formula) any {
+			res, err := Eval(f, map[string]bool{"p": true, "q": false, "r": true, "s": false})
+			return fmt.Sprint(res, err)
+		}},
+		{"PartialEval", func(f Formula) any { return PartialEval(f, map[string]bool{"p": false}) }},
+		{"Visit", func(f Formula) any { return Visit(f, printer{}) }},
+		{"Operands", func(f Formula) any { return fmt.Sprint(Operands(f)) }},
+		{"Subformulas", func(f Formula) any { return fmt.Sprint(slices.Collect(Subformulas(f, PostOrder))) }},
+		{"Letters", func(f Formula) any { return fmt.Sprint(Letters(f)) }},
+		{"Size", func(f Formula) any { return Size(f) }},
+		{"Depth", func(f Formula) any { return Depth(f) }},
+		{"OperatorCounts", func(f Formula) any { return fmt.Sprint(OperatorCounts(f)) }},
+		{"Transform", func(f Formula) any { return Transform(f, func(f Formula) Formula { return f }) }},
+		{"Substitute", func(f Formula) any { return Substitute(f, map[string]Formula{"p": NewTop()}) }},
+		{"Canonicalize", func(f Formula) any { return fmt.Sprint(Canonicalize(f)) }},
+		{"Simplify", func(f Formula) any { return fmt.Sprint(Simplify(f, WithCoreConnectives())) }},
+		{"ToNNF", func(f Formula) any { return ToNNF(f) }},
+		{"ToCNF", func(f Formula) any { return fmt.Sprint(ToCNF(f, 0)) }},
+		{"ToDNF", func(f Formula) any { return fmt.Sprint(ToDNF(f, 0)) }},
+		{"Tseitin", func(f Formula) any { return fmt.Sprint(Tseitin(f).Clauses) }},
+		{"PlaistedGreenbaum", func(f Formula) any { return fmt.Sprint(PlaistedGreenbaum(f).Clauses) }},
+		{"CompileCardinality", func(f Formula) any { return fmt.Sprint(CompileCardinality(f, SequentialCounter)) }},
+		{"MarshalJSON", func(f Formula) any {
+			data, err := MarshalJSON(f)
+			return fmt.Sprint(string(data), err)
+		}},
+		{"Compare", func(f Formula) any { return Compare(f, Parse("p & q")) }},
+		{"Hash", func(f Formula) any { return Hash(f) }},
+		{"Abbreviate", func(f Formula) any {
+			return Abbreviations{Parse("p -> T"): {Name: "A"}}.Abbreviate(f, nil)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := fmt.Sprint(tt.fn(f))
+			if got := fmt.Sprint(tt.fn(n)); got != want {
+				t.Errorf("%s(%v) = %v with the interned formula, want %v", tt.name, input, got, want)
+			}
+			if got := fmt.Sprint(tt.fn(nested)); got != want {
+				t.Errorf("%s(%v) = %v with an interned operand, want %v", tt.name, input, got, want)
+			}
+		})
+	}
+}
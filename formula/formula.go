@@ -3,6 +3,7 @@ package formula
 import (
 	"fmt"
 	"math/rand"
+	"strings"
 )
 
 // Classification represent the classification of a formula: it can be alpha, beta or literal.
@@ -57,6 +58,40 @@ func (l Letter) String() string {
 	return l.name
 }
 
+// Top is the truth constant ⊤, which is true under every assignment.
+type Top struct{}
+
+// NewTop returns the truth constant ⊤.
+func NewTop() Top {
+	return Top{}
+}
+
+// Class returns the Classification of the formula.
+func (t Top) Class() Classification {
+	return LiteralClass
+}
+
+func (t Top) String() string {
+	return "⊤"
+}
+
+// Bottom is the truth constant ⊥, which is false under every assignment.
+type Bottom struct{}
+
+// NewBottom returns the truth constant ⊥.
+func NewBottom() Bottom {
+	return Bottom{}
+}
+
+// Class returns the Classification of the formula.
+func (b Bottom) Class() Classification {
+	return LiteralClass
+}
+
+func (b Bottom) String() string {
+	return "⊥"
+}
+
 // Not is a negation of a Formula.
 type Not struct {
 	negated Formula
@@ -74,13 +109,17 @@ func (n Not) Negated() Formula {
 
 // Class returns the Classification of the formula.
 func (n Not) Class() Classification {
-	switch inner := n.negated.(type) {
-	case Letter:
+	switch inner := plain(n.negated).(type) {
+	case Letter, Top, Bottom:
 		return LiteralClass
 	case Not:
 		return Alpha
-	case Binary:
+	case Binary, NAry:
 		return inner.Class() * -1
+	case Ite, Cardinality:
+		return Beta // the negation of ite(C, A, B) is ite(C, !A, !B), a constraint is like the ite of its Branch.
+	case Custom:
+		return inner.Connective().Decomposition(true).Class
 
 	default:
 		panic(fmt.Errorf("%v: %T is not a Formula", inner, inner))
@@ -104,6 +143,8 @@ const (
 	Nor
 	Biconditional
 	Xor
+	ConverseImplies
+	NonImplies
 )
 
 func (o Operator) String() string {
@@ -122,6 +163,10 @@ func (o Operator) String() string {
 		return "<->"
 	case Xor:
 		return "^"
+	case ConverseImplies:
+		return "<-"
+	case NonImplies:
+		return "!->"
 	default:
 		panic(fmt.Errorf("unknown operator type %T", o)) // unreachable
 	}
@@ -188,10 +233,21 @@ func NewXor(left, right Formula) Binary {
 	return NewBinary(left, right, Xor)
 }
 
+// NewConverseImplies returns a new Binary formula representing the implication from right to left.
+func NewConverseImplies(left, right Formula) Binary {
+	return NewBinary(left, right, ConverseImplies)
+}
+
+// NewNonImplies returns a new Binary formula representing the non-implication of left and right, which is true when
+// left is true and right is false.
+func NewNonImplies(left, right Formula) Binary {
+	return NewBinary(left, right, NonImplies)
+}
+
 // Class returns the Classification of the formula.
 func (b Binary) Class() Classification {
 	switch b.op {
-	case And, Nor, Biconditional:
+	case And, Nor, Biconditional, NonImplies:
 		return Alpha
 	default:
 		return Beta
@@ -204,20 +260,413 @@ func (b Binary) String() string {
 	return res
 }
 
+// NAry is the conjunction, the disjunction or the exclusive disjunction of two or more formulas, like (p & q & r).
+// It is equivalent to a chain of Binary formulas with the same operator, but the tableaux expand conjunctions and
+// disjunctions at once: an n-ary conjunction adds all its conjuncts to the branch and an n-ary disjunction splits the
+// branch in one branch for every disjunct. An n-ary exclusive disjunction is true when an odd number of its operands
+// is true.
+type NAry struct {
+	op       Operator
+	len      int
+	operands operandList
+}
+
+// operandList is an immutable list of formulas. Unlike a slice it can be compared with ==, so that NAry formulas can
+// be compared and used as map keys like the other formulas.
+type operandList struct {
+	first Formula
+	rest  any // rest is nil or the operandList of the following operands.
+}
+
+// NewNAry returns the n-ary formula joining the operands with op.
+// It panics if op is not And, Or or Xor, or if there are fewer than two operands.
+func NewNAry(op Operator, operands ...Formula) NAry {
+	if op != And && op != Or && op != Xor {
+		panic(fmt.Errorf("%v is not an n-ary operator", op))
+	}
+	if len(operands) < 2 {
+		panic(fmt.Errorf("an n-ary formula needs at least two operands, found %d", len(operands)))
+	}
+
+	return NAry{op: op, len: len(operands), operands: newOperandList(operands)}
+}
+
+func newOperandList(operands []Formula) operandList {
+	var list any
+	for i := len(operands) - 1; i >= 0; i-- {
+		list = operandList{first: operands[i], rest: list}
+	}
+	return list.(operandList)
+}
+
+// slice returns the first n formulas of the list.
+func (l operandList) slice(n int) []Formula {
+	res := make([]Formula, 0, n)
+	for list := any(l); list != nil && len(res) < n; {
+		l := list.(operandList)
+		res = append(res, l.first)
+		list = l.rest
+	}
+	return res
+}
+
+// Conjunction returns the conjunction of the operands as the parser reads it: ⊤ if there are none, the operand itself
+// if there is only one, a Binary formula if there are two and an NAry formula otherwise.
+func Conjunction(operands ...Formula) Formula {
+	return junctionOf(And, NewTop(), operands)
+}
+
+// Disjunction returns the disjunction of the operands as the parser reads it: ⊥ if there are none, the operand itself
+// if there is only one, a Binary formula if there are two and an NAry formula otherwise.
+func Disjunction(operands ...Formula) Formula {
+	return junctionOf(Or, NewBottom(), operands)
+}
+
+// Parity returns the exclusive disjunction of the operands as the parser reads it: ⊥ if there are none, the operand
+// itself if there is only one, a Binary formula if there are two and an NAry formula otherwise.
+func Parity(operands ...Formula) Formula {
+	return junctionOf(Xor, NewBottom(), operands)
+}
+
+func junctionOf(op Operator, empty Formula, operands []Formula) Formula {
+	switch len(operands) {
+	case 0:
+		return empty
+	case 1:
+		return operands[0]
+	case 2:
+		return NewBinary(operands[0], operands[1], op)
+	default:
+		return NewNAry(op, operands...)
+	}
+}
+
+// Op returns the Operator of the formula, And, Or or Xor.
+func (n NAry) Op() Operator {
+	return n.op
+}
+
+// Len returns the number of operands of the formula.
+func (n NAry) Len() int {
+	return n.len
+}
+
+// Operands returns the operands of the formula, from left to right.
+func (n NAry) Operands() []Formula {
+	return n.operands.slice(n.len)
+}
+
+// Class returns the Classification of the formula: a conjunction is an alpha formula, a disjunction and an exclusive
+// disjunction are beta formulas.
+func (n NAry) Class() Classification {
+	if n.op == And {
+		return Alpha
+	}
+	return Beta
+}
+
+func (n NAry) String() string {
+	strs := make([]string, n.len)
+	for i, operand := range n.Operands() {
+		strs[i] = operand.String()
+	}
+	return "(" + strings.Join(strs, " "+n.op.String()+" ") + ")"
+}
+
+// binary returns the chain of Binary formulas associated to the left that is equivalent to the n-ary formula, like
+// ((p ^ q) ^ r) for (p ^ q ^ r).
+func (n NAry) binary() Binary {
+	operands := n.Operands()
+	res := NewBinary(operands[0], operands[1], n.op)
+	for _, operand := range operands[2:] {
+		res = NewBinary(res, operand, n.op)
+	}
+	return res
+}
+
+// Ite is the if-then-else formula ite(C, A, B), which is equivalent to A when the condition C is true and to B when it
+// is false, that is ((C & A) | (!C & B)).
+type Ite struct {
+	condition, then, els Formula
+}
+
+// NewIte returns the formula that is equivalent to then if condition is true and to els otherwise.
+func NewIte(condition, then, els Formula) Ite {
+	return Ite{condition: condition, then: then, els: els}
+}
+
+// Condition returns the condition of the formula.
+func (i Ite) Condition() Formula {
+	return i.condition
+}
+
+// Then returns the formula that holds when the condition is true.
+func (i Ite) Then() Formula {
+	return i.then
+}
+
+// Else returns the formula that holds when the condition is false.
+func (i Ite) Else() Formula {
+	return i.els
+}
+
+// Class returns the Classification of the formula: an if-then-else is a beta formula, since it splits the branch in
+// the one where the condition is true and the one where it is false.
+func (i Ite) Class() Classification {
+	return Beta
+}
+
+func (i Ite) String() string {
+	return fmt.Sprintf("ite(%s, %s, %s)", i.condition, i.then, i.els)
+}
+
+// CardinalityKind is the kind of a cardinality constraint.
+type CardinalityKind int
+
+const (
+	AtMost  CardinalityKind = iota // AtMost is the constraint that at most k operands are true.
+	AtLeast                        // AtLeast is the constraint that at least k operands are true.
+	Exactly                        // Exactly is the constraint that exactly k operands are true.
+)
+
+func (k CardinalityKind) String() string {
+	switch k {
+	case AtMost:
+		return "atmost"
+	case AtLeast:
+		return "atleast"
+	case Exactly:
+		return "exactly"
+	default:
+		panic(fmt.Errorf("unknown cardinality kind %d", int(k)))
+	}
+}
+
+// Cardinality is a constraint on the number of true operands, like atmost(1; p, q, r), which is true when at most
+// one of p, q and r is true. It is equivalent to a formula with Binary operators, which is quadratic or larger in the
+// number of operands, but the tableaux branch on the first operand and change the bound of the constraint on the
+// others instead: see Branch.
+type Cardinality struct {
+	kind     CardinalityKind
+	bound    int
+	len      int
+	operands operandList
+}
+
+// NewCardinality returns the constraint of the given kind on the number of true operands.
+// It panics if the bound is negative or if there are no operands.
+func NewCardinality(kind CardinalityKind, bound int, operands ...Formula) Cardinality {
+	if bound < 0 {
+		panic(fmt.Errorf("the bound of a cardinality constraint cannot be negative, found %d", bound))
+	}
+	if len(operands) == 0 {
+		panic(fmt.Errorf("a cardinality constraint needs at least one operand"))
+	}
+	return Cardinality{kind: kind, bound: bound, len: len(operands), operands: newOperandList(operands)}
+}
+
+// NewAtMost returns the constraint that at most bound operands are true.
+func NewAtMost(bound int, operands ...Formula) Cardinality {
+	return NewCardinality(AtMost, bound, operands...)
+}
+
+// NewAtLeast returns the constraint that at least bound operands are true.
+func NewAtLeast(bound int, operands ...Formula) Cardinality {
+	return NewCardinality(AtLeast, bound, operands...)
+}
+
+// NewExactly returns the constraint that exactly bound operands are true.
+func NewExactly(bound int, operands ...Formula) Cardinality {
+	return NewCardinality(Exactly, bound, operands...)
+}
+
+// cardinality returns the constraint of the given kind, or the constant it is equivalent to when the bound decides
+// it regardless of the operands, like for atleast(0; p) or atmost(2; p, q).
+func cardinality(kind CardinalityKind, bound int, operands []Formula) Formula {
+	if holds, decided := decide(kind, bound, 0, len(operands)); decided {
+		return constant(holds)
+	}
+	return NewCardinality(kind, bound, operands...)
+}
+
+// decide reports whether the constraint with the given kind and bound holds when trues operands are true and the
+// value of the other unknown operands does not matter, and whether it is decided at all.
+func decide(kind CardinalityKind, bound, trues, unknown int) (holds bool, decided bool) {
+	atMost := trues+unknown <= bound // it holds even if the unknown operands are all true
+	atLeast := trues >= bound        // it holds even if the unknown operands are all false
+	tooMany := trues > bound         // it fails even if the unknown operands are all false
+	tooFew := trues+unknown < bound  // it fails even if the unknown operands are all true
+	switch kind {
+	case AtMost:
+		return atMost, atMost || tooMany
+	case AtLeast:
+		return atLeast, atLeast || tooFew
+	default:
+		return atMost && atLeast, atMost && atLeast || tooMany || tooFew
+	}
+}
+
+// Kind returns the kind of the constraint.
+func (c Cardinality) Kind() CardinalityKind {
+	return c.kind
+}
+
+// Bound returns the number of true operands the constraint compares with.
+func (c Cardinality) Bound() int {
+	return c.bound
+}
+
+// Len returns the number of operands of the constraint.
+func (c Cardinality) Len() int {
+	return c.len
+}
+
+// Operands returns the operands of the constraint, from left to right.
+func (c Cardinality) Operands() []Formula {
+	return c.operands.slice(c.len)
+}
+
+// Branch returns the if-then-else that is equivalent to the constraint and branches on its first operand A, like
+// ite(p, atmost(0; q, r), atmost(1; q, r)) for atmost(1; p, q, r): if A is true the constraint on the other operands
+// has the bound decreased by one, otherwise it has the same bound. The constraints on the other operands are
+// replaced by ⊤ or ⊥ when their bound decides them, like atmost(1; q) or atleast(2; q).
+func (c Cardinality) Branch() Ite {
+	rest := c.Operands()[1:]
+	return NewIte(c.operands.first, cardinality(c.kind, c.bound-1, rest), cardinality(c.kind, c.bound, rest))
+}
+
+// Class returns the Classification of the formula: a cardinality constraint is a beta formula, like the
+// if-then-else returned by Branch.
+func (c Cardinality) Class() Classification {
+	return Beta
+}
+
+func (c Cardinality) String() string {
+	strs := make([]string, c.len)
+	for i, operand := range c.Operands() {
+		strs[i] = operand.String()
+	}
+	return fmt.Sprintf("%s(%d; %s)", c.kind, c.bound, strings.Join(strs, ", "))
+}
+
+// Custom is a formula whose root is a connective registered with RegisterConnective, like maj(p, q, r).
+// Its meaning is the Definition of the connective, and the tableaux expand it with the Decomposition of the connective.
+type Custom struct {
+	connective *Connective
+	len        int
+	operands   operandList
+}
+
+// NewCustom returns the formula joining the operands with the registered connective with the given name or symbol.
+// It panics if there is no such connective or if the number of operands is not its arity.
+func NewCustom(name string, operands ...Formula) Custom {
+	c, ok := LookupConnective(name)
+	if !ok {
+		panic(fmt.Errorf("%s is not a registered connective", name))
+	}
+	if len(operands) != c.Arity {
+		panic(fmt.Errorf("%s expects %d operands, found %d", name, c.Arity, len(operands)))
+	}
+	return newCustom(c, operands)
+}
+
+func newCustom(c *Connective, operands []Formula) Custom {
+	return Custom{connective: c, len: len(operands), operands: newOperandList(operands)}
+}
+
+// with returns the formula joining the operands with the same connective, even if it is not registered anymore.
+func (c Custom) with(operands []Formula) Custom {
+	return newCustom(c.connective, operands)
+}
+
+// Connective returns the registered connective of the formula.
+func (c Custom) Connective() *Connective {
+	return c.connective
+}
+
+// Name returns the name of the connective of the formula.
+func (c Custom) Name() string {
+	return c.connective.Name
+}
+
+// Len returns the number of operands of the formula.
+func (c Custom) Len() int {
+	return c.len
+}
+
+// Operands returns the operands of the formula, from left to right.
+func (c Custom) Operands() []Formula {
+	return c.operands.slice(c.len)
+}
+
+// Definition returns the formula that the connective stands for, given by the Definition of the connective.
+func (c Custom) Definition() Formula {
+	return c.connective.Definition(c.Operands())
+}
+
+// Class returns the Classification of the formula, the one of the Positive decomposition of its connective.
+func (c Custom) Class() Classification {
+	return c.connective.Decomposition(false).Class
+}
+
+func (c Custom) String() string {
+	strs := make([]string, c.len)
+	for i, operand := range c.Operands() {
+		strs[i] = operand.String()
+	}
+	return c.connective.Name + "(" + strings.Join(strs, ", ") + ")"
+}
+
 // IsLiteral checks if the given formula is a literal (either a letter or its negation).
 func IsLiteral(formula Formula) bool {
+	formula = plain(formula)
 	if _, ok := formula.(Letter); ok {
 		return true
 	}
 
 	if not, ok := formula.(Not); ok {
-		_, ok := not.Negated().(Letter)
+		_, ok := plain(not.Negated()).(Letter)
 		return ok
 	}
 
 	return false
 }
 
+// IsConstant checks if the given formula is a truth constant (⊤ or ⊥) or the negation of a truth constant.
+func IsConstant(formula Formula) bool {
+	switch f := plain(formula).(type) {
+	case Top, Bottom:
+		return true
+	case Not:
+		switch plain(f.Negated()).(type) {
+		case Top, Bottom:
+			return true
+		}
+	}
+
+	return false
+}
+
+// AsConstant returns the truth value of a constant formula: true for ⊤ and ¬⊥, false for ⊥ and ¬⊤.
+// It panics if the formula is not a constant, so it should be used only after checking with IsConstant.
+func AsConstant(formula Formula) bool {
+	switch f := plain(formula).(type) {
+	case Top:
+		return true
+	case Bottom:
+		return false
+	case Not:
+		switch plain(f.Negated()).(type) {
+		case Top:
+			return false
+		case Bottom:
+			return true
+		}
+	}
+
+	panic(fmt.Errorf("%v is not a constant", formula))
+}
+
 // Literal is either a letter or its negation
 type Literal struct {
 	Name string
@@ -227,6 +676,7 @@ type Literal struct {
 // AsLiteral converts a Formula to a Literal. It panics if the formula is not a literal.
 // It should be used only after checking with IsLiteral.
 func AsLiteral(formula Formula) Literal {
+	formula = plain(formula)
 	if letter, ok := formula.(Letter); ok {
 		return Literal{
 			Name: letter.Name(),
@@ -235,7 +685,7 @@ func AsLiteral(formula Formula) Literal {
 	}
 
 	if not, ok := formula.(Not); ok {
-		if letter, ok := not.Negated().(Letter); ok {
+		if letter, ok := plain(not.Negated()).(Letter); ok {
 			return Literal{
 				Name: letter.Name(),
 				Neg:  true,
@@ -248,7 +698,11 @@ func AsLiteral(formula Formula) Literal {
 
 // Complement returns the complement of the given formula.
 // If the formula is a negation, it returns the inner formula; otherwise, it returns the negation of the formula.
+// The complement of an interned formula is interned by the same factory.
 func Complement(formula Formula) Formula {
+	if n, ok := formula.(*Interned); ok {
+		return n.Complement()
+	}
 	if not, ok := formula.(Not); ok {
 		return not.Negated()
 	} else {
@@ -257,6 +711,7 @@ func Complement(formula Formula) Formula {
 }
 
 // GenerateRandom generates a random formula of the given size. Size is the number of nodes.
+// The formula contains letters, negations, binary formulas, if-then-else formulas and cardinality constraints.
 func GenerateRandom(rand *rand.Rand, size int) Formula {
 	if size <= 1 {
 		letters := "pqrstuvwxyz"
@@ -272,10 +727,33 @@ func GenerateRandom(rand *rand.Rand, size int) Formula {
 		return NewNot(GenerateRandom(rand, size-1))
 	}
 
+	if size >= 4 && rand.Intn(16) == 0 {
+		n := 2 + rand.Intn(2)
+		operands := make([]Formula, n)
+		remaining := size - 1
+		for i := range operands[:n-1] {
+			operandSize := rand.Intn(remaining-(n-1-i)) + 1
+			operands[i] = GenerateRandom(rand, operandSize)
+			remaining -= operandSize
+		}
+		operands[n-1] = GenerateRandom(rand, remaining)
+
+		return NewCardinality(CardinalityKind(rand.Intn(int(Exactly)+1)), rand.Intn(n+1), operands...)
+	}
+
+	if size >= 4 && rand.Intn(8) == 0 {
+		conditionSize := rand.Intn(size-3) + 1
+		thenSize := rand.Intn(size-2-conditionSize) + 1
+		elseSize := size - 1 - conditionSize - thenSize
+
+		return NewIte(GenerateRandom(rand, conditionSize), GenerateRandom(rand, thenSize),
+			GenerateRandom(rand, elseSize))
+	}
+
 	leftSize := rand.Intn(size-2) + 1
 	rightSize := size - 1 - leftSize
 
 	return NewBinary(GenerateRandom(rand, leftSize), GenerateRandom(rand, rightSize),
-		Operator(rand.Intn(7))) // random binary operation
+		Operator(rand.Intn(int(NonImplies)+1))) // random binary operation
 
 }
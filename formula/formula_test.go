@@ -1,6 +1,8 @@
 package formula
 
 import (
+	"math/rand"
+	"slices"
 	"testing"
 )
 
@@ -88,6 +90,11 @@ func TestFormula_String(t *testing.T) {
 			f:    NewNot(NewNot(NewLetter("r"))),
 			want: "!!r",
 		},
+		{
+			name: "constants",
+			f:    NewOr(NewTop(), NewNot(NewBottom())),
+			want: "(⊤ | !⊥)",
+		},
 		{
 			name: "and",
 			f:    NewBinary(letters.p, letters.q, And),
@@ -110,6 +117,21 @@ func TestFormula_String(t *testing.T) {
 			)),
 			want: "!(!(p1 ^ p2) <-> (q1 !& (p2 !| q1)))",
 		},
+		{
+			name: "converse and non-implication",
+			f:    NewConverseImplies(letters.p, NewNonImplies(letters.q, letters.r)),
+			want: "(p <- (q !-> r))",
+		},
+		{
+			name: "if-then-else",
+			f:    NewIte(letters.p, NewNAry(Xor, letters.p, letters.q, letters.r), NewNot(letters.q)),
+			want: "ite(p, (p ^ q ^ r), !q)",
+		},
+		{
+			name: "cardinality constraint",
+			f:    NewExactly(1, letters.p, NewAnd(letters.q, letters.r), NewNot(letters.r)),
+			want: "exactly(1; p, (q & r), !r)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -121,6 +143,21 @@ func TestFormula_String(t *testing.T) {
 	}
 }
 
+// TestConstants_String checks that the constants print differently from the letters with the names the parser reads
+// as constants, and that they are read back as constants.
+func TestConstants_String(t *testing.T) {
+	for _, c := range []Formula{NewTop(), NewBottom()} {
+		for _, name := range []string{"T", "F", "true", "false"} {
+			if NewLetter(name).String() == c.String() {
+				t.Errorf("the letter %s prints as the constant %v", name, c)
+			}
+		}
+		if got := Parse(c.String()); got != c {
+			t.Errorf("Parse(%q) = %v, want %#v", c.String(), got, c)
+		}
+	}
+}
+
 func TestFormula_Class(t *testing.T) {
 	// A and B are just some random formulas, used for testing a more generic kind of formulas instead of only literals
 	// and operators.
@@ -143,6 +180,16 @@ func TestFormula_Class(t *testing.T) {
 			formula:  NewNot(letters.p),
 			expected: LiteralClass,
 		},
+		{
+			name:     "top",
+			formula:  NewTop(),
+			expected: LiteralClass,
+		},
+		{
+			name:     "negated bottom",
+			formula:  NewNot(NewBottom()),
+			expected: LiteralClass,
+		},
 		// Alpha-formulas
 		{
 			name:     "double negation",
@@ -215,6 +262,56 @@ func TestFormula_Class(t *testing.T) {
 			formula:  NewXor(A, B),
 			expected: Beta,
 		},
+		{
+			name:     "converse implication",
+			formula:  NewConverseImplies(A, B),
+			expected: Beta,
+		},
+		{
+			name:     "converse implication negation",
+			formula:  NewNot(NewConverseImplies(A, B)),
+			expected: Alpha,
+		},
+		{
+			name:     "non-implication",
+			formula:  NewNonImplies(A, B),
+			expected: Alpha,
+		},
+		{
+			name:     "non-implication negation",
+			formula:  NewNot(NewNonImplies(A, B)),
+			expected: Beta,
+		},
+		{
+			name:     "n-ary xor",
+			formula:  NewNAry(Xor, A, B, A),
+			expected: Beta,
+		},
+		{
+			name:     "n-ary xor negation",
+			formula:  NewNot(NewNAry(Xor, A, B, A)),
+			expected: Alpha,
+		},
+		{
+			name:     "if-then-else",
+			formula:  NewIte(A, B, A),
+			expected: Beta,
+		},
+		{
+			name:     "if-then-else negation",
+			formula:  NewNot(NewIte(A, B, A)),
+			expected: Beta,
+		},
+		{
+			name:     "cardinality constraint",
+			formula:  NewAtMost(1, A, B),
+			expected: Beta,
+		},
+		{
+			name:     "cardinality constraint negation",
+			formula:  NewNot(NewAtLeast(1, A, B)),
+			expected: Beta,
+		},
 	}
 
 	for _, tt := range tests {
@@ -244,6 +341,134 @@ func TestBinary_Getters(t *testing.T) {
 	}
 }
 
+func TestIte(t *testing.T) {
+	ite := NewIte(letters.p, letters.q, letters.r)
+
+	if ite.Condition() != letters.p || ite.Then() != letters.q || ite.Else() != letters.r {
+		t.Errorf("unexpected getters of %v", ite)
+	}
+	if set := map[Formula]bool{ite: true}; !set[NewIte(letters.p, letters.q, letters.r)] {
+		t.Errorf("if-then-else formulas are not compared by their operands")
+	}
+}
+
+func TestCardinality(t *testing.T) {
+	p, q, r := letters.p, letters.q, letters.r
+	c := NewAtMost(1, p, q, r)
+
+	if c.Kind() != AtMost || c.Bound() != 1 || c.Len() != 3 || !slices.Equal(c.Operands(), []Formula{p, q, r}) {
+		t.Errorf("unexpected getters of %v", c)
+	}
+	set := map[Formula]bool{c: true}
+	if !set[NewAtMost(1, p, q, r)] || set[NewAtMost(2, p, q, r)] || set[NewAtLeast(1, p, q, r)] {
+		t.Errorf("cardinality constraints are not compared by their kind, bound and operands")
+	}
+
+	for _, f := range []func(){
+		func() { NewAtMost(-1, p, q) },
+		func() { NewExactly(0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewCardinality did not panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestCardinality_Branch(t *testing.T) {
+	p, q, r := letters.p, letters.q, letters.r
+	tests := []struct {
+		input Cardinality
+		want  Ite
+	}{
+		{NewAtMost(1, p, q, r), NewIte(p, NewAtMost(0, q, r), NewAtMost(1, q, r))},
+		{NewAtLeast(2, p, q, r), NewIte(p, NewAtLeast(1, q, r), NewAtLeast(2, q, r))},
+		{NewExactly(1, p, q, r), NewIte(p, NewExactly(0, q, r), NewExactly(1, q, r))},
+		// the constraints decided by their bound are constants.
+		{NewAtMost(0, p, q), NewIte(p, NewBottom(), NewAtMost(0, q))},
+		{NewAtMost(1, p, q), NewIte(p, NewAtMost(0, q), NewTop())},
+		{NewAtLeast(1, p, q), NewIte(p, NewTop(), NewAtLeast(1, q))},
+		{NewExactly(2, p, q), NewIte(p, NewExactly(1, q), NewBottom())},
+		{NewExactly(0, p), NewIte(p, NewBottom(), NewTop())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input.String(), func(t *testing.T) {
+			if got := tt.input.Branch(); got != tt.want {
+				t.Errorf("Branch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNAry(t *testing.T) {
+	p, q, r := letters.p, letters.q, letters.r
+	and := NewNAry(And, p, q, r)
+
+	if and.Op() != And || and.Len() != 3 || and.Class() != Alpha || NewNot(and).Class() != Beta {
+		t.Errorf("unexpected getters of %v", and)
+	}
+	if got := and.Operands(); len(got) != 3 || got[0] != p || got[1] != q || got[2] != r {
+		t.Errorf("Operands() = %v, want [p q r]", got)
+	}
+	if got, want := NewNAry(Or, p, NewNot(q), and).String(), "(p | !q | (p & q & r))"; got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+	if NewNAry(Or, p, q, r).Class() != Beta {
+		t.Errorf("an n-ary disjunction is not a beta formula")
+	}
+
+	// n-ary formulas are compared by value, so they can be map keys.
+	set := map[Formula]bool{and: true}
+	if !set[NewNAry(And, p, q, r)] || set[NewNAry(And, p, r, q)] || set[NewNAry(Or, p, q, r)] {
+		t.Errorf("n-ary formulas are not compared by their operands")
+	}
+
+	for _, f := range []func(){
+		func() { NewNAry(Implies, p, q, r) },
+		func() { NewNAry(Xor, p) },
+		func() { NewNAry(And, p) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewNAry did not panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestConjunction(t *testing.T) {
+	p, q, r := letters.p, letters.q, letters.r
+	tests := []struct {
+		operands     []Formula
+		and, or, xor Formula
+	}{
+		{nil, NewTop(), NewBottom(), NewBottom()},
+		{[]Formula{p}, p, p, p},
+		{[]Formula{p, q}, NewAnd(p, q), NewOr(p, q), NewXor(p, q)},
+		{[]Formula{p, q, r}, NewNAry(And, p, q, r), NewNAry(Or, p, q, r), NewNAry(Xor, p, q, r)},
+	}
+
+	for _, tt := range tests {
+		if got := Conjunction(tt.operands...); got != tt.and {
+			t.Errorf("Conjunction(%v) = %v, want %v", tt.operands, got, tt.and)
+		}
+		if got := Disjunction(tt.operands...); got != tt.or {
+			t.Errorf("Disjunction(%v) = %v, want %v", tt.operands, got, tt.or)
+		}
+		if got := Parity(tt.operands...); got != tt.xor {
+			t.Errorf("Parity(%v) = %v, want %v", tt.operands, got, tt.xor)
+		}
+	}
+}
+
 func TestNot_Negated(t *testing.T) {
 	neg := NewNot(letters.p)
 
@@ -326,6 +551,42 @@ func TestComplement(t *testing.T) {
 	}
 }
 
+func TestAsConstant(t *testing.T) {
+	tests := []struct {
+		name     string
+		formula  Formula
+		constant bool
+		want     bool
+	}{
+		{"top", NewTop(), true, true},
+		{"bottom", NewBottom(), true, false},
+		{"negated top", NewNot(NewTop()), true, false},
+		{"negated bottom", NewNot(NewBottom()), true, true},
+		{"letter", letters.p, false, false},
+		{"double negated top", NewNot(NewNot(NewTop())), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsConstant(tt.formula); got != tt.constant {
+				t.Fatalf("IsConstant(%v) = %v, want %v", tt.formula, got, tt.constant)
+			}
+
+			defer func() {
+				if r := recover(); r != nil && tt.constant {
+					t.Errorf("AsConstant(%v) panicked unexpectedly", tt.formula)
+				} else if r == nil && !tt.constant {
+					t.Errorf("AsConstant(%v) did not panic as expected", tt.formula)
+				}
+			}()
+
+			if got := AsConstant(tt.formula); got != tt.want {
+				t.Errorf("AsConstant(%v) = %v, want %v", tt.formula, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAsLiteral(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -379,3 +640,18 @@ func TestAsLiteral(t *testing.T) {
 		})
 	}
 }
+
+// generateRandomNAry generates a random formula like GenerateRandom, where half of the times the chains of & and | are
+// n-ary formulas.
+func generateRandomNAry(r *rand.Rand, size int) Formula {
+	f := GenerateRandom(r, size)
+	if r.Intn(2) == 0 {
+		return f
+	}
+	return Transform(f, func(f Formula) Formula {
+		if b, ok := f.(Binary); ok && (b.Op() == And || b.Op() == Or) {
+			return buildChain(chainOperands(b, b.Op()), b.Op())
+		}
+		return f
+	})
+}
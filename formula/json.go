@@ -0,0 +1,341 @@
+package formula
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonNode is a node of the JSON encoding of a formula. Exactly one of Letter, Const and Op is set.
+type jsonNode struct {
+	Letter   *string     `json:"letter,omitempty"`
+	Const    *bool       `json:"const,omitempty"`
+	Op       string      `json:"op,omitempty"`
+	Operand  *jsonNode   `json:"operand,omitempty"`
+	Left     *jsonNode   `json:"left,omitempty"`
+	Right    *jsonNode   `json:"right,omitempty"`
+	Bound    *int        `json:"bound,omitempty"`
+	Operands []*jsonNode `json:"operands,omitempty"`
+}
+
+// notOp is the value of "op" for a negation and iteOp the one for an if-then-else.
+const (
+	notOp = "!"
+	iteOp = "ite"
+)
+
+// MarshalJSON encodes the formula as a tree of JSON objects, one for every node of the formula:
+//   - a letter is {"letter":"p"};
+//   - ⊤ is {"const":true} and ⊥ is {"const":false};
+//   - a negation is {"op":"!","operand":…};
+//   - a binary formula is {"op":"->","left":…,"right":…}, where "op" is the String of the Operator:
+//     "&", "|", "->", "!&", "!|", "<->", "^", "<-" or "!->";
+//   - an n-ary formula is {"op":"&","operands":[…]}, where "op" is "&", "|" or "^" and there are at least two operands;
+//   - an if-then-else is {"op":"ite","operands":[…]}, with the condition, the then and the else formulas;
+//   - a cardinality constraint is {"op":"atmost","bound":1,"operands":[…]}, where "op" is the String of the
+//     CardinalityKind: "atmost", "atleast" or "exactly";
+//   - a registered connective is {"op":"maj","operands":[…]}, where "op" is the name of the connective, and it can
+//     be decoded only while the connective is registered.
+//
+// For example p & !q is encoded as {"op":"&","left":{"letter":"p"},"right":{"op":"!","operand":{"letter":"q"}}}.
+// The operators are not escaped as HTML, so they are readable; note that json.Marshal escapes them again when it
+// encodes a JSON value, which is equivalent JSON. Interned formulas are encoded as their plain formulas.
+func MarshalJSON(formula Formula) ([]byte, error) {
+	node, err := toJSONNode(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func toJSONNode(formula Formula) (*jsonNode, error) {
+	switch f := plain(formula).(type) {
+	case Letter:
+		name := f.Name()
+		return &jsonNode{Letter: &name}, nil
+	case Top, Bottom:
+		value := AsConstant(f)
+		return &jsonNode{Const: &value}, nil
+	case Not:
+		operand, err := toJSONNode(f.Negated())
+		if err != nil {
+			return nil, err
+		}
+		return &jsonNode{Op: notOp, Operand: operand}, nil
+	case Binary:
+		left, err := toJSONNode(f.Left())
+		if err != nil {
+			return nil, err
+		}
+		right, err := toJSONNode(f.Right())
+		if err != nil {
+			return nil, err
+		}
+		return &jsonNode{Op: f.Op().String(), Left: left, Right: right}, nil
+	case NAry:
+		operands, err := toJSONList(f.Operands())
+		return &jsonNode{Op: f.Op().String(), Operands: operands}, err
+	case Ite:
+		operands, err := toJSONList(Operands(f))
+		return &jsonNode{Op: iteOp, Operands: operands}, err
+	case Cardinality:
+		bound := f.Bound()
+		operands, err := toJSONList(f.Operands())
+		return &jsonNode{Op: f.Kind().String(), Bound: &bound, Operands: operands}, err
+	case Custom:
+		operands, err := toJSONList(f.Operands())
+		return &jsonNode{Op: f.Name(), Operands: operands}, err
+	default:
+		return nil, fmt.Errorf("cannot encode %v: %T is not a Formula", f, f)
+	}
+}
+
+func toJSONList(operands []Formula) ([]*jsonNode, error) {
+	res := make([]*jsonNode, len(operands))
+	for i, operand := range operands {
+		n, err := toJSONNode(operand)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = n
+	}
+	return res, nil
+}
+
+// UnmarshalJSON decodes a formula encoded as described in MarshalJSON. Objects with unknown fields, with more than
+// one of "letter", "const" and "op", or without the operands required by "op" are rejected: the error reports
+// the path of the wrong object, like $.left.operand.
+func UnmarshalJSON(data []byte) (Formula, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var node *jsonNode
+	if err := decoder.Decode(&node); err != nil {
+		return nil, fmt.Errorf("cannot decode formula: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("cannot decode formula: unexpected data after the formula")
+	}
+	return fromJSONNode(node, "$")
+}
+
+// operatorsByName maps the String of every Operator to the Operator.
+var operatorsByName = func() map[string]Operator {
+	res := make(map[string]Operator)
+	for op := And; op <= NonImplies; op++ {
+		res[op.String()] = op
+	}
+	return res
+}()
+
+// cardinalityKindsByName maps the String of every CardinalityKind to the CardinalityKind.
+var cardinalityKindsByName = func() map[string]CardinalityKind {
+	res := make(map[string]CardinalityKind)
+	for kind := AtMost; kind <= Exactly; kind++ {
+		res[kind.String()] = kind
+	}
+	return res
+}()
+
+func fromJSONNode(node *jsonNode, path string) (Formula, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("cannot decode formula at %s: %s", path, fmt.Sprintf(format, args...))
+	}
+
+	if node == nil {
+		return nil, invalid("missing formula")
+	}
+
+	kinds := 0
+	for _, set := range []bool{node.Letter != nil, node.Const != nil, node.Op != ""} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return nil, invalid(`exactly one of "letter", "const" and "op" is required`)
+	}
+
+	hasOperand, hasSides, hasOperands := node.Operand != nil, node.Left != nil || node.Right != nil, node.Operands != nil
+	if kind, ok := cardinalityKindsByName[node.Op]; ok {
+		return fromJSONCardinality(node, kind, path)
+	}
+	if node.Bound != nil {
+		return nil, invalid(`only a cardinality constraint has a "bound"`)
+	}
+
+	switch {
+	case node.Letter != nil:
+		if *node.Letter == "" {
+			return nil, invalid("empty letter")
+		}
+		if hasOperand || hasSides || hasOperands {
+			return nil, invalid("a letter has no operands")
+		}
+		return NewLetter(*node.Letter), nil
+	case node.Const != nil:
+		if hasOperand || hasSides || hasOperands {
+			return nil, invalid("a constant has no operands")
+		}
+		if *node.Const {
+			return NewTop(), nil
+		}
+		return NewBottom(), nil
+	case node.Op == notOp:
+		if hasSides || hasOperands {
+			return nil, invalid(`a negation has an "operand", not "left" and "right" or "operands"`)
+		}
+		operand, err := fromJSONNode(node.Operand, path+".operand")
+		if err != nil {
+			return nil, err
+		}
+		return NewNot(operand), nil
+	case node.Op == iteOp:
+		if hasOperand || hasSides || len(node.Operands) != 3 {
+			return nil, invalid(`an if-then-else has three "operands", found %d`, len(node.Operands))
+		}
+		operands, err := fromJSONList(node.Operands, path)
+		if err != nil {
+			return nil, err
+		}
+		return NewIte(operands[0], operands[1], operands[2]), nil
+	}
+
+	op, ok := operatorsByName[node.Op]
+	if c, registered := LookupConnective(node.Op); !ok && registered {
+		return fromJSONCustom(node, c, path)
+	}
+	if !ok {
+		return nil, invalid("unknown operator %q", node.Op)
+	}
+	if hasOperands {
+		return fromJSONOperands(node, op, path)
+	}
+	if hasOperand {
+		return nil, invalid(`a binary formula has "left" and "right", not "operand"`)
+	}
+
+	left, err := fromJSONNode(node.Left, path+".left")
+	if err != nil {
+		return nil, err
+	}
+	right, err := fromJSONNode(node.Right, path+".right")
+	if err != nil {
+		return nil, err
+	}
+	return NewBinary(left, right, op), nil
+}
+
+// fromJSONOperands decodes an n-ary formula.
+func fromJSONOperands(node *jsonNode, op Operator, path string) (Formula, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("cannot decode formula at %s: %s", path, fmt.Sprintf(format, args...))
+	}
+
+	if op != And && op != Or && op != Xor {
+		return nil, invalid(`only "&", "|", "^" and "ite" can have "operands", found %q`, node.Op)
+	}
+	if node.Operand != nil || node.Left != nil || node.Right != nil {
+		return nil, invalid(`an n-ary formula has "operands", not "operand", "left" and "right"`)
+	}
+	if len(node.Operands) < 2 {
+		return nil, invalid("an n-ary formula has at least two operands, found %d", len(node.Operands))
+	}
+
+	operands, err := fromJSONList(node.Operands, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewNAry(op, operands...), nil
+}
+
+// fromJSONCustom decodes a formula whose root is a registered connective.
+func fromJSONCustom(node *jsonNode, c *Connective, path string) (Formula, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("cannot decode formula at %s: %s", path, fmt.Sprintf(format, args...))
+	}
+
+	if node.Operand != nil || node.Left != nil || node.Right != nil {
+		return nil, invalid(`%s has "operands", not "operand", "left" and "right"`, node.Op)
+	}
+	if len(node.Operands) != c.Arity {
+		return nil, invalid("%s has %d operands, found %d", node.Op, c.Arity, len(node.Operands))
+	}
+
+	operands, err := fromJSONList(node.Operands, path)
+	if err != nil {
+		return nil, err
+	}
+	return newCustom(c, operands), nil
+}
+
+// fromJSONCardinality decodes a cardinality constraint.
+func fromJSONCardinality(node *jsonNode, kind CardinalityKind, path string) (Formula, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("cannot decode formula at %s: %s", path, fmt.Sprintf(format, args...))
+	}
+
+	if node.Operand != nil || node.Left != nil || node.Right != nil {
+		return nil, invalid(`a cardinality constraint has "bound" and "operands", not "operand", "left" and "right"`)
+	}
+	if node.Bound == nil || *node.Bound < 0 {
+		return nil, invalid(`a cardinality constraint has a non-negative "bound"`)
+	}
+	if len(node.Operands) == 0 {
+		return nil, invalid("a cardinality constraint has at least one operand")
+	}
+
+	operands, err := fromJSONList(node.Operands, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewCardinality(kind, *node.Bound, operands...), nil
+}
+
+// fromJSONList decodes the "operands" of the object at path.
+func fromJSONList(nodes []*jsonNode, path string) ([]Formula, error) {
+	operands := make([]Formula, len(nodes))
+	for i, n := range nodes {
+		operand, err := fromJSONNode(n, fmt.Sprintf("%s.operands[%d]", path, i))
+		if err != nil {
+			return nil, err
+		}
+		operands[i] = operand
+	}
+	return operands, nil
+}
+
+// JSON wraps a Formula to encode and decode it with encoding/json, for example as a field of a struct, using the
+// format described in MarshalJSON. The zero JSON, with a nil Formula, is encoded as null.
+type JSON struct {
+	Formula Formula
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if j.Formula == nil {
+		return []byte("null"), nil
+	}
+	return MarshalJSON(j.Formula)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A null value sets Formula to nil.
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		j.Formula = nil
+		return nil
+	}
+	f, err := UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	j.Formula = f
+	return nil
+}
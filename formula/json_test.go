@@ -0,0 +1,149 @@
+package formula
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"p", `{"letter":"p"}`},
+		{"T", `{"const":true}`},
+		{"F", `{"const":false}`},
+		{"!p", `{"op":"!","operand":{"letter":"p"}}`},
+		{"p & q", `{"op":"&","left":{"letter":"p"},"right":{"letter":"q"}}`},
+		{"p | q", `{"op":"|","left":{"letter":"p"},"right":{"letter":"q"}}`},
+		{"p -> q", `{"op":"->","left":{"letter":"p"},"right":{"letter":"q"}}`},
+		{"p !& q", `{"op":"!&","left":{"letter":"p"},"right":{"letter":"q"}}`},
+		{"p !| q", `{"op":"!|","left":{"letter":"p"},"right":{"letter":"q"}}`},
+		{"p <- q", `{"op":"<-","left":{"letter":"p"},"right":{"letter":"q"}}`},
+		{"p !-> q", `{"op":"!->","left":{"letter":"p"},"right":{"letter":"q"}}`},
+		{"p ^ q ^ r", `{"op":"^","operands":[{"letter":"p"},{"letter":"q"},{"letter":"r"}]}`},
+		{"ite(p, q, F)", `{"op":"ite","operands":[{"letter":"p"},{"letter":"q"},{"const":false}]}`},
+		{"atmost(1; p, !q)", `{"op":"atmost","bound":1,"operands":[{"letter":"p"},{"op":"!","operand":{"letter":"q"}}]}`},
+		{"exactly(0; p)", `{"op":"exactly","bound":0,"operands":[{"letter":"p"}]}`},
+		{"maj(p, q, T)", `{"op":"maj","operands":[{"letter":"p"},{"letter":"q"},{"const":true}]}`},
+		{"p <-> q", `{"op":"<->","left":{"letter":"p"},"right":{"letter":"q"}}`},
+		{"p ^ !T", `{"op":"^","left":{"letter":"p"},"right":{"op":"!","operand":{"const":true}}}`},
+		{"p | q | !r", `{"op":"|","operands":[{"letter":"p"},{"letter":"q"},{"op":"!","operand":{"letter":"r"}}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := MarshalJSON(Parse(tt.input))
+			if err != nil || string(got) != tt.want {
+				t.Errorf("MarshalJSON(%v) = %s, %v, want %s", tt.input, got, err, tt.want)
+			}
+
+			f, err := UnmarshalJSON([]byte(tt.want))
+			if err != nil || f != Parse(tt.input) {
+				t.Errorf("UnmarshalJSON(%s) = %v, %v, want %v", tt.want, f, err, tt.input)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string // want is a part of the error message.
+	}{
+		{"syntax", `{"letter":`, "unexpected EOF"},
+		{"null", `null`, "at $: missing formula"},
+		{"empty object", `{}`, "at $: exactly one of"},
+		{"two kinds", `{"letter":"p","const":true}`, "at $: exactly one of"},
+		{"empty letter", `{"letter":""}`, "empty letter"},
+		{"unknown field", `{"letter":"p","name":"q"}`, `unknown field "name"`},
+		{"unknown operator", `{"op":"=>","left":{"letter":"p"},"right":{"letter":"q"}}`, `unknown operator "=>"`},
+		{"missing right", `{"op":"&","left":{"letter":"p"}}`, "at $.right: missing formula"},
+		{"negation with sides", `{"op":"!","left":{"letter":"p"}}`, "a negation has"},
+		{"binary with operand", `{"op":"&","operand":{"letter":"p"}}`, "a binary formula has"},
+		{"letter with operand", `{"letter":"p","operand":{"letter":"q"}}`, "a letter has no operands"},
+		{"nested", `{"op":"!","operand":{"op":"|","left":{"const":true},"right":{}}}`, "at $.operand.right"},
+		{"trailing data", `{"letter":"p"} {"letter":"q"}`, "unexpected data"},
+		{"n-ary implication", `{"op":"->","operands":[{"letter":"p"},{"letter":"q"}]}`, `only "&", "|", "^" and "ite"`},
+		{"one operand", `{"op":"&","operands":[{"letter":"p"}]}`, "at least two operands, found 1"},
+		{"n-ary with sides", `{"op":"|","operands":[],"left":{"letter":"p"}}`, `has "operands", not`},
+		{"nested operand", `{"op":"|","operands":[{"letter":"p"},{}]}`, "at $.operands[1]"},
+		{"ite with two operands", `{"op":"ite","operands":[{"letter":"p"},{"letter":"q"}]}`, `three "operands", found 2`},
+		{"ite with sides", `{"op":"ite","left":{"letter":"p"},"right":{"letter":"q"}}`, `three "operands", found 0`},
+		{"cardinality without bound", `{"op":"atleast","operands":[{"letter":"p"}]}`, `non-negative "bound"`},
+		{"negative bound", `{"op":"atmost","bound":-1,"operands":[{"letter":"p"}]}`, `non-negative "bound"`},
+		{"cardinality without operands", `{"op":"exactly","bound":0}`, "at least one operand"},
+		{"cardinality with sides", `{"op":"atmost","bound":1,"left":{"letter":"p"}}`, `has "bound" and "operands"`},
+		{"bound of a binary formula", `{"op":"&","bound":1,"operands":[{"letter":"p"}]}`, `only a cardinality`},
+		{"connective arity", `{"op":"stroke","operands":[{"letter":"p"}]}`, "stroke has 2 operands, found 1"},
+		{"connective with sides", `{"op":"maj","left":{"letter":"p"}}`, `maj has "operands", not`},
+		{"unregistered connective", `{"op":"unknown","operands":[{"letter":"p"}]}`, `unknown operator "unknown"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := UnmarshalJSON([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("UnmarshalJSON(%s) = %v, %v, want an error containing %q", tt.input, f, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type record struct {
+		Name    string `json:"name"`
+		Formula JSON   `json:"formula"`
+	}
+
+	in := record{Name: "modus ponens", Formula: JSON{Parse("(p & (p -> q)) -> q")}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out record
+	if err := json.Unmarshal(data, &out); err != nil || !reflect.DeepEqual(out, in) {
+		t.Errorf("decoded %s as %v, %v", data, out, err)
+	}
+
+	var empty record
+	if data, err := json.Marshal(empty); err != nil || string(data) != `{"name":"","formula":null}` {
+		t.Errorf("json.Marshal(%v) = %s, %v", empty, data, err)
+	}
+	if err := json.Unmarshal([]byte(`{"formula":null}`), &out); err != nil || out.Formula.Formula != nil {
+		t.Errorf("null decoded as %v, %v", out.Formula, err)
+	}
+}
+
+// TestJSON_RoundTrip checks that decoding the encoding of a formula gives the same formula, also when it is interned.
+func TestJSON_RoundTrip(t *testing.T) {
+	f := func(f Formula) bool {
+		data, err := MarshalJSON(NewFactory().Intern(f))
+		if err != nil {
+			t.Errorf("%v: %v", f, err)
+			return false
+		}
+		got, err := UnmarshalJSON(data)
+		if err != nil || got != f {
+			t.Errorf("%s decoded as %v, %v; want %v", data, got, err, f)
+			return false
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(generateRandomNAry(r, r.Intn(30)))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,246 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrNormalFormTooLarge is returned by ToCNF and ToDNF when the normal form has more clauses than the given limit.
+var ErrNormalFormTooLarge = errors.New("normal form too large")
+
+// ToNNF returns a formula equivalent to the given one in negation normal form: it contains only letters, constants,
+// binary and n-ary And and Or, and negations of letters. The other operators, the if-then-else formulas, the
+// cardinality constraints, written with the Pairwise encoding, and the registered connectives, written with their
+// Definition, are eliminated and the negations are pushed to the letters, while negated constants are replaced by
+// their value.
+func ToNNF(formula Formula) Formula {
+	res, _ := nnf(formula)
+	return res
+}
+
+// nnf returns the negation normal forms of the formula and of its negation. They are computed together, so that the
+// operators that need both forms of their operands, like the biconditional, share them instead of computing them
+// again: this keeps the time linear in the size of the formula, even when the printed forms are exponentially larger.
+func nnf(formula Formula) (Formula, Formula) {
+	switch f := plain(formula).(type) {
+	case Letter:
+		return f, NewNot(f)
+	case Top:
+		return f, NewBottom()
+	case Bottom:
+		return f, NewTop()
+	case Not:
+		pos, neg := nnf(f.Negated())
+		return neg, pos
+	case Binary:
+		lp, ln := nnf(f.Left())
+		rp, rn := nnf(f.Right())
+
+		switch f.Op() {
+		case And:
+			return NewAnd(lp, rp), NewOr(ln, rn)
+		case Or:
+			return NewOr(lp, rp), NewAnd(ln, rn)
+		case Implies:
+			// (l -> r) = (!l | r)
+			return NewOr(ln, rp), NewAnd(lp, rn)
+		case Nand:
+			// (l !& r) = !(l & r)
+			return NewOr(ln, rn), NewAnd(lp, rp)
+		case Nor:
+			// (l !| r) = !(l | r)
+			return NewAnd(ln, rn), NewOr(lp, rp)
+		case ConverseImplies:
+			// (l <- r) = (l | !r)
+			return NewOr(lp, rn), NewAnd(ln, rp)
+		case NonImplies:
+			// (l !-> r) = (l & !r)
+			return NewAnd(lp, rn), NewOr(ln, rp)
+		case Biconditional, Xor:
+			// (l <-> r) = ((!l | r) & (l | !r)) and (l ^ r) = ((l | r) & (!l | !r))
+			iff, xor := NewAnd(NewOr(ln, rp), NewOr(lp, rn)), NewAnd(NewOr(lp, rp), NewOr(ln, rn))
+			if f.Op() == Xor {
+				return xor, iff
+			}
+			return iff, xor
+		default:
+			panic(fmt.Errorf("unknown operator %v", f.Op()))
+		}
+	case NAry:
+		if f.Op() == Xor {
+			return nnf(f.binary())
+		}
+		operands := f.Operands()
+		negations := make([]Formula, len(operands))
+		for i, operand := range operands {
+			operands[i], negations[i] = nnf(operand)
+		}
+		// !(a & b & c) = (!a | !b | !c) and !(a | b | c) = (!a & !b & !c)
+		if f.Op() == And {
+			return NewNAry(And, operands...), NewNAry(Or, negations...)
+		}
+		return NewNAry(Or, operands...), NewNAry(And, negations...)
+	case Ite:
+		// ite(c, a, b) = ((c & a) | (!c & b)) and its negation is ite(c, !a, !b)
+		cp, cn := nnf(f.Condition())
+		tp, tn := nnf(f.Then())
+		ep, en := nnf(f.Else())
+		return NewOr(NewAnd(cp, tp), NewAnd(cn, ep)), NewOr(NewAnd(cp, tn), NewAnd(cn, en))
+	case Cardinality:
+		pos, _ := nnf(pairwise(f))
+		// the complement is written with Pairwise too, otherwise its negation would be f again.
+		complement := f.complement()
+		if c, ok := complement.(Cardinality); ok {
+			complement = pairwise(c)
+		}
+		neg, _ := nnf(complement)
+		return pos, neg
+	case Custom:
+		return nnf(f.Definition())
+	default:
+		panic(fmt.Errorf("%v: %T is not a Formula", f, f))
+	}
+}
+
+// ToCNF returns the conjunctive normal form of the formula as a conjunction of clauses, where every clause is a
+// disjunction of literals. An empty slice is true and an empty clause is false.
+// Tautological clauses, which contain a literal and its complement, are removed, as well as repeated clauses and
+// repeated literals in a clause. Since the CNF can be exponentially larger than the formula, it returns an error
+// wrapping ErrNormalFormTooLarge as soon as the number of clauses exceeds the limit. A limit <= 0 means no limit.
+func ToCNF(formula Formula, limit int) ([][]Literal, error) {
+	return normalForm(ToNNF(formula), And, limit)
+}
+
+// ToDNF returns the disjunctive normal form of the formula as a disjunction of cubes, where every cube is a
+// conjunction of literals. An empty slice is false and an empty cube is true.
+// Contradictory cubes, which contain a literal and its complement, are removed, as well as repeated cubes and
+// repeated literals in a cube. Since the DNF can be exponentially larger than the formula, it returns an error
+// wrapping ErrNormalFormTooLarge as soon as the number of cubes exceeds the limit. A limit <= 0 means no limit.
+func ToDNF(formula Formula, limit int) ([][]Literal, error) {
+	return normalForm(ToNNF(formula), Or, limit)
+}
+
+// normalForm distributes a formula in negation normal form into a list of clauses joined by outer (And for CNF, Or
+// for DNF), where the literals in each clause are joined by the other operator.
+func normalForm(formula Formula, outer Operator, limit int) ([][]Literal, error) {
+	switch f := formula.(type) {
+	case Letter, Not:
+		return [][]Literal{{AsLiteral(f)}}, nil
+	case Top, Bottom:
+		// the empty conjunction is true and the empty disjunction is false.
+		if AsConstant(f) == (outer == And) {
+			return [][]Literal{}, nil
+		}
+		return [][]Literal{{}}, nil
+	case Binary, NAry:
+		operands := Operands(f)
+		res, err := normalForm(operands[0], outer, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, operand := range operands[1:] {
+			clauses, err := normalForm(operand, outer, limit)
+			if err != nil {
+				return nil, err
+			}
+			if res, err = combineClauses(res, clauses, operatorOf(f) == outer, limit); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	default:
+		panic(fmt.Errorf("%v is not in negation normal form", f))
+	}
+}
+
+// operatorOf returns the operator of a binary or n-ary formula.
+func operatorOf(formula Formula) Operator {
+	switch f := formula.(type) {
+	case Binary:
+		return f.Op()
+	case NAry:
+		return f.Op()
+	default:
+		panic(fmt.Errorf("%v has no operator", f))
+	}
+}
+
+// combineClauses returns the union of the two lists of clauses if union is true, otherwise the list of the merges of
+// every clause of left with every clause of right, which distributes the inner operator over the outer one.
+func combineClauses(left, right [][]Literal, union bool, limit int) ([][]Literal, error) {
+	size := len(left) * len(right)
+	if union {
+		size = len(left) + len(right)
+	}
+	if limit > 0 && size > limit {
+		return nil, fmt.Errorf("%w: more than %d clauses", ErrNormalFormTooLarge, limit)
+	}
+
+	res := newClauseSet()
+	if union {
+		for _, clause := range append(left, right...) {
+			res.add(clause)
+		}
+		return res.clauses, nil
+	}
+
+	for _, l := range left {
+		for _, r := range right {
+			if clause, ok := mergeClauses(l, r); ok {
+				res.add(clause)
+			}
+		}
+	}
+	return res.clauses, nil
+}
+
+// clauseSet is a list of clauses without clauses that contain the same literals.
+type clauseSet struct {
+	clauses [][]Literal
+	keys    map[string]bool
+}
+
+func newClauseSet() *clauseSet {
+	return &clauseSet{clauses: [][]Literal{}, keys: make(map[string]bool)}
+}
+
+// add appends the clause to the set, unless a clause with the same literals is already contained.
+func (s *clauseSet) add(clause []Literal) {
+	names := make([]string, len(clause))
+	for i, lit := range clause {
+		names[i] = lit.Name
+		if lit.Neg {
+			names[i] = "!" + lit.Name
+		}
+	}
+	slices.Sort(names)
+	key := strings.Join(names, "\x00")
+
+	if !s.keys[key] {
+		s.keys[key] = true
+		s.clauses = append(s.clauses, clause)
+	}
+}
+
+// mergeClauses returns the union of the literals of the two clauses without repetitions.
+// It returns false if the union contains complementary literals.
+func mergeClauses(left, right []Literal) ([]Literal, bool) {
+	res := make([]Literal, 0, len(left)+len(right))
+	seen := make(map[Literal]bool, len(left)+len(right))
+
+	for _, clause := range [][]Literal{left, right} {
+		for _, lit := range clause {
+			if seen[neg(lit)] {
+				return nil, false
+			}
+			if !seen[lit] {
+				seen[lit] = true
+				res = append(res, lit)
+			}
+		}
+	}
+
+	return res, true
+}
@@ -0,0 +1,236 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// isNNF checks that the formula contains only letters, constants, binary and n-ary And and Or, and negations of
+// letters.
+func isNNF(formula Formula) bool {
+	switch f := formula.(type) {
+	case Letter, Top, Bottom:
+		return true
+	case Not:
+		_, ok := f.Negated().(Letter)
+		return ok
+	case Binary:
+		return (f.Op() == And || f.Op() == Or) && isNNF(f.Left()) && isNNF(f.Right())
+	case NAry:
+		return (f.Op() == And || f.Op() == Or) && !slices.ContainsFunc(f.Operands(), func(f Formula) bool {
+			return !isNNF(f)
+		})
+	default:
+		return false
+	}
+}
+
+// evalClauses evaluates a CNF, if cnf is true, or a DNF under a complete assignment.
+func evalClauses(clauses [][]Literal, cnf bool, assignment map[string]bool) bool {
+	for _, clause := range clauses {
+		// a clause of a CNF is true if one of its literals is true, a cube of a DNF is true if all of them are.
+		value := !cnf
+		for _, lit := range clause {
+			if (assignment[lit.Name] != lit.Neg) == cnf {
+				value = cnf
+				break
+			}
+		}
+
+		if value != cnf {
+			return !cnf
+		}
+	}
+	return cnf
+}
+
+// assignments returns all the complete assignments of the letters of the formula.
+func assignments(formula Formula) []map[string]bool {
+	letters := Letters(formula)
+	res := make([]map[string]bool, 0, 1<<len(letters))
+	for mask := 0; mask < 1<<len(letters); mask++ {
+		a := make(map[string]bool, len(letters))
+		for i, name := range letters {
+			a[name] = mask&(1<<i) != 0
+		}
+		res = append(res, a)
+	}
+	return res
+}
+
+func TestToNNF(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"letter", "p", "p"},
+		{"double negation", "!!p", "p"},
+		{"negated and", "!(p & q)", "(!p | !q)"},
+		{"negated or", "!(p | q)", "(!p & !q)"},
+		{"implication", "p -> q", "(!p | q)"},
+		{"negated implication", "!(p -> q)", "(p & !q)"},
+		{"nand", "p !& q", "(!p | !q)"},
+		{"negated nand", "!(p !& q)", "(p & q)"},
+		{"nor", "p !| q", "(!p & !q)"},
+		{"negated nor", "!(p !| q)", "(p | q)"},
+		{"biconditional", "p <-> q", "((!p | q) & (p | !q))"},
+		{"negated biconditional", "!(p <-> q)", "((p | q) & (!p | !q))"},
+		{"xor", "p ^ q", "((p | q) & (!p | !q))"},
+		{"negated xor", "!(p ^ q)", "((!p | q) & (p | !q))"},
+		{"negated constants", "!T | !!F", "(⊥ | ⊥)"},
+		{"nested", "!((p -> q) & !r)", "((p & !q) | r)"},
+		{"negated n-ary", "!(p & q & !(r | p | q))", "(!p | !q | (r | p | q))"},
+		{"converse implication", "p <- q", "(p | !q)"},
+		{"negated non-implication", "!(p !-> q)", "(!p | q)"},
+		{"n-ary xor", "p ^ q ^ r", "((((p | q) & (!p | !q)) | r) & (((!p | q) & (p | !q)) | !r))"},
+		{"negated if-then-else", "!ite(p, q, !r)", "((p & !q) | (!p & r))"},
+		{"cardinality", "atmost(1; p, !q, r)", "(((!p | q) & (!p | !r)) & (q | !r))"},
+		{"negated cardinality", "!atmost(1; p, q)", "(p & q)"},
+		{"negated exactly", "!exactly(1; p, q)", "((!p & !q) | (p & q))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToNNF(Parse(tt.input)); got.String() != tt.want {
+				t.Errorf("ToNNF(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToCNF(t *testing.T) {
+	p, q, r := Literal{Name: "p"}, Literal{Name: "q"}, Literal{Name: "r"}
+	np, nq := Literal{Name: "p", Neg: true}, Literal{Name: "q", Neg: true}
+
+	tests := []struct {
+		name  string
+		input string
+		cnf   [][]Literal
+		dnf   [][]Literal
+	}{
+		{"letter", "p", [][]Literal{{p}}, [][]Literal{{p}}},
+		{"and", "p & q", [][]Literal{{p}, {q}}, [][]Literal{{p, q}}},
+		{"or", "p | q", [][]Literal{{p, q}}, [][]Literal{{p}, {q}}},
+		{"distribution", "p | (q & r)", [][]Literal{{p, q}, {p, r}}, [][]Literal{{p}, {q, r}}},
+		{"repeated literals", "(p | q) & (p | p)", [][]Literal{{p, q}, {p}}, [][]Literal{{p}, {q, p}}},
+		{"tautology", "p | !p", [][]Literal{}, [][]Literal{{p}, {np}}},
+		{"contradiction", "p & !p", [][]Literal{{p}, {np}}, [][]Literal{}},
+		{"xor", "p ^ q", [][]Literal{{p, q}, {np, nq}}, [][]Literal{{p, nq}, {q, np}}},
+		{"top", "T", [][]Literal{}, [][]Literal{{}}},
+		{"bottom", "F", [][]Literal{{}}, [][]Literal{}},
+		{"or with bottom", "F | p", [][]Literal{{p}}, [][]Literal{{p}}},
+		{"and with top", "T & p", [][]Literal{{p}}, [][]Literal{{p}}},
+		{"n-ary", "p & (q | r | !p) & q", [][]Literal{{p}, {q, r, np}, {q}}, [][]Literal{{p, q}, {p, r, q}}},
+		{"negated n-ary", "!(p | q | !r)", [][]Literal{{np}, {nq}, {r}}, [][]Literal{{np, nq, r}}},
+		{"if-then-else", "ite(p, q, r)", [][]Literal{{p, r}, {q, np}, {q, r}}, [][]Literal{{p, q}, {np, r}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cnf, err := ToCNF(Parse(tt.input), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cnf, tt.cnf) {
+				t.Errorf("ToCNF(%v) = %v, want %v", tt.input, cnf, tt.cnf)
+			}
+
+			dnf, err := ToDNF(Parse(tt.input), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(dnf, tt.dnf) {
+				t.Errorf("ToDNF(%v) = %v, want %v", tt.input, dnf, tt.dnf)
+			}
+		})
+	}
+}
+
+func TestToCNF_Limit(t *testing.T) {
+	// (p1 & q1) | (p2 & q2) | ... has 2^n clauses in CNF, but only n cubes in DNF.
+	f := Formula(NewAnd(letters.p1, letters.q1))
+	f = NewOr(f, NewAnd(letters.p2, letters.q2))
+	f = NewOr(f, NewAnd(letters.p3, letters.q3))
+
+	if _, err := ToCNF(f, 4); !errors.Is(err, ErrNormalFormTooLarge) {
+		t.Errorf("expected ErrNormalFormTooLarge, got %v", err)
+	}
+
+	if cnf, err := ToCNF(f, 8); err != nil || len(cnf) != 8 {
+		t.Errorf("expected 8 clauses, got %v, %v", cnf, err)
+	}
+
+	if dnf, err := ToDNF(f, 3); err != nil || len(dnf) != 3 {
+		t.Errorf("expected 3 cubes, got %v, %v", dnf, err)
+	}
+
+	if _, err := ToDNF(NewNot(f), 4); !errors.Is(err, ErrNormalFormTooLarge) {
+		t.Errorf("expected ErrNormalFormTooLarge, got %v", err)
+	}
+}
+
+// TestToCNF_LimitBiconditionalChain checks that the limit stops the conversion of a chain of biconditionals and
+// exclusive disjunctions, whose negation normal form doubles with every operator, before it takes exponential time.
+func TestToCNF_LimitBiconditionalChain(t *testing.T) {
+	for _, op := range []Operator{Biconditional, Xor} {
+		f := Formula(NewLetter("p0"))
+		for i := 1; i <= 60; i++ {
+			f = NewBinary(NewLetter(fmt.Sprintf("p%d", i)), f, op)
+		}
+
+		if _, err := ToCNF(f, 10); !errors.Is(err, ErrNormalFormTooLarge) {
+			t.Errorf("ToCNF(%v): expected ErrNormalFormTooLarge, got %v", op, err)
+		}
+		if _, err := ToDNF(NewNot(f), 10); !errors.Is(err, ErrNormalFormTooLarge) {
+			t.Errorf("ToDNF(%v): expected ErrNormalFormTooLarge, got %v", op, err)
+		}
+	}
+}
+
+// TestNormalForms_Equivalence checks that NNF, CNF and DNF are equivalent to the original formula.
+func TestNormalForms_Equivalence(t *testing.T) {
+	f := func(f Formula) bool {
+		nnf := ToNNF(f)
+		if !isNNF(nnf) {
+			t.Errorf("ToNNF(%v) = %v is not in negation normal form", f, nnf)
+			return false
+		}
+
+		cnf, err := ToCNF(f, 0)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		dnf, err := ToDNF(f, 0)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+
+		for _, a := range assignments(f) {
+			want, _ := Eval(f, a)
+			got, _ := Eval(nnf, a)
+			if got != want || evalClauses(cnf, true, a) != want || evalClauses(dnf, false, a) != want {
+				t.Errorf("%v: normal forms are not equivalent under %v", f, a)
+				return false
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(generateRandomNAry(r, r.Intn(15)))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,13 +1,120 @@
 package formula
 
 import (
+	"fmt"
 	"github.com/antlr4-go/antlr/v4"
 	"github.com/francodesource/propositional_tableaux/formula/parser"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 type formulaListener struct {
 	*parser.BaseFormulaListener
 	stack []Formula
+
+	definitions   map[string]definition
+	parameters    map[string]bool // parameters contains the parameters of the schema being defined, if any.
+	abbreviations Abbreviations
+	errors        []SyntaxError
+}
+
+// definition is an abbreviation, without parameters, or a schema. The parameters occur in the body as placeholder
+// letters, which cannot be written in the input, so that the letters of the arguments and of the abbreviations used
+// in the body are never replaced when the schema is applied.
+type definition struct {
+	parameters []string
+	body       Formula
+}
+
+// placeholder returns the letter that stands for a parameter in the body of a schema.
+func placeholder(parameter string) Letter {
+	return NewLetter("\x00" + parameter)
+}
+
+func (f *formulaListener) errorf(token antlr.Token, format string, args ...any) {
+	f.errors = append(f.errors, SyntaxError{Line: token.GetLine(), Column: token.GetColumn(),
+		Offending: token.GetText(), Msg: fmt.Sprintf(format, args...)})
+}
+
+// abbreviate records the abbreviation of the formula, unless it is in the body of a schema, where it may contain
+// placeholders, or it already has an abbreviation.
+func (f *formulaListener) abbreviate(formula Formula, abbreviation Abbreviation) {
+	if f.parameters != nil {
+		return
+	}
+	if f.abbreviations == nil {
+		f.abbreviations = make(Abbreviations)
+	}
+	if _, ok := f.abbreviations[formula]; !ok {
+		f.abbreviations[formula] = abbreviation
+	}
+}
+
+func (f *formulaListener) define(token antlr.Token, d definition) {
+	if _, ok := f.definitions[token.GetText()]; ok {
+		f.errorf(token, "%s is already defined", token.GetText())
+		return
+	}
+	if f.definitions == nil {
+		f.definitions = make(map[string]definition)
+	}
+	f.definitions[token.GetText()] = d
+}
+
+func (f *formulaListener) ExitAbbreviation(ctx *parser.AbbreviationContext) {
+	f.define(ctx.GetName(), definition{body: f.pop()})
+}
+
+func (f *formulaListener) EnterSchema(ctx *parser.SchemaContext) {
+	f.parameters = make(map[string]bool)
+	for _, parameter := range ctx.Parameters().AllVARIABLE() {
+		if f.parameters[parameter.GetText()] {
+			f.errorf(parameter.GetSymbol(), "repeated parameter %s", parameter.GetText())
+		}
+		f.parameters[parameter.GetText()] = true
+	}
+}
+
+func (f *formulaListener) ExitSchema(ctx *parser.SchemaContext) {
+	f.parameters = nil
+	var parameters []string
+	for _, parameter := range ctx.Parameters().AllVARIABLE() {
+		parameters = append(parameters, parameter.GetText())
+	}
+	f.define(ctx.GetName(), definition{parameters: parameters, body: f.pop()})
+}
+
+func (f *formulaListener) ExitApplication(ctx *parser.ApplicationContext) {
+	n := len(ctx.GetArguments().AllExpression())
+	args := slices.Clone(f.stack[len(f.stack)-n:])
+	f.stack = f.stack[:len(f.stack)-n]
+
+	name := ctx.GetName()
+	d, ok := f.definitions[name.GetText()]
+	c, registered := LookupConnective(name.GetText())
+	switch {
+	case !ok && registered && c.Arity != n:
+		f.errorf(name, "%s expects %d arguments, found %d", name.GetText(), c.Arity, n)
+	case !ok && registered:
+		f.stack = append(f.stack, newCustom(c, args))
+		return
+	case !ok:
+		f.errorf(name, "undefined schema %s", name.GetText())
+	case len(d.parameters) != n:
+		f.errorf(name, "%s expects %d arguments, found %d", name.GetText(), len(d.parameters), n)
+	default:
+		sub := make(map[string]Formula, n)
+		for i, parameter := range d.parameters {
+			sub[placeholder(parameter).Name()] = args[i]
+		}
+		expanded := Substitute(d.body, sub)
+		f.abbreviate(expanded, Abbreviation{Name: name.GetText(), Arguments: args})
+		f.stack = append(f.stack, expanded)
+		return
+	}
+	// the error is reported, the letter keeps the stack consistent.
+	f.stack = append(f.stack, NewLetter(name.GetText()))
 }
 
 func (f *formulaListener) pop() Formula {
@@ -18,13 +125,73 @@ func (f *formulaListener) pop() Formula {
 }
 
 func (f *formulaListener) ExitLetter(ctx *parser.LetterContext) {
-	f.stack = append(f.stack, Letter{name: ctx.GetText()})
+	name := ctx.GetText()
+	if d, ok := f.definitions[name]; ok && !f.parameters[name] {
+		if len(d.parameters) > 0 {
+			f.errorf(ctx.GetStart(), "%s expects %d arguments", name, len(d.parameters))
+		} else {
+			f.abbreviate(d.body, Abbreviation{Name: name})
+			f.stack = append(f.stack, d.body)
+			return
+		}
+	}
+	if f.parameters[name] {
+		f.stack = append(f.stack, placeholder(name))
+		return
+	}
+	f.stack = append(f.stack, Letter{name: name})
+}
+
+func (f *formulaListener) ExitTop(ctx *parser.TopContext) {
+	f.stack = append(f.stack, Top{})
+}
+
+func (f *formulaListener) ExitBottom(ctx *parser.BottomContext) {
+	f.stack = append(f.stack, Bottom{})
 }
 
 func (f *formulaListener) ExitNegation(ctx *parser.NegationContext) {
 	f.stack = append(f.stack, Not{negated: f.pop()})
 }
 
+func (f *formulaListener) ExitIte(ctx *parser.IteContext) {
+	els := f.pop()
+	then := f.pop()
+	f.stack = append(f.stack, NewIte(f.pop(), then, els))
+}
+
+func (f *formulaListener) ExitCardinality(ctx *parser.CardinalityContext) {
+	n := len(ctx.GetOperands().AllExpression())
+	operands := slices.Clone(f.stack[len(f.stack)-n:])
+	f.stack = f.stack[:len(f.stack)-n]
+
+	bound, err := strconv.Atoi(ctx.GetBound().GetText())
+	if err != nil {
+		f.errorf(ctx.GetBound(), "the bound of %s must be a number, found %s", ctx.GetKind().GetText(),
+			ctx.GetBound().GetText())
+		bound = 0 // the error is reported, the bound keeps the formula well-formed.
+	}
+	var kind CardinalityKind
+	switch ctx.GetKind().GetTokenType() {
+	case parser.FormulaLexerATMOST:
+		kind = AtMost
+	case parser.FormulaLexerATLEAST:
+		kind = AtLeast
+	case parser.FormulaLexerEXACTLY:
+		kind = Exactly
+	}
+	f.stack = append(f.stack, NewCardinality(kind, bound, operands...))
+}
+
+// flatChain reports whether the left operand of the binary expression is an expression with the same operator and
+// without parentheses, like p & q in p & q & r, when the operator is &, | or ^: such chains become NAry formulas.
+func flatChain(ctx *parser.BinaryContext) bool {
+	op := ctx.GetOp().GetTokenType()
+	left, ok := ctx.GetLeft().(*parser.BinaryContext)
+	nary := op == parser.FormulaParserAND || op == parser.FormulaParserOR || op == parser.FormulaParserXOR
+	return ok && nary && left.GetOp().GetTokenType() == op
+}
+
 func (f *formulaListener) ExitBinary(ctx *parser.BinaryContext) {
 	right := f.pop()
 	left := f.pop()
@@ -44,30 +211,220 @@ func (f *formulaListener) ExitBinary(ctx *parser.BinaryContext) {
 		formula = NewNor(left, right)
 	case parser.FormulaLexerXOR:
 		formula = NewXor(left, right)
+	case parser.FormulaLexerCONVERSE:
+		formula = NewConverseImplies(left, right)
+	case parser.FormulaLexerNONIMPLIES:
+		formula = NewNonImplies(left, right)
+	}
+	if flatChain(ctx) {
+		formula = NewNAry(formula.(Binary).Op(), append(Operands(left), right)...)
 	}
 	f.stack = append(f.stack, formula)
 }
 
-// Parse takes a string input representing a propositional logic formula
+// SyntaxError describes a single syntax error found while parsing a formula.
+type SyntaxError struct {
+	Line      int      // Line is the line of the error, starting from 1.
+	Column    int      // Column is the position of the error in its line, starting from 0.
+	Offending string   // Offending is the text of the token that caused the error.
+	Expected  []string // Expected contains the tokens that would have been accepted instead of Offending.
+	Msg       string   // Msg is the message reported by the parser.
+}
+
+func (e SyntaxError) String() string {
+	return fmt.Sprintf("line %d:%d %s", e.Line, e.Column, e.Msg)
+}
+
+// ParseError is the error returned when the input is not a well-formed formula.
+// It collects all the syntax errors found in the input.
+type ParseError struct {
+	Input  string
+	Errors []SyntaxError
+}
+
+func (e *ParseError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, se := range e.Errors {
+		msgs[i] = se.String()
+	}
+	return fmt.Sprintf("cannot parse %q: %s", e.Input, strings.Join(msgs, "; "))
+}
+
+// errorListener is an antlr.ErrorListener that collects the syntax errors reported by both the lexer and the parser
+// instead of printing them on the console.
+type errorListener struct {
+	*antlr.DefaultErrorListener
+	errors []SyntaxError
+}
+
+// expectedTokens returns the display names of the tokens the parser expects in its current state.
+func expectedTokens(p antlr.Parser) []string {
+	literalNames, symbolicNames := p.GetLiteralNames(), p.GetSymbolicNames()
+	var res []string
+	for _, interval := range p.GetExpectedTokens().GetIntervals() {
+		for t := interval.Start; t < interval.Stop; t++ {
+			switch {
+			case t == antlr.TokenEOF:
+				res = append(res, "<EOF>")
+			case t < len(literalNames) && literalNames[t] != "":
+				res = append(res, literalNames[t])
+			case t < len(symbolicNames):
+				res = append(res, symbolicNames[t])
+			}
+		}
+	}
+	return res
+}
+
+func (l *errorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int,
+	msg string, _ antlr.RecognitionException) {
+	se := SyntaxError{Line: line, Column: column, Msg: msg}
+
+	switch r := recognizer.(type) {
+	case antlr.Parser:
+		if token, ok := offendingSymbol.(antlr.Token); ok {
+			se.Offending = token.GetText()
+		}
+		se.Expected = expectedTokens(r)
+	case *antlr.BaseLexer:
+		// The lexer does not report an offending token, so the unrecognized text is taken from the input.
+		input := r.GetInputStream()
+		se.Offending = input.GetTextFromInterval(antlr.NewInterval(r.TokenStartCharIndex, input.Index()))
+	}
+
+	l.errors = append(l.errors, se)
+}
+
+// newLexer returns a lexer of the input that reports its errors to errListener.
+func newLexer(input string, errListener *errorListener) *parser.FormulaLexer {
+	lexer := parser.NewFormulaLexer(antlr.NewInputStream(input))
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errListener)
+	return lexer
+}
+
+// formulaLexer is a FormulaLexer for formulas, which reports a turnstile as a syntax error pointing to ParseSequent,
+// since only a sequent has one, and ends the input there. reported is the number of errors up to the turnstile,
+// the later ones being caused by the input ending early.
+type formulaLexer struct {
+	*parser.FormulaLexer
+	errListener *errorListener
+	reported    int
+}
+
+func (l *formulaLexer) NextToken() antlr.Token {
+	t := l.FormulaLexer.NextToken()
+	if t.GetTokenType() != parser.FormulaLexerTURNSTILE {
+		return t
+	}
+
+	msg := fmt.Sprintf("the turnstile %s can only separate the premises and the conclusions of a sequent, "+
+		"which is read by ParseSequent", t.GetText())
+	if t.GetText() == "|-" {
+		msg += ": a disjunction with a negated operand is written | -"
+	}
+	l.errListener.errors = append(l.errListener.errors,
+		SyntaxError{Line: t.GetLine(), Column: t.GetColumn(), Offending: t.GetText(), Msg: msg})
+	l.reported = len(l.errListener.errors)
+	return l.GetTokenFactory().Create(t.GetSource(), antlr.TokenEOF, "<EOF>", antlr.TokenDefaultChannel,
+		t.GetStart(), t.GetStart()-1, t.GetLine(), t.GetColumn())
+}
+
+// newParser returns a parser of the tokens of source that reports its errors to errListener.
+func newParser(source antlr.Lexer, errListener *errorListener) *parser.FormulaParser {
+	p := parser.NewFormulaParser(antlr.NewCommonTokenStream(source, antlr.TokenDefaultChannel))
+	p.RemoveErrorListeners()
+	p.AddErrorListener(errListener)
+	return p
+}
+
+// ParseE takes a string input representing a propositional logic formula
 // and returns its corresponding Formula representation.
-// The operators supported are:
-//   - AND: &
-//   - OR: |
-//   - NOT: !
-//   - IMPLIES: ->
-//   - BICONDITIONAL: <->
-//   - NAND: !&
-//   - NOR: !|
-//   - XOR: ^
+// If the input is not a well-formed formula it returns a *ParseError containing all the syntax errors found.
+// The operators supported are listed below, each one followed by its alternative spellings:
+//   - AND: &, ∧, /\, and, \land, \wedge
+//   - OR: |, ∨, \/, or, \lor, \vee
+//   - NOT: !, ¬, ~, -, not, \neg, \lnot
+//   - IMPLIES: ->, →, ⇒, =>, \to, \rightarrow, \Rightarrow, \implies
+//   - BICONDITIONAL: <->, ↔, ⇔, <=>, \leftrightarrow, \Leftrightarrow, \iff
+//   - NAND: !&, ↑, nand, \uparrow
+//   - NOR: !|, ↓, nor, \downarrow
+//   - XOR: ^, ⊕, ⊻, xor, \oplus, \veebar
+//   - CONVERSE IMPLICATION: <-, ←, ⇐, <=, \leftarrow, \Leftarrow
+//   - NON-IMPLICATION: !->, ↛, \nrightarrow
 //
-// Binary operations must always be enclosed in parentheses.
-// Redundant parentheses are not allowed.
-func Parse(input string) Formula {
-	is := antlr.NewInputStream(input)
-	lexer := parser.NewFormulaLexer(is)
-	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
-	p := parser.NewFormulaParser(stream)
+// The if-then-else ite(c, a, b) is equivalent to a when c is true and to b when c is false. The cardinality
+// constraints atmost(k; a, b, c), atleast(k; a, b, c) and exactly(k; a, b, c) are true when at most, at least or
+// exactly k of their operands are true, where k is a number. The keywords ite, atmost, atleast and exactly can also be
+// written as \mathrm{ite} or \operatorname{ite}, and so on.
+//
+// The truth constants ⊤ and ⊥ can be written as T and F, true and false, ⊤ and ⊥, or \top and \bot.
+//
+// Parentheses can also be written as \left( and \right), so that formulas printed in Unicode or LaTeX
+// can be parsed back. The keywords and, or, not, nand, nor, xor, ite, atmost, atleast, exactly, true, false and
+// therefore, and the names T and F cannot be used as letters. The text from # to the end of the line is a comment.
+//
+// Parentheses are optional and may be redundant. Without them the operators bind, from the tightest to the loosest,
+// in this order: !, then & and !&, then ^, then | and !|, then ->, then <- and !->, then <->.
+// Implication is right-associative, all the other binary operators are left-associative:
+// "p -> q -> r" is read as "(p -> (q -> r))" while "p !& q !& r" is read as "((p !& q) !& r)".
+// A chain of &, | or ^ without parentheses is read as a single NAry formula instead: "p & q & r" is the conjunction
+// of three operands, printed as "(p & q & r)", while "(p & q) & r" is still a Binary formula.
+//
+// The formula can be preceded by definitions: let A = (p & q); defines the abbreviation A, and
+// def Pierce(X, Y) = (((X -> Y) -> X) -> X); defines the schema Pierce, which is applied like Pierce(p, q & r).
+// A definition can use the previous ones. The definitions are expanded, so the formula contains only letters,
+// constants and operators: ParseAbbreviated also returns the names of the expanded subformulas.
+// The keywords let and def cannot be used as letters.
+//
+// A turnstile, like |- or ⊢, is a syntax error that points to ParseSequent, even when it is written without spaces as
+// in "p |-q": a disjunction with a negated operand is written "p | -q".
+//
+// The connectives registered with RegisterConnective are applied like the schemas, as in maj(p, q, r), by their name
+// or by one of their symbols. A schema defined in the input hides the connective with the same name.
+func ParseE(input string) (Formula, error) {
+	f, _, err := ParseAbbreviated(input)
+	return f, err
+}
+
+// ParseAbbreviated is like ParseE, but it also returns the Abbreviations of the definitions used in the input:
+// the expansion of let A = (p & q); is abbreviated as A and the one of Pierce(p, A) as Pierce with the arguments p
+// and (p & q).
+func ParseAbbreviated(input string) (Formula, Abbreviations, error) {
+	errListener := &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	lexer := &formulaLexer{FormulaLexer: newLexer(input, errListener), errListener: errListener}
+	p := newParser(lexer, errListener)
+
+	tree := p.Start_()
+	if lexer.reported > 0 {
+		errListener.errors = errListener.errors[:lexer.reported]
+	}
+	if len(errListener.errors) > 0 {
+		return nil, nil, &ParseError{Input: input, Errors: errListener.errors}
+	}
+
 	listener := &formulaListener{}
-	antlr.ParseTreeWalkerDefault.Walk(listener, p.Start_())
-	return listener.pop()
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	if len(listener.errors) > 0 {
+		return nil, nil, &ParseError{Input: input, Errors: listener.errors}
+	}
+	return listener.pop(), listener.abbreviations, nil
+}
+
+// MustParse is like ParseE but panics if the input is not a well-formed formula.
+// It simplifies the initialization of variables holding formulas that are known to be correct.
+func MustParse(input string) Formula {
+	f, err := ParseE(input)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Parse takes a string input representing a propositional logic formula
+// and returns its corresponding Formula representation.
+// It panics if the input is not a well-formed formula, use ParseE to handle syntax errors.
+// See ParseE for the supported syntax.
+func Parse(input string) Formula {
+	return MustParse(input)
 }
@@ -27,11 +27,41 @@ func (s *BaseFormulaListener) EnterStart(ctx *StartContext) {}
 // ExitStart is called when production start is exited.
 func (s *BaseFormulaListener) ExitStart(ctx *StartContext) {}
 
-// EnterBinary is called when production Binary is entered.
-func (s *BaseFormulaListener) EnterBinary(ctx *BinaryContext) {}
+// EnterSequent is called when production sequent is entered.
+func (s *BaseFormulaListener) EnterSequent(ctx *SequentContext) {}
 
-// ExitBinary is called when production Binary is exited.
-func (s *BaseFormulaListener) ExitBinary(ctx *BinaryContext) {}
+// ExitSequent is called when production sequent is exited.
+func (s *BaseFormulaListener) ExitSequent(ctx *SequentContext) {}
+
+// EnterFormulas is called when production formulas is entered.
+func (s *BaseFormulaListener) EnterFormulas(ctx *FormulasContext) {}
+
+// ExitFormulas is called when production formulas is exited.
+func (s *BaseFormulaListener) ExitFormulas(ctx *FormulasContext) {}
+
+// EnterAbbreviation is called when production Abbreviation is entered.
+func (s *BaseFormulaListener) EnterAbbreviation(ctx *AbbreviationContext) {}
+
+// ExitAbbreviation is called when production Abbreviation is exited.
+func (s *BaseFormulaListener) ExitAbbreviation(ctx *AbbreviationContext) {}
+
+// EnterSchema is called when production Schema is entered.
+func (s *BaseFormulaListener) EnterSchema(ctx *SchemaContext) {}
+
+// ExitSchema is called when production Schema is exited.
+func (s *BaseFormulaListener) ExitSchema(ctx *SchemaContext) {}
+
+// EnterParameters is called when production parameters is entered.
+func (s *BaseFormulaListener) EnterParameters(ctx *ParametersContext) {}
+
+// ExitParameters is called when production parameters is exited.
+func (s *BaseFormulaListener) ExitParameters(ctx *ParametersContext) {}
+
+// EnterParenthesized is called when production Parenthesized is entered.
+func (s *BaseFormulaListener) EnterParenthesized(ctx *ParenthesizedContext) {}
+
+// ExitParenthesized is called when production Parenthesized is exited.
+func (s *BaseFormulaListener) ExitParenthesized(ctx *ParenthesizedContext) {}
 
 // EnterNegation is called when production Negation is entered.
 func (s *BaseFormulaListener) EnterNegation(ctx *NegationContext) {}
@@ -39,8 +69,44 @@ func (s *BaseFormulaListener) EnterNegation(ctx *NegationContext) {}
 // ExitNegation is called when production Negation is exited.
 func (s *BaseFormulaListener) ExitNegation(ctx *NegationContext) {}
 
+// EnterIte is called when production Ite is entered.
+func (s *BaseFormulaListener) EnterIte(ctx *IteContext) {}
+
+// ExitIte is called when production Ite is exited.
+func (s *BaseFormulaListener) ExitIte(ctx *IteContext) {}
+
+// EnterCardinality is called when production Cardinality is entered.
+func (s *BaseFormulaListener) EnterCardinality(ctx *CardinalityContext) {}
+
+// ExitCardinality is called when production Cardinality is exited.
+func (s *BaseFormulaListener) ExitCardinality(ctx *CardinalityContext) {}
+
+// EnterApplication is called when production Application is entered.
+func (s *BaseFormulaListener) EnterApplication(ctx *ApplicationContext) {}
+
+// ExitApplication is called when production Application is exited.
+func (s *BaseFormulaListener) ExitApplication(ctx *ApplicationContext) {}
+
 // EnterLetter is called when production Letter is entered.
 func (s *BaseFormulaListener) EnterLetter(ctx *LetterContext) {}
 
 // ExitLetter is called when production Letter is exited.
 func (s *BaseFormulaListener) ExitLetter(ctx *LetterContext) {}
+
+// EnterTop is called when production Top is entered.
+func (s *BaseFormulaListener) EnterTop(ctx *TopContext) {}
+
+// ExitTop is called when production Top is exited.
+func (s *BaseFormulaListener) ExitTop(ctx *TopContext) {}
+
+// EnterBottom is called when production Bottom is entered.
+func (s *BaseFormulaListener) EnterBottom(ctx *BottomContext) {}
+
+// ExitBottom is called when production Bottom is exited.
+func (s *BaseFormulaListener) ExitBottom(ctx *BottomContext) {}
+
+// EnterBinary is called when production Binary is entered.
+func (s *BaseFormulaListener) EnterBinary(ctx *BinaryContext) {}
+
+// ExitBinary is called when production Binary is exited.
+func (s *BaseFormulaListener) ExitBinary(ctx *BinaryContext) {}
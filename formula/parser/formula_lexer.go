@@ -44,44 +44,333 @@ func formulalexerLexerInit() {
 	}
 	staticData.LiteralNames = []string{
 		"", "'('", "')'", "'&'", "'|'", "'->'", "'<->'", "'!|'", "'!&'", "'^'",
-		"'!'",
+		"'!'", "'T'", "'F'", "','", "'|-'", "'let'", "'def'", "'='", "';'", "'<-'",
+		"'!->'", "'ite'", "'atmost'", "'atleast'", "'exactly'", "'\\left('", "'\\right)'",
 	}
 	staticData.SymbolicNames = []string{
 		"", "OP", "CP", "AND", "OR", "IMPLIES", "BICONDITIONAL", "NOR", "NAND",
-		"XOR", "NOT", "VARIABLE", "WHITESPACE",
+		"XOR", "NOT", "TOP", "BOTTOM", "COMMA", "TURNSTILE", "LET", "DEF", "EQUALS",
+		"SEMICOLON", "CONVERSE", "NONIMPLIES", "ITE", "ATMOST", "ATLEAST", "EXACTLY",
+		"OP_ALT", "CP_ALT", "AND_ALT", "OR_ALT", "IMPLIES_ALT", "BICONDITIONAL_ALT",
+		"NOR_ALT", "NAND_ALT", "XOR_ALT", "NOT_ALT", "TOP_ALT", "BOTTOM_ALT", "CONVERSE_ALT",
+		"NONIMPLIES_ALT", "ITE_ALT", "ATMOST_ALT", "ATLEAST_ALT", "EXACTLY_ALT",
+		"TURNSTILE_ALT", "VARIABLE", "WHITESPACE", "COMMENT",
 	}
 	staticData.RuleNames = []string{
 		"OP", "CP", "AND", "OR", "IMPLIES", "BICONDITIONAL", "NOR", "NAND",
-		"XOR", "NOT", "VARIABLE", "WHITESPACE",
+		"XOR", "NOT", "TOP", "BOTTOM", "COMMA", "TURNSTILE", "LET", "DEF",
+		"EQUALS", "SEMICOLON", "CONVERSE", "NONIMPLIES", "ITE", "ATMOST", "ATLEAST",
+		"EXACTLY", "OP_ALT", "CP_ALT", "AND_ALT", "OR_ALT", "IMPLIES_ALT",
+		"BICONDITIONAL_ALT", "NOR_ALT", "NAND_ALT", "XOR_ALT", "NOT_ALT", "TOP_ALT",
+		"BOTTOM_ALT", "CONVERSE_ALT", "NONIMPLIES_ALT", "ITE_ALT", "ATMOST_ALT",
+		"ATLEAST_ALT", "EXACTLY_ALT", "TURNSTILE_ALT", "VARIABLE", "WHITESPACE",
+		"COMMENT",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 0, 12, 62, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
+		4, 0, 46, 666, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
 		4, 7, 4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2,
-		10, 7, 10, 2, 11, 7, 11, 1, 0, 1, 0, 1, 1, 1, 1, 1, 2, 1, 2, 1, 3, 1, 3,
-		1, 4, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7,
-		1, 7, 1, 8, 1, 8, 1, 9, 1, 9, 1, 10, 4, 10, 52, 8, 10, 11, 10, 12, 10,
-		53, 1, 11, 4, 11, 57, 8, 11, 11, 11, 12, 11, 58, 1, 11, 1, 11, 0, 0, 12,
-		1, 1, 3, 2, 5, 3, 7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11,
-		23, 12, 1, 0, 2, 4, 0, 48, 57, 65, 90, 95, 95, 97, 122, 3, 0, 9, 10, 13,
-		13, 32, 32, 63, 0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0,
-		7, 1, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0,
-		0, 15, 1, 0, 0, 0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0,
-		0, 0, 23, 1, 0, 0, 0, 1, 25, 1, 0, 0, 0, 3, 27, 1, 0, 0, 0, 5, 29, 1, 0,
-		0, 0, 7, 31, 1, 0, 0, 0, 9, 33, 1, 0, 0, 0, 11, 36, 1, 0, 0, 0, 13, 40,
-		1, 0, 0, 0, 15, 43, 1, 0, 0, 0, 17, 46, 1, 0, 0, 0, 19, 48, 1, 0, 0, 0,
-		21, 51, 1, 0, 0, 0, 23, 56, 1, 0, 0, 0, 25, 26, 5, 40, 0, 0, 26, 2, 1,
-		0, 0, 0, 27, 28, 5, 41, 0, 0, 28, 4, 1, 0, 0, 0, 29, 30, 5, 38, 0, 0, 30,
-		6, 1, 0, 0, 0, 31, 32, 5, 124, 0, 0, 32, 8, 1, 0, 0, 0, 33, 34, 5, 45,
-		0, 0, 34, 35, 5, 62, 0, 0, 35, 10, 1, 0, 0, 0, 36, 37, 5, 60, 0, 0, 37,
-		38, 5, 45, 0, 0, 38, 39, 5, 62, 0, 0, 39, 12, 1, 0, 0, 0, 40, 41, 5, 33,
-		0, 0, 41, 42, 5, 124, 0, 0, 42, 14, 1, 0, 0, 0, 43, 44, 5, 33, 0, 0, 44,
-		45, 5, 38, 0, 0, 45, 16, 1, 0, 0, 0, 46, 47, 5, 94, 0, 0, 47, 18, 1, 0,
-		0, 0, 48, 49, 5, 33, 0, 0, 49, 20, 1, 0, 0, 0, 50, 52, 7, 0, 0, 0, 51,
-		50, 1, 0, 0, 0, 52, 53, 1, 0, 0, 0, 53, 51, 1, 0, 0, 0, 53, 54, 1, 0, 0,
-		0, 54, 22, 1, 0, 0, 0, 55, 57, 7, 1, 0, 0, 56, 55, 1, 0, 0, 0, 57, 58,
-		1, 0, 0, 0, 58, 56, 1, 0, 0, 0, 58, 59, 1, 0, 0, 0, 59, 60, 1, 0, 0, 0,
-		60, 61, 6, 11, 0, 0, 61, 24, 1, 0, 0, 0, 3, 0, 53, 58, 1, 6, 0, 0,
+		10, 7, 10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15,
+		7, 15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7,
+		20, 2, 21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 2, 24, 7, 24, 2, 25, 7, 25,
+		2, 26, 7, 26, 2, 27, 7, 27, 2, 28, 7, 28, 2, 29, 7, 29, 2, 30, 7, 30, 2,
+		31, 7, 31, 2, 32, 7, 32, 2, 33, 7, 33, 2, 34, 7, 34, 2, 35, 7, 35, 2, 36,
+		7, 36, 2, 37, 7, 37, 2, 38, 7, 38, 2, 39, 7, 39, 2, 40, 7, 40, 2, 41, 7,
+		41, 2, 42, 7, 42, 2, 43, 7, 43, 2, 44, 7, 44, 2, 45, 7, 45, 1, 0, 1, 0,
+		1, 1, 1, 1, 1, 2, 1, 2, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5,
+		1, 5, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 1, 8, 1, 8, 1, 9, 1, 9, 1, 10,
+		1, 10, 1, 11, 1, 11, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14, 1,
+		14, 1, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 16, 1, 16, 1, 17, 1, 17, 1, 18,
+		1, 18, 1, 18, 1, 19, 1, 19, 1, 19, 1, 19, 1, 20, 1, 20, 1, 20, 1, 20, 1,
+		21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 22, 1, 22, 1, 22, 1, 22,
+		1, 22, 1, 22, 1, 22, 1, 22, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1,
+		23, 1, 23, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24,
+		1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1,
+		26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26,
+		1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 3, 26, 210, 8, 26, 1, 26, 1,
+		26, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27,
+		1, 27, 1, 27, 1, 27, 3, 27, 227, 8, 27, 1, 27, 1, 27, 1, 28, 1, 28, 1,
+		28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28,
+		1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1,
+		28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28,
+		1, 28, 1, 28, 3, 28, 267, 8, 28, 1, 28, 1, 28, 1, 29, 1, 29, 1, 29, 1,
+		29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29,
+		1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1,
+		29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29,
+		1, 29, 1, 29, 1, 29, 3, 29, 309, 8, 29, 1, 29, 1, 29, 1, 30, 1, 30, 1,
+		30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30,
+		1, 30, 3, 30, 327, 8, 30, 1, 30, 1, 30, 1, 31, 1, 31, 1, 31, 1, 31, 1,
+		31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 3, 31, 344,
+		8, 31, 1, 31, 1, 31, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1,
+		32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 3, 32,
+		365, 8, 32, 1, 32, 1, 32, 1, 33, 1, 33, 1, 33, 1, 33, 1, 33, 1, 33, 1,
+		33, 1, 33, 1, 33, 1, 33, 1, 33, 1, 33, 1, 33, 3, 33, 382, 8, 33, 1, 33,
+		1, 33, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 3,
+		34, 395, 8, 34, 1, 34, 1, 34, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35,
+		1, 35, 1, 35, 1, 35, 1, 35, 3, 35, 409, 8, 35, 1, 35, 1, 35, 1, 36, 1,
+		36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36,
+		1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 1,
+		36, 3, 36, 436, 8, 36, 1, 36, 1, 36, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37,
+		1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 3, 37, 453, 8,
+		37, 1, 37, 1, 37, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38,
+		1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1,
+		38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38,
+		1, 38, 3, 38, 487, 8, 38, 1, 38, 1, 38, 1, 39, 1, 39, 1, 39, 1, 39, 1,
+		39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39,
+		1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1,
+		39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39,
+		3, 39, 527, 8, 39, 1, 39, 1, 39, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1,
+		40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40,
+		1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1,
+		40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40,
+		1, 40, 3, 40, 569, 8, 40, 1, 40, 1, 40, 1, 41, 1, 41, 1, 41, 1, 41, 1,
+		41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41,
+		1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1,
+		41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41,
+		1, 41, 1, 41, 3, 41, 611, 8, 41, 1, 41, 1, 41, 1, 42, 1, 42, 1, 42, 1,
+		42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42,
+		1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1,
+		42, 1, 42, 3, 42, 641, 8, 42, 1, 42, 1, 42, 1, 43, 4, 43, 646, 8, 43, 11,
+		43, 12, 43, 647, 1, 44, 4, 44, 651, 8, 44, 11, 44, 12, 44, 652, 1, 44,
+		1, 44, 1, 45, 1, 45, 1, 45, 5, 45, 660, 8, 45, 10, 45, 12, 45, 663, 9,
+		45, 1, 45, 1, 45, 0, 0, 46, 1, 1, 3, 2, 5, 3, 7, 4, 9, 5, 11, 6, 13, 7,
+		15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25, 13, 27, 14, 29, 15, 31, 16, 33,
+		17, 35, 18, 37, 19, 39, 20, 41, 21, 43, 22, 45, 23, 47, 24, 49, 25, 51,
+		26, 53, 27, 55, 28, 57, 29, 59, 30, 61, 31, 63, 32, 65, 33, 67, 34, 69,
+		35, 71, 36, 73, 37, 75, 38, 77, 39, 79, 40, 81, 41, 83, 42, 85, 43, 87,
+		44, 89, 45, 91, 46, 1, 0, 9, 2, 0, 8594, 8594, 8658, 8658, 2, 0, 8596,
+		8596, 8660, 8660, 2, 0, 8853, 8853, 8891, 8891, 3, 0, 45, 45, 126, 126,
+		172, 172, 2, 0, 8592, 8592, 8656, 8656, 2, 0, 8756, 8756, 8866, 8866, 4,
+		0, 48, 57, 65, 90, 95, 95, 97, 122, 3, 0, 9, 10, 13, 13, 32, 32, 2, 0,
+		10, 10, 13, 13, 710, 0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0,
+		0, 0, 7, 1, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0,
+		0, 0, 0, 15, 1, 0, 0, 0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1,
+		0, 0, 0, 0, 23, 1, 0, 0, 0, 0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29,
+		1, 0, 0, 0, 0, 31, 1, 0, 0, 0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0,
+		37, 1, 0, 0, 0, 0, 39, 1, 0, 0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0,
+		0, 45, 1, 0, 0, 0, 0, 47, 1, 0, 0, 0, 0, 49, 1, 0, 0, 0, 0, 51, 1, 0, 0,
+		0, 0, 53, 1, 0, 0, 0, 0, 55, 1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0, 59, 1, 0,
+		0, 0, 0, 61, 1, 0, 0, 0, 0, 63, 1, 0, 0, 0, 0, 65, 1, 0, 0, 0, 0, 67, 1,
+		0, 0, 0, 0, 69, 1, 0, 0, 0, 0, 71, 1, 0, 0, 0, 0, 73, 1, 0, 0, 0, 0, 75,
+		1, 0, 0, 0, 0, 77, 1, 0, 0, 0, 0, 79, 1, 0, 0, 0, 0, 81, 1, 0, 0, 0, 0,
+		83, 1, 0, 0, 0, 0, 85, 1, 0, 0, 0, 0, 87, 1, 0, 0, 0, 0, 89, 1, 0, 0, 0,
+		0, 91, 1, 0, 0, 0, 1, 93, 1, 0, 0, 0, 3, 95, 1, 0, 0, 0, 5, 97, 1, 0, 0,
+		0, 7, 99, 1, 0, 0, 0, 9, 101, 1, 0, 0, 0, 11, 104, 1, 0, 0, 0, 13, 108,
+		1, 0, 0, 0, 15, 111, 1, 0, 0, 0, 17, 114, 1, 0, 0, 0, 19, 116, 1, 0, 0,
+		0, 21, 118, 1, 0, 0, 0, 23, 120, 1, 0, 0, 0, 25, 122, 1, 0, 0, 0, 27, 124,
+		1, 0, 0, 0, 29, 127, 1, 0, 0, 0, 31, 131, 1, 0, 0, 0, 33, 135, 1, 0, 0,
+		0, 35, 137, 1, 0, 0, 0, 37, 139, 1, 0, 0, 0, 39, 142, 1, 0, 0, 0, 41, 146,
+		1, 0, 0, 0, 43, 150, 1, 0, 0, 0, 45, 157, 1, 0, 0, 0, 47, 165, 1, 0, 0,
+		0, 49, 173, 1, 0, 0, 0, 51, 182, 1, 0, 0, 0, 53, 209, 1, 0, 0, 0, 55, 226,
+		1, 0, 0, 0, 57, 266, 1, 0, 0, 0, 59, 308, 1, 0, 0, 0, 61, 326, 1, 0, 0,
+		0, 63, 343, 1, 0, 0, 0, 65, 364, 1, 0, 0, 0, 67, 381, 1, 0, 0, 0, 69, 394,
+		1, 0, 0, 0, 71, 408, 1, 0, 0, 0, 73, 435, 1, 0, 0, 0, 75, 452, 1, 0, 0,
+		0, 77, 486, 1, 0, 0, 0, 79, 526, 1, 0, 0, 0, 81, 568, 1, 0, 0, 0, 83, 610,
+		1, 0, 0, 0, 85, 640, 1, 0, 0, 0, 87, 645, 1, 0, 0, 0, 89, 650, 1, 0, 0,
+		0, 91, 656, 1, 0, 0, 0, 93, 94, 5, 40, 0, 0, 94, 2, 1, 0, 0, 0, 95, 96,
+		5, 41, 0, 0, 96, 4, 1, 0, 0, 0, 97, 98, 5, 38, 0, 0, 98, 6, 1, 0, 0, 0,
+		99, 100, 5, 124, 0, 0, 100, 8, 1, 0, 0, 0, 101, 102, 5, 45, 0, 0, 102,
+		103, 5, 62, 0, 0, 103, 10, 1, 0, 0, 0, 104, 105, 5, 60, 0, 0, 105, 106,
+		5, 45, 0, 0, 106, 107, 5, 62, 0, 0, 107, 12, 1, 0, 0, 0, 108, 109, 5, 33,
+		0, 0, 109, 110, 5, 124, 0, 0, 110, 14, 1, 0, 0, 0, 111, 112, 5, 33, 0,
+		0, 112, 113, 5, 38, 0, 0, 113, 16, 1, 0, 0, 0, 114, 115, 5, 94, 0, 0, 115,
+		18, 1, 0, 0, 0, 116, 117, 5, 33, 0, 0, 117, 20, 1, 0, 0, 0, 118, 119, 5,
+		84, 0, 0, 119, 22, 1, 0, 0, 0, 120, 121, 5, 70, 0, 0, 121, 24, 1, 0, 0,
+		0, 122, 123, 5, 44, 0, 0, 123, 26, 1, 0, 0, 0, 124, 125, 5, 124, 0, 0,
+		125, 126, 5, 45, 0, 0, 126, 28, 1, 0, 0, 0, 127, 128, 5, 108, 0, 0, 128,
+		129, 5, 101, 0, 0, 129, 130, 5, 116, 0, 0, 130, 30, 1, 0, 0, 0, 131, 132,
+		5, 100, 0, 0, 132, 133, 5, 101, 0, 0, 133, 134, 5, 102, 0, 0, 134, 32,
+		1, 0, 0, 0, 135, 136, 5, 61, 0, 0, 136, 34, 1, 0, 0, 0, 137, 138, 5, 59,
+		0, 0, 138, 36, 1, 0, 0, 0, 139, 140, 5, 60, 0, 0, 140, 141, 5, 45, 0, 0,
+		141, 38, 1, 0, 0, 0, 142, 143, 5, 33, 0, 0, 143, 144, 5, 45, 0, 0, 144,
+		145, 5, 62, 0, 0, 145, 40, 1, 0, 0, 0, 146, 147, 5, 105, 0, 0, 147, 148,
+		5, 116, 0, 0, 148, 149, 5, 101, 0, 0, 149, 42, 1, 0, 0, 0, 150, 151, 5,
+		97, 0, 0, 151, 152, 5, 116, 0, 0, 152, 153, 5, 109, 0, 0, 153, 154, 5,
+		111, 0, 0, 154, 155, 5, 115, 0, 0, 155, 156, 5, 116, 0, 0, 156, 44, 1,
+		0, 0, 0, 157, 158, 5, 97, 0, 0, 158, 159, 5, 116, 0, 0, 159, 160, 5, 108,
+		0, 0, 160, 161, 5, 101, 0, 0, 161, 162, 5, 97, 0, 0, 162, 163, 5, 115,
+		0, 0, 163, 164, 5, 116, 0, 0, 164, 46, 1, 0, 0, 0, 165, 166, 5, 101, 0,
+		0, 166, 167, 5, 120, 0, 0, 167, 168, 5, 97, 0, 0, 168, 169, 5, 99, 0, 0,
+		169, 170, 5, 116, 0, 0, 170, 171, 5, 108, 0, 0, 171, 172, 5, 121, 0, 0,
+		172, 48, 1, 0, 0, 0, 173, 174, 5, 92, 0, 0, 174, 175, 5, 108, 0, 0, 175,
+		176, 5, 101, 0, 0, 176, 177, 5, 102, 0, 0, 177, 178, 5, 116, 0, 0, 178,
+		179, 5, 40, 0, 0, 179, 180, 1, 0, 0, 0, 180, 181, 6, 24, 0, 0, 181, 50,
+		1, 0, 0, 0, 182, 183, 5, 92, 0, 0, 183, 184, 5, 114, 0, 0, 184, 185, 5,
+		105, 0, 0, 185, 186, 5, 103, 0, 0, 186, 187, 5, 104, 0, 0, 187, 188, 5,
+		116, 0, 0, 188, 189, 5, 41, 0, 0, 189, 190, 1, 0, 0, 0, 190, 191, 6, 25,
+		1, 0, 191, 52, 1, 0, 0, 0, 192, 210, 5, 8743, 0, 0, 193, 194, 5, 47, 0,
+		0, 194, 210, 5, 92, 0, 0, 195, 196, 5, 97, 0, 0, 196, 197, 5, 110, 0, 0,
+		197, 210, 5, 100, 0, 0, 198, 199, 5, 92, 0, 0, 199, 200, 5, 108, 0, 0,
+		200, 201, 5, 97, 0, 0, 201, 202, 5, 110, 0, 0, 202, 210, 5, 100, 0, 0,
+		203, 204, 5, 92, 0, 0, 204, 205, 5, 119, 0, 0, 205, 206, 5, 101, 0, 0,
+		206, 207, 5, 100, 0, 0, 207, 208, 5, 103, 0, 0, 208, 210, 5, 101, 0, 0,
+		209, 192, 1, 0, 0, 0, 209, 193, 1, 0, 0, 0, 209, 195, 1, 0, 0, 0, 209,
+		198, 1, 0, 0, 0, 209, 203, 1, 0, 0, 0, 210, 211, 1, 0, 0, 0, 211, 212,
+		6, 26, 2, 0, 212, 54, 1, 0, 0, 0, 213, 227, 5, 8744, 0, 0, 214, 215, 5,
+		92, 0, 0, 215, 227, 5, 47, 0, 0, 216, 217, 5, 111, 0, 0, 217, 227, 5, 114,
+		0, 0, 218, 219, 5, 92, 0, 0, 219, 220, 5, 108, 0, 0, 220, 221, 5, 111,
+		0, 0, 221, 227, 5, 114, 0, 0, 222, 223, 5, 92, 0, 0, 223, 224, 5, 118,
+		0, 0, 224, 225, 5, 101, 0, 0, 225, 227, 5, 101, 0, 0, 226, 213, 1, 0, 0,
+		0, 226, 214, 1, 0, 0, 0, 226, 216, 1, 0, 0, 0, 226, 218, 1, 0, 0, 0, 226,
+		222, 1, 0, 0, 0, 227, 228, 1, 0, 0, 0, 228, 229, 6, 27, 3, 0, 229, 56,
+		1, 0, 0, 0, 230, 267, 7, 0, 0, 0, 231, 232, 5, 61, 0, 0, 232, 267, 5, 62,
+		0, 0, 233, 234, 5, 92, 0, 0, 234, 235, 5, 116, 0, 0, 235, 267, 5, 111,
+		0, 0, 236, 237, 5, 92, 0, 0, 237, 238, 5, 114, 0, 0, 238, 239, 5, 105,
+		0, 0, 239, 240, 5, 103, 0, 0, 240, 241, 5, 104, 0, 0, 241, 242, 5, 116,
+		0, 0, 242, 243, 5, 97, 0, 0, 243, 244, 5, 114, 0, 0, 244, 245, 5, 114,
+		0, 0, 245, 246, 5, 111, 0, 0, 246, 267, 5, 119, 0, 0, 247, 248, 5, 92,
+		0, 0, 248, 249, 5, 82, 0, 0, 249, 250, 5, 105, 0, 0, 250, 251, 5, 103,
+		0, 0, 251, 252, 5, 104, 0, 0, 252, 253, 5, 116, 0, 0, 253, 254, 5, 97,
+		0, 0, 254, 255, 5, 114, 0, 0, 255, 256, 5, 114, 0, 0, 256, 257, 5, 111,
+		0, 0, 257, 267, 5, 119, 0, 0, 258, 259, 5, 92, 0, 0, 259, 260, 5, 105,
+		0, 0, 260, 261, 5, 109, 0, 0, 261, 262, 5, 112, 0, 0, 262, 263, 5, 108,
+		0, 0, 263, 264, 5, 105, 0, 0, 264, 265, 5, 101, 0, 0, 265, 267, 5, 115,
+		0, 0, 266, 230, 1, 0, 0, 0, 266, 231, 1, 0, 0, 0, 266, 233, 1, 0, 0, 0,
+		266, 236, 1, 0, 0, 0, 266, 247, 1, 0, 0, 0, 266, 258, 1, 0, 0, 0, 267,
+		268, 1, 0, 0, 0, 268, 269, 6, 28, 4, 0, 269, 58, 1, 0, 0, 0, 270, 309,
+		7, 1, 0, 0, 271, 272, 5, 60, 0, 0, 272, 273, 5, 61, 0, 0, 273, 309, 5,
+		62, 0, 0, 274, 275, 5, 92, 0, 0, 275, 276, 5, 108, 0, 0, 276, 277, 5, 101,
+		0, 0, 277, 278, 5, 102, 0, 0, 278, 279, 5, 116, 0, 0, 279, 280, 5, 114,
+		0, 0, 280, 281, 5, 105, 0, 0, 281, 282, 5, 103, 0, 0, 282, 283, 5, 104,
+		0, 0, 283, 284, 5, 116, 0, 0, 284, 285, 5, 97, 0, 0, 285, 286, 5, 114,
+		0, 0, 286, 287, 5, 114, 0, 0, 287, 288, 5, 111, 0, 0, 288, 309, 5, 119,
+		0, 0, 289, 290, 5, 92, 0, 0, 290, 291, 5, 76, 0, 0, 291, 292, 5, 101, 0,
+		0, 292, 293, 5, 102, 0, 0, 293, 294, 5, 116, 0, 0, 294, 295, 5, 114, 0,
+		0, 295, 296, 5, 105, 0, 0, 296, 297, 5, 103, 0, 0, 297, 298, 5, 104, 0,
+		0, 298, 299, 5, 116, 0, 0, 299, 300, 5, 97, 0, 0, 300, 301, 5, 114, 0,
+		0, 301, 302, 5, 114, 0, 0, 302, 303, 5, 111, 0, 0, 303, 309, 5, 119, 0,
+		0, 304, 305, 5, 92, 0, 0, 305, 306, 5, 105, 0, 0, 306, 307, 5, 102, 0,
+		0, 307, 309, 5, 102, 0, 0, 308, 270, 1, 0, 0, 0, 308, 271, 1, 0, 0, 0,
+		308, 274, 1, 0, 0, 0, 308, 289, 1, 0, 0, 0, 308, 304, 1, 0, 0, 0, 309,
+		310, 1, 0, 0, 0, 310, 311, 6, 29, 5, 0, 311, 60, 1, 0, 0, 0, 312, 327,
+		5, 8595, 0, 0, 313, 314, 5, 110, 0, 0, 314, 315, 5, 111, 0, 0, 315, 327,
+		5, 114, 0, 0, 316, 317, 5, 92, 0, 0, 317, 318, 5, 100, 0, 0, 318, 319,
+		5, 111, 0, 0, 319, 320, 5, 119, 0, 0, 320, 321, 5, 110, 0, 0, 321, 322,
+		5, 97, 0, 0, 322, 323, 5, 114, 0, 0, 323, 324, 5, 114, 0, 0, 324, 325,
+		5, 111, 0, 0, 325, 327, 5, 119, 0, 0, 326, 312, 1, 0, 0, 0, 326, 313, 1,
+		0, 0, 0, 326, 316, 1, 0, 0, 0, 327, 328, 1, 0, 0, 0, 328, 329, 6, 30, 6,
+		0, 329, 62, 1, 0, 0, 0, 330, 344, 5, 8593, 0, 0, 331, 332, 5, 110, 0, 0,
+		332, 333, 5, 97, 0, 0, 333, 334, 5, 110, 0, 0, 334, 344, 5, 100, 0, 0,
+		335, 336, 5, 92, 0, 0, 336, 337, 5, 117, 0, 0, 337, 338, 5, 112, 0, 0,
+		338, 339, 5, 97, 0, 0, 339, 340, 5, 114, 0, 0, 340, 341, 5, 114, 0, 0,
+		341, 342, 5, 111, 0, 0, 342, 344, 5, 119, 0, 0, 343, 330, 1, 0, 0, 0, 343,
+		331, 1, 0, 0, 0, 343, 335, 1, 0, 0, 0, 344, 345, 1, 0, 0, 0, 345, 346,
+		6, 31, 7, 0, 346, 64, 1, 0, 0, 0, 347, 365, 7, 2, 0, 0, 348, 349, 5, 120,
+		0, 0, 349, 350, 5, 111, 0, 0, 350, 365, 5, 114, 0, 0, 351, 352, 5, 92,
+		0, 0, 352, 353, 5, 111, 0, 0, 353, 354, 5, 112, 0, 0, 354, 355, 5, 108,
+		0, 0, 355, 356, 5, 117, 0, 0, 356, 365, 5, 115, 0, 0, 357, 358, 5, 92,
+		0, 0, 358, 359, 5, 118, 0, 0, 359, 360, 5, 101, 0, 0, 360, 361, 5, 101,
+		0, 0, 361, 362, 5, 98, 0, 0, 362, 363, 5, 97, 0, 0, 363, 365, 5, 114, 0,
+		0, 364, 347, 1, 0, 0, 0, 364, 348, 1, 0, 0, 0, 364, 351, 1, 0, 0, 0, 364,
+		357, 1, 0, 0, 0, 365, 366, 1, 0, 0, 0, 366, 367, 6, 32, 8, 0, 367, 66,
+		1, 0, 0, 0, 368, 382, 7, 3, 0, 0, 369, 370, 5, 110, 0, 0, 370, 371, 5,
+		111, 0, 0, 371, 382, 5, 116, 0, 0, 372, 373, 5, 92, 0, 0, 373, 374, 5,
+		110, 0, 0, 374, 375, 5, 101, 0, 0, 375, 382, 5, 103, 0, 0, 376, 377, 5,
+		92, 0, 0, 377, 378, 5, 108, 0, 0, 378, 379, 5, 110, 0, 0, 379, 380, 5,
+		111, 0, 0, 380, 382, 5, 116, 0, 0, 381, 368, 1, 0, 0, 0, 381, 369, 1, 0,
+		0, 0, 381, 372, 1, 0, 0, 0, 381, 376, 1, 0, 0, 0, 382, 383, 1, 0, 0, 0,
+		383, 384, 6, 33, 9, 0, 384, 68, 1, 0, 0, 0, 385, 395, 5, 8868, 0, 0, 386,
+		387, 5, 116, 0, 0, 387, 388, 5, 114, 0, 0, 388, 389, 5, 117, 0, 0, 389,
+		395, 5, 101, 0, 0, 390, 391, 5, 92, 0, 0, 391, 392, 5, 116, 0, 0, 392,
+		393, 5, 111, 0, 0, 393, 395, 5, 112, 0, 0, 394, 385, 1, 0, 0, 0, 394, 386,
+		1, 0, 0, 0, 394, 390, 1, 0, 0, 0, 395, 396, 1, 0, 0, 0, 396, 397, 6, 34,
+		10, 0, 397, 70, 1, 0, 0, 0, 398, 409, 5, 8869, 0, 0, 399, 400, 5, 102,
+		0, 0, 400, 401, 5, 97, 0, 0, 401, 402, 5, 108, 0, 0, 402, 403, 5, 115,
+		0, 0, 403, 409, 5, 101, 0, 0, 404, 405, 5, 92, 0, 0, 405, 406, 5, 98, 0,
+		0, 406, 407, 5, 111, 0, 0, 407, 409, 5, 116, 0, 0, 408, 398, 1, 0, 0, 0,
+		408, 399, 1, 0, 0, 0, 408, 404, 1, 0, 0, 0, 409, 410, 1, 0, 0, 0, 410,
+		411, 6, 35, 11, 0, 411, 72, 1, 0, 0, 0, 412, 436, 7, 4, 0, 0, 413, 414,
+		5, 60, 0, 0, 414, 436, 5, 61, 0, 0, 415, 416, 5, 92, 0, 0, 416, 417, 5,
+		108, 0, 0, 417, 418, 5, 101, 0, 0, 418, 419, 5, 102, 0, 0, 419, 420, 5,
+		116, 0, 0, 420, 421, 5, 97, 0, 0, 421, 422, 5, 114, 0, 0, 422, 423, 5,
+		114, 0, 0, 423, 424, 5, 111, 0, 0, 424, 436, 5, 119, 0, 0, 425, 426, 5,
+		92, 0, 0, 426, 427, 5, 76, 0, 0, 427, 428, 5, 101, 0, 0, 428, 429, 5, 102,
+		0, 0, 429, 430, 5, 116, 0, 0, 430, 431, 5, 97, 0, 0, 431, 432, 5, 114,
+		0, 0, 432, 433, 5, 114, 0, 0, 433, 434, 5, 111, 0, 0, 434, 436, 5, 119,
+		0, 0, 435, 412, 1, 0, 0, 0, 435, 413, 1, 0, 0, 0, 435, 415, 1, 0, 0, 0,
+		435, 425, 1, 0, 0, 0, 436, 437, 1, 0, 0, 0, 437, 438, 6, 36, 12, 0, 438,
+		74, 1, 0, 0, 0, 439, 453, 5, 8603, 0, 0, 440, 441, 5, 92, 0, 0, 441, 442,
+		5, 110, 0, 0, 442, 443, 5, 114, 0, 0, 443, 444, 5, 105, 0, 0, 444, 445,
+		5, 103, 0, 0, 445, 446, 5, 104, 0, 0, 446, 447, 5, 116, 0, 0, 447, 448,
+		5, 97, 0, 0, 448, 449, 5, 114, 0, 0, 449, 450, 5, 114, 0, 0, 450, 451,
+		5, 111, 0, 0, 451, 453, 5, 119, 0, 0, 452, 439, 1, 0, 0, 0, 452, 440, 1,
+		0, 0, 0, 453, 454, 1, 0, 0, 0, 454, 455, 6, 37, 13, 0, 455, 76, 1, 0, 0,
+		0, 456, 457, 5, 92, 0, 0, 457, 458, 5, 109, 0, 0, 458, 459, 5, 97, 0, 0,
+		459, 460, 5, 116, 0, 0, 460, 461, 5, 104, 0, 0, 461, 462, 5, 114, 0, 0,
+		462, 463, 5, 109, 0, 0, 463, 464, 5, 123, 0, 0, 464, 465, 5, 105, 0, 0,
+		465, 466, 5, 116, 0, 0, 466, 467, 5, 101, 0, 0, 467, 487, 5, 125, 0, 0,
+		468, 469, 5, 92, 0, 0, 469, 470, 5, 111, 0, 0, 470, 471, 5, 112, 0, 0,
+		471, 472, 5, 101, 0, 0, 472, 473, 5, 114, 0, 0, 473, 474, 5, 97, 0, 0,
+		474, 475, 5, 116, 0, 0, 475, 476, 5, 111, 0, 0, 476, 477, 5, 114, 0, 0,
+		477, 478, 5, 110, 0, 0, 478, 479, 5, 97, 0, 0, 479, 480, 5, 109, 0, 0,
+		480, 481, 5, 101, 0, 0, 481, 482, 5, 123, 0, 0, 482, 483, 5, 105, 0, 0,
+		483, 484, 5, 116, 0, 0, 484, 485, 5, 101, 0, 0, 485, 487, 5, 125, 0, 0,
+		486, 456, 1, 0, 0, 0, 486, 468, 1, 0, 0, 0, 487, 488, 1, 0, 0, 0, 488,
+		489, 6, 38, 14, 0, 489, 78, 1, 0, 0, 0, 490, 491, 5, 92, 0, 0, 491, 492,
+		5, 109, 0, 0, 492, 493, 5, 97, 0, 0, 493, 494, 5, 116, 0, 0, 494, 495,
+		5, 104, 0, 0, 495, 496, 5, 114, 0, 0, 496, 497, 5, 109, 0, 0, 497, 498,
+		5, 123, 0, 0, 498, 499, 5, 97, 0, 0, 499, 500, 5, 116, 0, 0, 500, 501,
+		5, 109, 0, 0, 501, 502, 5, 111, 0, 0, 502, 503, 5, 115, 0, 0, 503, 504,
+		5, 116, 0, 0, 504, 527, 5, 125, 0, 0, 505, 506, 5, 92, 0, 0, 506, 507,
+		5, 111, 0, 0, 507, 508, 5, 112, 0, 0, 508, 509, 5, 101, 0, 0, 509, 510,
+		5, 114, 0, 0, 510, 511, 5, 97, 0, 0, 511, 512, 5, 116, 0, 0, 512, 513,
+		5, 111, 0, 0, 513, 514, 5, 114, 0, 0, 514, 515, 5, 110, 0, 0, 515, 516,
+		5, 97, 0, 0, 516, 517, 5, 109, 0, 0, 517, 518, 5, 101, 0, 0, 518, 519,
+		5, 123, 0, 0, 519, 520, 5, 97, 0, 0, 520, 521, 5, 116, 0, 0, 521, 522,
+		5, 109, 0, 0, 522, 523, 5, 111, 0, 0, 523, 524, 5, 115, 0, 0, 524, 525,
+		5, 116, 0, 0, 525, 527, 5, 125, 0, 0, 526, 490, 1, 0, 0, 0, 526, 505, 1,
+		0, 0, 0, 527, 528, 1, 0, 0, 0, 528, 529, 6, 39, 15, 0, 529, 80, 1, 0, 0,
+		0, 530, 531, 5, 92, 0, 0, 531, 532, 5, 109, 0, 0, 532, 533, 5, 97, 0, 0,
+		533, 534, 5, 116, 0, 0, 534, 535, 5, 104, 0, 0, 535, 536, 5, 114, 0, 0,
+		536, 537, 5, 109, 0, 0, 537, 538, 5, 123, 0, 0, 538, 539, 5, 97, 0, 0,
+		539, 540, 5, 116, 0, 0, 540, 541, 5, 108, 0, 0, 541, 542, 5, 101, 0, 0,
+		542, 543, 5, 97, 0, 0, 543, 544, 5, 115, 0, 0, 544, 545, 5, 116, 0, 0,
+		545, 569, 5, 125, 0, 0, 546, 547, 5, 92, 0, 0, 547, 548, 5, 111, 0, 0,
+		548, 549, 5, 112, 0, 0, 549, 550, 5, 101, 0, 0, 550, 551, 5, 114, 0, 0,
+		551, 552, 5, 97, 0, 0, 552, 553, 5, 116, 0, 0, 553, 554, 5, 111, 0, 0,
+		554, 555, 5, 114, 0, 0, 555, 556, 5, 110, 0, 0, 556, 557, 5, 97, 0, 0,
+		557, 558, 5, 109, 0, 0, 558, 559, 5, 101, 0, 0, 559, 560, 5, 123, 0, 0,
+		560, 561, 5, 97, 0, 0, 561, 562, 5, 116, 0, 0, 562, 563, 5, 108, 0, 0,
+		563, 564, 5, 101, 0, 0, 564, 565, 5, 97, 0, 0, 565, 566, 5, 115, 0, 0,
+		566, 567, 5, 116, 0, 0, 567, 569, 5, 125, 0, 0, 568, 530, 1, 0, 0, 0, 568,
+		546, 1, 0, 0, 0, 569, 570, 1, 0, 0, 0, 570, 571, 6, 40, 16, 0, 571, 82,
+		1, 0, 0, 0, 572, 573, 5, 92, 0, 0, 573, 574, 5, 109, 0, 0, 574, 575, 5,
+		97, 0, 0, 575, 576, 5, 116, 0, 0, 576, 577, 5, 104, 0, 0, 577, 578, 5,
+		114, 0, 0, 578, 579, 5, 109, 0, 0, 579, 580, 5, 123, 0, 0, 580, 581, 5,
+		101, 0, 0, 581, 582, 5, 120, 0, 0, 582, 583, 5, 97, 0, 0, 583, 584, 5,
+		99, 0, 0, 584, 585, 5, 116, 0, 0, 585, 586, 5, 108, 0, 0, 586, 587, 5,
+		121, 0, 0, 587, 611, 5, 125, 0, 0, 588, 589, 5, 92, 0, 0, 589, 590, 5,
+		111, 0, 0, 590, 591, 5, 112, 0, 0, 591, 592, 5, 101, 0, 0, 592, 593, 5,
+		114, 0, 0, 593, 594, 5, 97, 0, 0, 594, 595, 5, 116, 0, 0, 595, 596, 5,
+		111, 0, 0, 596, 597, 5, 114, 0, 0, 597, 598, 5, 110, 0, 0, 598, 599, 5,
+		97, 0, 0, 599, 600, 5, 109, 0, 0, 600, 601, 5, 101, 0, 0, 601, 602, 5,
+		123, 0, 0, 602, 603, 5, 101, 0, 0, 603, 604, 5, 120, 0, 0, 604, 605, 5,
+		97, 0, 0, 605, 606, 5, 99, 0, 0, 606, 607, 5, 116, 0, 0, 607, 608, 5, 108,
+		0, 0, 608, 609, 5, 121, 0, 0, 609, 611, 5, 125, 0, 0, 610, 572, 1, 0, 0,
+		0, 610, 588, 1, 0, 0, 0, 611, 612, 1, 0, 0, 0, 612, 613, 6, 41, 17, 0,
+		613, 84, 1, 0, 0, 0, 614, 641, 7, 5, 0, 0, 615, 616, 5, 116, 0, 0, 616,
+		617, 5, 104, 0, 0, 617, 618, 5, 101, 0, 0, 618, 619, 5, 114, 0, 0, 619,
+		620, 5, 101, 0, 0, 620, 621, 5, 102, 0, 0, 621, 622, 5, 111, 0, 0, 622,
+		623, 5, 114, 0, 0, 623, 641, 5, 101, 0, 0, 624, 625, 5, 92, 0, 0, 625,
+		626, 5, 118, 0, 0, 626, 627, 5, 100, 0, 0, 627, 628, 5, 97, 0, 0, 628,
+		629, 5, 115, 0, 0, 629, 641, 5, 104, 0, 0, 630, 631, 5, 92, 0, 0, 631,
+		632, 5, 116, 0, 0, 632, 633, 5, 104, 0, 0, 633, 634, 5, 101, 0, 0, 634,
+		635, 5, 114, 0, 0, 635, 636, 5, 101, 0, 0, 636, 637, 5, 102, 0, 0, 637,
+		638, 5, 111, 0, 0, 638, 639, 5, 114, 0, 0, 639, 641, 5, 101, 0, 0, 640,
+		614, 1, 0, 0, 0, 640, 615, 1, 0, 0, 0, 640, 624, 1, 0, 0, 0, 640, 630,
+		1, 0, 0, 0, 641, 642, 1, 0, 0, 0, 642, 643, 6, 42, 18, 0, 643, 86, 1, 0,
+		0, 0, 644, 646, 7, 6, 0, 0, 645, 644, 1, 0, 0, 0, 646, 647, 1, 0, 0, 0,
+		647, 645, 1, 0, 0, 0, 647, 648, 1, 0, 0, 0, 648, 88, 1, 0, 0, 0, 649, 651,
+		7, 7, 0, 0, 650, 649, 1, 0, 0, 0, 651, 652, 1, 0, 0, 0, 652, 650, 1, 0,
+		0, 0, 652, 653, 1, 0, 0, 0, 653, 654, 1, 0, 0, 0, 654, 655, 6, 44, 19,
+		0, 655, 90, 1, 0, 0, 0, 656, 661, 5, 35, 0, 0, 657, 658, 8, 8, 0, 0, 658,
+		660, 1, 0, 0, 0, 659, 657, 1, 0, 0, 0, 660, 663, 1, 0, 0, 0, 661, 659,
+		1, 0, 0, 0, 661, 662, 1, 0, 0, 0, 662, 664, 1, 0, 0, 0, 663, 661, 1, 0,
+		0, 0, 664, 665, 6, 45, 19, 0, 665, 92, 1, 0, 0, 0, 21, 0, 209, 226, 266,
+		308, 326, 343, 364, 381, 394, 408, 435, 452, 486, 526, 568, 610, 640, 647,
+		652, 661, 20, 7, 1, 0, 7, 2, 0, 7, 3, 0, 7, 4, 0, 7, 5, 0, 7, 6, 0, 7,
+		7, 0, 7, 8, 0, 7, 9, 0, 7, 10, 0, 7, 11, 0, 7, 12, 0, 7, 19, 0, 7, 20,
+		0, 7, 21, 0, 7, 22, 0, 7, 23, 0, 7, 24, 0, 7, 14, 0, 6, 0, 0,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -122,16 +411,50 @@ func NewFormulaLexer(input antlr.CharStream) *FormulaLexer {
 
 // FormulaLexer tokens.
 const (
-	FormulaLexerOP            = 1
-	FormulaLexerCP            = 2
-	FormulaLexerAND           = 3
-	FormulaLexerOR            = 4
-	FormulaLexerIMPLIES       = 5
-	FormulaLexerBICONDITIONAL = 6
-	FormulaLexerNOR           = 7
-	FormulaLexerNAND          = 8
-	FormulaLexerXOR           = 9
-	FormulaLexerNOT           = 10
-	FormulaLexerVARIABLE      = 11
-	FormulaLexerWHITESPACE    = 12
+	FormulaLexerOP                = 1
+	FormulaLexerCP                = 2
+	FormulaLexerAND               = 3
+	FormulaLexerOR                = 4
+	FormulaLexerIMPLIES           = 5
+	FormulaLexerBICONDITIONAL     = 6
+	FormulaLexerNOR               = 7
+	FormulaLexerNAND              = 8
+	FormulaLexerXOR               = 9
+	FormulaLexerNOT               = 10
+	FormulaLexerTOP               = 11
+	FormulaLexerBOTTOM            = 12
+	FormulaLexerCOMMA             = 13
+	FormulaLexerTURNSTILE         = 14
+	FormulaLexerLET               = 15
+	FormulaLexerDEF               = 16
+	FormulaLexerEQUALS            = 17
+	FormulaLexerSEMICOLON         = 18
+	FormulaLexerCONVERSE          = 19
+	FormulaLexerNONIMPLIES        = 20
+	FormulaLexerITE               = 21
+	FormulaLexerATMOST            = 22
+	FormulaLexerATLEAST           = 23
+	FormulaLexerEXACTLY           = 24
+	FormulaLexerOP_ALT            = 25
+	FormulaLexerCP_ALT            = 26
+	FormulaLexerAND_ALT           = 27
+	FormulaLexerOR_ALT            = 28
+	FormulaLexerIMPLIES_ALT       = 29
+	FormulaLexerBICONDITIONAL_ALT = 30
+	FormulaLexerNOR_ALT           = 31
+	FormulaLexerNAND_ALT          = 32
+	FormulaLexerXOR_ALT           = 33
+	FormulaLexerNOT_ALT           = 34
+	FormulaLexerTOP_ALT           = 35
+	FormulaLexerBOTTOM_ALT        = 36
+	FormulaLexerCONVERSE_ALT      = 37
+	FormulaLexerNONIMPLIES_ALT    = 38
+	FormulaLexerITE_ALT           = 39
+	FormulaLexerATMOST_ALT        = 40
+	FormulaLexerATLEAST_ALT       = 41
+	FormulaLexerEXACTLY_ALT       = 42
+	FormulaLexerTURNSTILE_ALT     = 43
+	FormulaLexerVARIABLE          = 44
+	FormulaLexerWHITESPACE        = 45
+	FormulaLexerCOMMENT           = 46
 )
@@ -11,24 +11,90 @@ type FormulaListener interface {
 	// EnterStart is called when entering the start production.
 	EnterStart(c *StartContext)
 
-	// EnterBinary is called when entering the Binary production.
-	EnterBinary(c *BinaryContext)
+	// EnterSequent is called when entering the sequent production.
+	EnterSequent(c *SequentContext)
+
+	// EnterFormulas is called when entering the formulas production.
+	EnterFormulas(c *FormulasContext)
+
+	// EnterAbbreviation is called when entering the Abbreviation production.
+	EnterAbbreviation(c *AbbreviationContext)
+
+	// EnterSchema is called when entering the Schema production.
+	EnterSchema(c *SchemaContext)
+
+	// EnterParameters is called when entering the parameters production.
+	EnterParameters(c *ParametersContext)
+
+	// EnterParenthesized is called when entering the Parenthesized production.
+	EnterParenthesized(c *ParenthesizedContext)
 
 	// EnterNegation is called when entering the Negation production.
 	EnterNegation(c *NegationContext)
 
+	// EnterIte is called when entering the Ite production.
+	EnterIte(c *IteContext)
+
+	// EnterCardinality is called when entering the Cardinality production.
+	EnterCardinality(c *CardinalityContext)
+
+	// EnterApplication is called when entering the Application production.
+	EnterApplication(c *ApplicationContext)
+
 	// EnterLetter is called when entering the Letter production.
 	EnterLetter(c *LetterContext)
 
+	// EnterTop is called when entering the Top production.
+	EnterTop(c *TopContext)
+
+	// EnterBottom is called when entering the Bottom production.
+	EnterBottom(c *BottomContext)
+
+	// EnterBinary is called when entering the Binary production.
+	EnterBinary(c *BinaryContext)
+
 	// ExitStart is called when exiting the start production.
 	ExitStart(c *StartContext)
 
-	// ExitBinary is called when exiting the Binary production.
-	ExitBinary(c *BinaryContext)
+	// ExitSequent is called when exiting the sequent production.
+	ExitSequent(c *SequentContext)
+
+	// ExitFormulas is called when exiting the formulas production.
+	ExitFormulas(c *FormulasContext)
+
+	// ExitAbbreviation is called when exiting the Abbreviation production.
+	ExitAbbreviation(c *AbbreviationContext)
+
+	// ExitSchema is called when exiting the Schema production.
+	ExitSchema(c *SchemaContext)
+
+	// ExitParameters is called when exiting the parameters production.
+	ExitParameters(c *ParametersContext)
+
+	// ExitParenthesized is called when exiting the Parenthesized production.
+	ExitParenthesized(c *ParenthesizedContext)
 
 	// ExitNegation is called when exiting the Negation production.
 	ExitNegation(c *NegationContext)
 
+	// ExitIte is called when exiting the Ite production.
+	ExitIte(c *IteContext)
+
+	// ExitCardinality is called when exiting the Cardinality production.
+	ExitCardinality(c *CardinalityContext)
+
+	// ExitApplication is called when exiting the Application production.
+	ExitApplication(c *ApplicationContext)
+
 	// ExitLetter is called when exiting the Letter production.
 	ExitLetter(c *LetterContext)
+
+	// ExitTop is called when exiting the Top production.
+	ExitTop(c *TopContext)
+
+	// ExitBottom is called when exiting the Bottom production.
+	ExitBottom(c *BottomContext)
+
+	// ExitBinary is called when exiting the Binary production.
+	ExitBinary(c *BinaryContext)
 }
@@ -34,25 +34,81 @@ func formulaParserInit() {
 	staticData := &FormulaParserStaticData
 	staticData.LiteralNames = []string{
 		"", "'('", "')'", "'&'", "'|'", "'->'", "'<->'", "'!|'", "'!&'", "'^'",
-		"'!'",
+		"'!'", "'T'", "'F'", "','", "'|-'", "'let'", "'def'", "'='", "';'", "'<-'",
+		"'!->'", "'ite'", "'atmost'", "'atleast'", "'exactly'", "'\\left('", "'\\right)'",
 	}
 	staticData.SymbolicNames = []string{
 		"", "OP", "CP", "AND", "OR", "IMPLIES", "BICONDITIONAL", "NOR", "NAND",
-		"XOR", "NOT", "VARIABLE", "WHITESPACE",
+		"XOR", "NOT", "TOP", "BOTTOM", "COMMA", "TURNSTILE", "LET", "DEF", "EQUALS",
+		"SEMICOLON", "CONVERSE", "NONIMPLIES", "ITE", "ATMOST", "ATLEAST", "EXACTLY",
+		"OP_ALT", "CP_ALT", "AND_ALT", "OR_ALT", "IMPLIES_ALT", "BICONDITIONAL_ALT",
+		"NOR_ALT", "NAND_ALT", "XOR_ALT", "NOT_ALT", "TOP_ALT", "BOTTOM_ALT", "CONVERSE_ALT",
+		"NONIMPLIES_ALT", "ITE_ALT", "ATMOST_ALT", "ATLEAST_ALT", "EXACTLY_ALT",
+		"TURNSTILE_ALT", "VARIABLE", "WHITESPACE", "COMMENT",
 	}
 	staticData.RuleNames = []string{
-		"start", "expression",
+		"start", "sequent", "formulas", "definition", "parameters", "expression",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 12, 19, 2, 0, 7, 0, 2, 1, 7, 1, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 17, 8, 1, 1, 1, 0, 0, 2, 0,
-		2, 0, 1, 1, 0, 3, 9, 18, 0, 4, 1, 0, 0, 0, 2, 16, 1, 0, 0, 0, 4, 5, 3,
-		2, 1, 0, 5, 6, 5, 0, 0, 1, 6, 1, 1, 0, 0, 0, 7, 8, 5, 1, 0, 0, 8, 9, 3,
-		2, 1, 0, 9, 10, 7, 0, 0, 0, 10, 11, 3, 2, 1, 0, 11, 12, 5, 2, 0, 0, 12,
-		17, 1, 0, 0, 0, 13, 14, 5, 10, 0, 0, 14, 17, 3, 2, 1, 0, 15, 17, 5, 11,
-		0, 0, 16, 7, 1, 0, 0, 0, 16, 13, 1, 0, 0, 0, 16, 15, 1, 0, 0, 0, 17, 3,
-		1, 0, 0, 0, 1, 16,
+		4, 1, 46, 136, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 2, 5, 7, 5, 1, 0, 1, 0, 5, 0, 15, 8, 0, 10, 0, 12, 0, 18, 9, 0, 1, 0,
+		1, 0, 1, 0, 1, 1, 1, 1, 5, 1, 25, 8, 1, 10, 1, 12, 1, 28, 9, 1, 1, 1, 3,
+		1, 31, 8, 1, 1, 1, 1, 1, 3, 1, 35, 8, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2,
+		1, 2, 5, 2, 43, 8, 2, 10, 2, 12, 2, 46, 9, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1,
+		3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 3, 3, 63,
+		8, 3, 1, 4, 1, 4, 1, 4, 1, 4, 5, 4, 69, 8, 4, 10, 4, 12, 4, 72, 9, 4, 1,
+		5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1,
+		5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1,
+		5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5, 105, 8, 5, 1, 5, 1, 5, 1,
+		5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1,
+		5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 5, 5, 131, 8,
+		5, 10, 5, 12, 5, 134, 9, 5, 1, 5, 0, 1, 10, 6, 0, 2, 4, 6, 8, 10, 0, 4,
+		1, 0, 22, 24, 2, 0, 3, 3, 8, 8, 2, 0, 4, 4, 7, 7, 1, 0, 19, 20, 149, 0,
+		16, 1, 0, 0, 0, 2, 26, 1, 0, 0, 0, 4, 38, 1, 0, 0, 0, 6, 62, 1, 0, 0, 0,
+		8, 64, 1, 0, 0, 0, 10, 104, 1, 0, 0, 0, 12, 13, 3, 6, 3, 0, 13, 15, 1,
+		0, 0, 0, 14, 12, 1, 0, 0, 0, 15, 18, 1, 0, 0, 0, 16, 14, 1, 0, 0, 0, 16,
+		17, 1, 0, 0, 0, 17, 19, 1, 0, 0, 0, 18, 16, 1, 0, 0, 0, 19, 20, 3, 10,
+		5, 0, 20, 21, 5, 0, 0, 1, 21, 1, 1, 0, 0, 0, 22, 23, 3, 6, 3, 0, 23, 25,
+		1, 0, 0, 0, 24, 22, 1, 0, 0, 0, 25, 28, 1, 0, 0, 0, 26, 24, 1, 0, 0, 0,
+		26, 27, 1, 0, 0, 0, 27, 30, 1, 0, 0, 0, 28, 26, 1, 0, 0, 0, 29, 31, 3,
+		4, 2, 0, 30, 29, 1, 0, 0, 0, 30, 31, 1, 0, 0, 0, 31, 32, 1, 0, 0, 0, 32,
+		34, 5, 14, 0, 0, 33, 35, 3, 4, 2, 0, 34, 33, 1, 0, 0, 0, 34, 35, 1, 0,
+		0, 0, 35, 36, 1, 0, 0, 0, 36, 37, 5, 0, 0, 1, 37, 3, 1, 0, 0, 0, 38, 44,
+		3, 10, 5, 0, 39, 40, 5, 13, 0, 0, 40, 41, 3, 10, 5, 0, 41, 43, 1, 0, 0,
+		0, 42, 39, 1, 0, 0, 0, 43, 46, 1, 0, 0, 0, 44, 42, 1, 0, 0, 0, 44, 45,
+		1, 0, 0, 0, 45, 5, 1, 0, 0, 0, 46, 44, 1, 0, 0, 0, 47, 48, 5, 15, 0, 0,
+		48, 49, 5, 44, 0, 0, 49, 50, 5, 17, 0, 0, 50, 51, 3, 10, 5, 0, 51, 52,
+		5, 18, 0, 0, 52, 63, 1, 0, 0, 0, 53, 54, 5, 16, 0, 0, 54, 55, 5, 44, 0,
+		0, 55, 56, 5, 1, 0, 0, 56, 57, 3, 8, 4, 0, 57, 58, 5, 2, 0, 0, 58, 59,
+		5, 17, 0, 0, 59, 60, 3, 10, 5, 0, 60, 61, 5, 18, 0, 0, 61, 63, 1, 0, 0,
+		0, 62, 47, 1, 0, 0, 0, 62, 53, 1, 0, 0, 0, 63, 7, 1, 0, 0, 0, 64, 70, 5,
+		44, 0, 0, 65, 66, 5, 13, 0, 0, 66, 67, 5, 44, 0, 0, 67, 69, 1, 0, 0, 0,
+		68, 65, 1, 0, 0, 0, 69, 72, 1, 0, 0, 0, 70, 68, 1, 0, 0, 0, 70, 71, 1,
+		0, 0, 0, 71, 9, 1, 0, 0, 0, 72, 70, 1, 0, 0, 0, 73, 74, 6, 5, -1, 0, 74,
+		75, 5, 1, 0, 0, 75, 76, 3, 10, 5, 0, 76, 77, 5, 2, 0, 0, 77, 105, 1, 0,
+		0, 0, 78, 79, 5, 10, 0, 0, 79, 105, 3, 10, 5, 13, 80, 81, 5, 21, 0, 0,
+		81, 82, 5, 1, 0, 0, 82, 83, 3, 10, 5, 0, 83, 84, 5, 13, 0, 0, 84, 85, 3,
+		10, 5, 0, 85, 86, 5, 13, 0, 0, 86, 87, 3, 10, 5, 0, 87, 88, 5, 2, 0, 0,
+		88, 105, 1, 0, 0, 0, 89, 90, 7, 0, 0, 0, 90, 91, 5, 1, 0, 0, 91, 92, 5,
+		44, 0, 0, 92, 93, 5, 18, 0, 0, 93, 94, 3, 4, 2, 0, 94, 95, 5, 2, 0, 0,
+		95, 105, 1, 0, 0, 0, 96, 97, 5, 44, 0, 0, 97, 98, 5, 1, 0, 0, 98, 99, 3,
+		4, 2, 0, 99, 100, 5, 2, 0, 0, 100, 105, 1, 0, 0, 0, 101, 105, 5, 44, 0,
+		0, 102, 105, 5, 11, 0, 0, 103, 105, 5, 12, 0, 0, 104, 73, 1, 0, 0, 0, 104,
+		78, 1, 0, 0, 0, 104, 80, 1, 0, 0, 0, 104, 89, 1, 0, 0, 0, 104, 96, 1, 0,
+		0, 0, 104, 101, 1, 0, 0, 0, 104, 102, 1, 0, 0, 0, 104, 103, 1, 0, 0, 0,
+		105, 132, 1, 0, 0, 0, 106, 107, 10, 12, 0, 0, 107, 108, 7, 1, 0, 0, 108,
+		109, 3, 10, 5, 13, 109, 131, 1, 0, 0, 0, 110, 111, 10, 11, 0, 0, 111, 112,
+		5, 9, 0, 0, 112, 113, 3, 10, 5, 12, 113, 131, 1, 0, 0, 0, 114, 115, 10,
+		10, 0, 0, 115, 116, 7, 2, 0, 0, 116, 117, 3, 10, 5, 11, 117, 131, 1, 0,
+		0, 0, 118, 119, 10, 9, 0, 0, 119, 120, 5, 5, 0, 0, 120, 121, 3, 10, 5,
+		9, 121, 131, 1, 0, 0, 0, 122, 123, 10, 8, 0, 0, 123, 124, 7, 3, 0, 0, 124,
+		125, 3, 10, 5, 9, 125, 131, 1, 0, 0, 0, 126, 127, 10, 7, 0, 0, 127, 128,
+		5, 6, 0, 0, 128, 129, 3, 10, 5, 8, 129, 131, 1, 0, 0, 0, 130, 106, 1, 0,
+		0, 0, 130, 110, 1, 0, 0, 0, 130, 114, 1, 0, 0, 0, 130, 118, 1, 0, 0, 0,
+		130, 122, 1, 0, 0, 0, 130, 126, 1, 0, 0, 0, 131, 134, 1, 0, 0, 0, 132,
+		130, 1, 0, 0, 0, 132, 133, 1, 0, 0, 0, 133, 11, 1, 0, 0, 0, 134, 132, 1,
+		0, 0, 0, 10, 16, 26, 30, 34, 44, 62, 70, 104, 130, 132,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -90,25 +146,63 @@ func NewFormulaParser(input antlr.TokenStream) *FormulaParser {
 
 // FormulaParser tokens.
 const (
-	FormulaParserEOF           = antlr.TokenEOF
-	FormulaParserOP            = 1
-	FormulaParserCP            = 2
-	FormulaParserAND           = 3
-	FormulaParserOR            = 4
-	FormulaParserIMPLIES       = 5
-	FormulaParserBICONDITIONAL = 6
-	FormulaParserNOR           = 7
-	FormulaParserNAND          = 8
-	FormulaParserXOR           = 9
-	FormulaParserNOT           = 10
-	FormulaParserVARIABLE      = 11
-	FormulaParserWHITESPACE    = 12
+	FormulaParserEOF               = antlr.TokenEOF
+	FormulaParserOP                = 1
+	FormulaParserCP                = 2
+	FormulaParserAND               = 3
+	FormulaParserOR                = 4
+	FormulaParserIMPLIES           = 5
+	FormulaParserBICONDITIONAL     = 6
+	FormulaParserNOR               = 7
+	FormulaParserNAND              = 8
+	FormulaParserXOR               = 9
+	FormulaParserNOT               = 10
+	FormulaParserTOP               = 11
+	FormulaParserBOTTOM            = 12
+	FormulaParserCOMMA             = 13
+	FormulaParserTURNSTILE         = 14
+	FormulaParserLET               = 15
+	FormulaParserDEF               = 16
+	FormulaParserEQUALS            = 17
+	FormulaParserSEMICOLON         = 18
+	FormulaParserCONVERSE          = 19
+	FormulaParserNONIMPLIES        = 20
+	FormulaParserITE               = 21
+	FormulaParserATMOST            = 22
+	FormulaParserATLEAST           = 23
+	FormulaParserEXACTLY           = 24
+	FormulaParserOP_ALT            = 25
+	FormulaParserCP_ALT            = 26
+	FormulaParserAND_ALT           = 27
+	FormulaParserOR_ALT            = 28
+	FormulaParserIMPLIES_ALT       = 29
+	FormulaParserBICONDITIONAL_ALT = 30
+	FormulaParserNOR_ALT           = 31
+	FormulaParserNAND_ALT          = 32
+	FormulaParserXOR_ALT           = 33
+	FormulaParserNOT_ALT           = 34
+	FormulaParserTOP_ALT           = 35
+	FormulaParserBOTTOM_ALT        = 36
+	FormulaParserCONVERSE_ALT      = 37
+	FormulaParserNONIMPLIES_ALT    = 38
+	FormulaParserITE_ALT           = 39
+	FormulaParserATMOST_ALT        = 40
+	FormulaParserATLEAST_ALT       = 41
+	FormulaParserEXACTLY_ALT       = 42
+	FormulaParserTURNSTILE_ALT     = 43
+	FormulaParserVARIABLE          = 44
+	FormulaParserWHITESPACE        = 45
+	FormulaParserCOMMENT           = 46
 )
 
 // FormulaParser rules.
 const (
 	FormulaParserRULE_start      = 0
-	FormulaParserRULE_expression = 1
+	FormulaParserRULE_sequent    = 1
+	FormulaParserRULE_formulas   = 2
+	FormulaParserRULE_definition = 3
+	FormulaParserRULE_parameters = 4
+	FormulaParserRULE_expression = 5
 )
 
 // IStartContext is an interface to support dynamic dispatch.
@@ -121,6 +215,8 @@ type IStartContext interface {
 	// Getter signatures
 	Expression() IExpressionContext
 	EOF() antlr.TerminalNode
+	AllDefinition() []IDefinitionContext
+	Definition(i int) IDefinitionContext
 
 	// IsStartContext differentiates from other interfaces.
 	IsStartContext()
@@ -178,6 +274,47 @@ func (s *StartContext) EOF() antlr.TerminalNode {
 	return s.GetToken(FormulaParserEOF, 0)
 }
 
+func (s *StartContext) AllDefinition() []IDefinitionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IDefinitionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IDefinitionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IDefinitionContext); ok {
+			tst[i] = t.(IDefinitionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *StartContext) Definition(i int) IDefinitionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDefinitionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDefinitionContext)
+}
+
 func (s *StartContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -201,13 +338,34 @@ func (s *StartContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *FormulaParser) Start_() (localctx IStartContext) {
 	localctx = NewStartContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 0, FormulaParserRULE_start)
+	var _la int
+
 	p.EnterOuterAlt(localctx, 1)
+	p.SetState(16)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == FormulaParserLET || _la == FormulaParserDEF {
+		{
+			p.SetState(12)
+			p.Definition()
+		}
+		p.SetState(18)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
 	{
-		p.SetState(4)
-		p.Expression()
+		p.SetState(19)
+		p.expression(0)
 	}
 	{
-		p.SetState(5)
+		p.SetState(20)
 		p.Match(FormulaParserEOF)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -228,127 +386,118 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-// IExpressionContext is an interface to support dynamic dispatch.
-type IExpressionContext interface {
+// ISequentContext is an interface to support dynamic dispatch.
+type ISequentContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
-	// IsExpressionContext differentiates from other interfaces.
-	IsExpressionContext()
+
+	// GetPremises returns the premises rule contexts.
+	GetPremises() IFormulasContext
+
+	// GetConclusions returns the conclusions rule contexts.
+	GetConclusions() IFormulasContext
+
+	// SetPremises sets the premises rule contexts.
+	SetPremises(IFormulasContext)
+
+	// SetConclusions sets the conclusions rule contexts.
+	SetConclusions(IFormulasContext)
+
+	// Getter signatures
+	TURNSTILE() antlr.TerminalNode
+	EOF() antlr.TerminalNode
+	AllDefinition() []IDefinitionContext
+	Definition(i int) IDefinitionContext
+	AllFormulas() []IFormulasContext
+	Formulas(i int) IFormulasContext
+
+	// IsSequentContext differentiates from other interfaces.
+	IsSequentContext()
 }
 
-type ExpressionContext struct {
+type SequentContext struct {
 	antlr.BaseParserRuleContext
-	parser antlr.Parser
+	parser      antlr.Parser
+	premises    IFormulasContext
+	conclusions IFormulasContext
 }
 
-func NewEmptyExpressionContext() *ExpressionContext {
-	var p = new(ExpressionContext)
+func NewEmptySequentContext() *SequentContext {
+	var p = new(SequentContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = FormulaParserRULE_expression
+	p.RuleIndex = FormulaParserRULE_sequent
 	return p
 }
 
-func InitEmptyExpressionContext(p *ExpressionContext) {
+func InitEmptySequentContext(p *SequentContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = FormulaParserRULE_expression
+	p.RuleIndex = FormulaParserRULE_sequent
 }
 
-func (*ExpressionContext) IsExpressionContext() {}
+func (*SequentContext) IsSequentContext() {}
 
-func NewExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExpressionContext {
-	var p = new(ExpressionContext)
+func NewSequentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SequentContext {
+	var p = new(SequentContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = FormulaParserRULE_expression
+	p.RuleIndex = FormulaParserRULE_sequent
 
 	return p
 }
 
-func (s *ExpressionContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *ExpressionContext) CopyAll(ctx *ExpressionContext) {
-	s.CopyFrom(&ctx.BaseParserRuleContext)
-}
-
-func (s *ExpressionContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *ExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
+func (s *SequentContext) GetParser() antlr.Parser { return s.parser }
 
-type LetterContext struct {
-	ExpressionContext
-}
+func (s *SequentContext) GetPremises() IFormulasContext { return s.premises }
 
-func NewLetterContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LetterContext {
-	var p = new(LetterContext)
+func (s *SequentContext) GetConclusions() IFormulasContext { return s.conclusions }
 
-	InitEmptyExpressionContext(&p.ExpressionContext)
-	p.parser = parser
-	p.CopyAll(ctx.(*ExpressionContext))
+func (s *SequentContext) SetPremises(v IFormulasContext) { s.premises = v }
 
-	return p
-}
+func (s *SequentContext) SetConclusions(v IFormulasContext) { s.conclusions = v }
 
-func (s *LetterContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *SequentContext) TURNSTILE() antlr.TerminalNode {
+	return s.GetToken(FormulaParserTURNSTILE, 0)
 }
 
-func (s *LetterContext) VARIABLE() antlr.TerminalNode {
-	return s.GetToken(FormulaParserVARIABLE, 0)
+func (s *SequentContext) EOF() antlr.TerminalNode {
+	return s.GetToken(FormulaParserEOF, 0)
 }
 
-func (s *LetterContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(FormulaListener); ok {
-		listenerT.EnterLetter(s)
+func (s *SequentContext) AllDefinition() []IDefinitionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IDefinitionContext); ok {
+			len++
+		}
 	}
-}
 
-func (s *LetterContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(FormulaListener); ok {
-		listenerT.ExitLetter(s)
+	tst := make([]IDefinitionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IDefinitionContext); ok {
+			tst[i] = t.(IDefinitionContext)
+			i++
+		}
 	}
-}
-
-type NegationContext struct {
-	ExpressionContext
-	negated IExpressionContext
-}
-
-func NewNegationContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *NegationContext {
-	var p = new(NegationContext)
-
-	InitEmptyExpressionContext(&p.ExpressionContext)
-	p.parser = parser
-	p.CopyAll(ctx.(*ExpressionContext))
-
-	return p
-}
-
-func (s *NegationContext) GetNegated() IExpressionContext { return s.negated }
-
-func (s *NegationContext) SetNegated(v IExpressionContext) { s.negated = v }
-
-func (s *NegationContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
 
-func (s *NegationContext) NOT() antlr.TerminalNode {
-	return s.GetToken(FormulaParserNOT, 0)
+	return tst
 }
 
-func (s *NegationContext) Expression() IExpressionContext {
+func (s *SequentContext) Definition(i int) IDefinitionContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(IDefinitionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -356,76 +505,23 @@ func (s *NegationContext) Expression() IExpressionContext {
 		return nil
 	}
 
-	return t.(IExpressionContext)
-}
-
-func (s *NegationContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(FormulaListener); ok {
-		listenerT.EnterNegation(s)
-	}
-}
-
-func (s *NegationContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(FormulaListener); ok {
-		listenerT.ExitNegation(s)
-	}
-}
-
-type BinaryContext struct {
-	ExpressionContext
-	left  IExpressionContext
-	op    antlr.Token
-	right IExpressionContext
-}
-
-func NewBinaryContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BinaryContext {
-	var p = new(BinaryContext)
-
-	InitEmptyExpressionContext(&p.ExpressionContext)
-	p.parser = parser
-	p.CopyAll(ctx.(*ExpressionContext))
-
-	return p
-}
-
-func (s *BinaryContext) GetOp() antlr.Token { return s.op }
-
-func (s *BinaryContext) SetOp(v antlr.Token) { s.op = v }
-
-func (s *BinaryContext) GetLeft() IExpressionContext { return s.left }
-
-func (s *BinaryContext) GetRight() IExpressionContext { return s.right }
-
-func (s *BinaryContext) SetLeft(v IExpressionContext) { s.left = v }
-
-func (s *BinaryContext) SetRight(v IExpressionContext) { s.right = v }
-
-func (s *BinaryContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *BinaryContext) OP() antlr.TerminalNode {
-	return s.GetToken(FormulaParserOP, 0)
-}
-
-func (s *BinaryContext) CP() antlr.TerminalNode {
-	return s.GetToken(FormulaParserCP, 0)
+	return t.(IDefinitionContext)
 }
 
-func (s *BinaryContext) AllExpression() []IExpressionContext {
+func (s *SequentContext) AllFormulas() []IFormulasContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(IExpressionContext); ok {
+		if _, ok := ctx.(IFormulasContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]IExpressionContext, len)
+	tst := make([]IFormulasContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(IExpressionContext); ok {
-			tst[i] = t.(IExpressionContext)
+		if t, ok := ctx.(IFormulasContext); ok {
+			tst[i] = t.(IFormulasContext)
 			i++
 		}
 	}
@@ -433,11 +529,11 @@ func (s *BinaryContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *BinaryContext) Expression(i int) IExpressionContext {
+func (s *SequentContext) Formulas(i int) IFormulasContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
+		if _, ok := ctx.(IFormulasContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -450,106 +546,1622 @@ func (s *BinaryContext) Expression(i int) IExpressionContext {
 		return nil
 	}
 
-	return t.(IExpressionContext)
-}
-
-func (s *BinaryContext) AND() antlr.TerminalNode {
-	return s.GetToken(FormulaParserAND, 0)
-}
-
-func (s *BinaryContext) OR() antlr.TerminalNode {
-	return s.GetToken(FormulaParserOR, 0)
-}
-
-func (s *BinaryContext) IMPLIES() antlr.TerminalNode {
-	return s.GetToken(FormulaParserIMPLIES, 0)
-}
-
-func (s *BinaryContext) BICONDITIONAL() antlr.TerminalNode {
-	return s.GetToken(FormulaParserBICONDITIONAL, 0)
-}
-
-func (s *BinaryContext) NOR() antlr.TerminalNode {
-	return s.GetToken(FormulaParserNOR, 0)
+	return t.(IFormulasContext)
 }
 
-func (s *BinaryContext) NAND() antlr.TerminalNode {
-	return s.GetToken(FormulaParserNAND, 0)
+func (s *SequentContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *BinaryContext) XOR() antlr.TerminalNode {
-	return s.GetToken(FormulaParserXOR, 0)
+func (s *SequentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *BinaryContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SequentContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(FormulaListener); ok {
-		listenerT.EnterBinary(s)
+		listenerT.EnterSequent(s)
 	}
 }
 
-func (s *BinaryContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SequentContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(FormulaListener); ok {
-		listenerT.ExitBinary(s)
+		listenerT.ExitSequent(s)
 	}
 }
 
-func (p *FormulaParser) Expression() (localctx IExpressionContext) {
-	localctx = NewExpressionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 2, FormulaParserRULE_expression)
+func (p *FormulaParser) Sequent() (localctx ISequentContext) {
+	localctx = NewSequentContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 2, FormulaParserRULE_sequent)
 	var _la int
 
-	p.SetState(16)
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(26)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
+	_la = p.GetTokenStream().LA(1)
 
-	switch p.GetTokenStream().LA(1) {
-	case FormulaParserOP:
-		localctx = NewBinaryContext(p, localctx)
-		p.EnterOuterAlt(localctx, 1)
+	for _la == FormulaParserLET || _la == FormulaParserDEF {
 		{
-			p.SetState(7)
-			p.Match(FormulaParserOP)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
+			p.SetState(22)
+			p.Definition()
 		}
-		{
-			p.SetState(8)
-
-			var _x = p.Expression()
-
-			localctx.(*BinaryContext).left = _x
+		p.SetState(28)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
 		}
+		_la = p.GetTokenStream().LA(1)
+	}
+	p.SetState(30)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&17592217508866) != 0 {
+		{
+			p.SetState(29)
+
+			var _x = p.Formulas()
+
+			localctx.(*SequentContext).premises = _x
+		}
+	}
+
+	{
+		p.SetState(32)
+		p.Match(FormulaParserTURNSTILE)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	p.SetState(34)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&17592217508866) != 0 {
+		{
+			p.SetState(33)
+
+			var _x = p.Formulas()
+
+			localctx.(*SequentContext).conclusions = _x
+		}
+	}
+
+	{
+		p.SetState(36)
+		p.Match(FormulaParserEOF)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IFormulasContext is an interface to support dynamic dispatch.
+type IFormulasContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllExpression() []IExpressionContext
+	Expression(i int) IExpressionContext
+	AllCOMMA() []antlr.TerminalNode
+	COMMA(i int) antlr.TerminalNode
+
+	// IsFormulasContext differentiates from other interfaces.
+	IsFormulasContext()
+}
+
+type FormulasContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyFormulasContext() *FormulasContext {
+	var p = new(FormulasContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = FormulaParserRULE_formulas
+	return p
+}
+
+func InitEmptyFormulasContext(p *FormulasContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = FormulaParserRULE_formulas
+}
+
+func (*FormulasContext) IsFormulasContext() {}
+
+func NewFormulasContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FormulasContext {
+	var p = new(FormulasContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = FormulaParserRULE_formulas
+
+	return p
+}
+
+func (s *FormulasContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *FormulasContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *FormulasContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *FormulasContext) AllCOMMA() []antlr.TerminalNode {
+	return s.GetTokens(FormulaParserCOMMA)
+}
+
+func (s *FormulasContext) COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(FormulaParserCOMMA, i)
+}
+
+func (s *FormulasContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *FormulasContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *FormulasContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterFormulas(s)
+	}
+}
+
+func (s *FormulasContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitFormulas(s)
+	}
+}
+
+func (p *FormulaParser) Formulas() (localctx IFormulasContext) {
+	localctx = NewFormulasContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 4, FormulaParserRULE_formulas)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(38)
+		p.expression(0)
+	}
+	p.SetState(44)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == FormulaParserCOMMA {
+		{
+			p.SetState(39)
+			p.Match(FormulaParserCOMMA)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(40)
+			p.expression(0)
+		}
+		p.SetState(46)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IDefinitionContext is an interface to support dynamic dispatch.
+type IDefinitionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+	// IsDefinitionContext differentiates from other interfaces.
+	IsDefinitionContext()
+}
+
+type DefinitionContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyDefinitionContext() *DefinitionContext {
+	var p = new(DefinitionContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = FormulaParserRULE_definition
+	return p
+}
+
+func InitEmptyDefinitionContext(p *DefinitionContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = FormulaParserRULE_definition
+}
+
+func (*DefinitionContext) IsDefinitionContext() {}
+
+func NewDefinitionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DefinitionContext {
+	var p = new(DefinitionContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = FormulaParserRULE_definition
+
+	return p
+}
+
+func (s *DefinitionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *DefinitionContext) CopyAll(ctx *DefinitionContext) {
+	s.CopyFrom(&ctx.BaseParserRuleContext)
+}
+
+func (s *DefinitionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DefinitionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type AbbreviationContext struct {
+	DefinitionContext
+	name antlr.Token
+}
+
+func NewAbbreviationContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AbbreviationContext {
+	var p = new(AbbreviationContext)
+
+	InitEmptyDefinitionContext(&p.DefinitionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*DefinitionContext))
+
+	return p
+}
+
+func (s *AbbreviationContext) GetName() antlr.Token { return s.name }
+
+func (s *AbbreviationContext) SetName(v antlr.Token) { s.name = v }
+
+func (s *AbbreviationContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AbbreviationContext) LET() antlr.TerminalNode {
+	return s.GetToken(FormulaParserLET, 0)
+}
+
+func (s *AbbreviationContext) EQUALS() antlr.TerminalNode {
+	return s.GetToken(FormulaParserEQUALS, 0)
+}
+
+func (s *AbbreviationContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *AbbreviationContext) SEMICOLON() antlr.TerminalNode {
+	return s.GetToken(FormulaParserSEMICOLON, 0)
+}
+
+func (s *AbbreviationContext) VARIABLE() antlr.TerminalNode {
+	return s.GetToken(FormulaParserVARIABLE, 0)
+}
+
+func (s *AbbreviationContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterAbbreviation(s)
+	}
+}
+
+func (s *AbbreviationContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitAbbreviation(s)
+	}
+}
+
+type SchemaContext struct {
+	DefinitionContext
+	name antlr.Token
+}
+
+func NewSchemaContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SchemaContext {
+	var p = new(SchemaContext)
+
+	InitEmptyDefinitionContext(&p.DefinitionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*DefinitionContext))
+
+	return p
+}
+
+func (s *SchemaContext) GetName() antlr.Token { return s.name }
+
+func (s *SchemaContext) SetName(v antlr.Token) { s.name = v }
+
+func (s *SchemaContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SchemaContext) DEF() antlr.TerminalNode {
+	return s.GetToken(FormulaParserDEF, 0)
+}
+
+func (s *SchemaContext) OP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserOP, 0)
+}
+
+func (s *SchemaContext) Parameters() IParametersContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IParametersContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IParametersContext)
+}
+
+func (s *SchemaContext) CP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserCP, 0)
+}
+
+func (s *SchemaContext) EQUALS() antlr.TerminalNode {
+	return s.GetToken(FormulaParserEQUALS, 0)
+}
+
+func (s *SchemaContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *SchemaContext) SEMICOLON() antlr.TerminalNode {
+	return s.GetToken(FormulaParserSEMICOLON, 0)
+}
+
+func (s *SchemaContext) VARIABLE() antlr.TerminalNode {
+	return s.GetToken(FormulaParserVARIABLE, 0)
+}
+
+func (s *SchemaContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterSchema(s)
+	}
+}
+
+func (s *SchemaContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitSchema(s)
+	}
+}
+
+func (p *FormulaParser) Definition() (localctx IDefinitionContext) {
+	localctx = NewDefinitionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, FormulaParserRULE_definition)
+	p.SetState(62)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case FormulaParserLET:
+		localctx = NewAbbreviationContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(47)
+			p.Match(FormulaParserLET)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(48)
+
+			var _m = p.Match(FormulaParserVARIABLE)
+
+			localctx.(*AbbreviationContext).name = _m
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(49)
+			p.Match(FormulaParserEQUALS)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(50)
+			p.expression(0)
+		}
+		{
+			p.SetState(51)
+			p.Match(FormulaParserSEMICOLON)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case FormulaParserDEF:
+		localctx = NewSchemaContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(53)
+			p.Match(FormulaParserDEF)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(54)
+
+			var _m = p.Match(FormulaParserVARIABLE)
+
+			localctx.(*SchemaContext).name = _m
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(55)
+			p.Match(FormulaParserOP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(56)
+			p.Parameters()
+		}
+		{
+			p.SetState(57)
+			p.Match(FormulaParserCP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(58)
+			p.Match(FormulaParserEQUALS)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(59)
+			p.expression(0)
+		}
+		{
+			p.SetState(60)
+			p.Match(FormulaParserSEMICOLON)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IParametersContext is an interface to support dynamic dispatch.
+type IParametersContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllVARIABLE() []antlr.TerminalNode
+	VARIABLE(i int) antlr.TerminalNode
+	AllCOMMA() []antlr.TerminalNode
+	COMMA(i int) antlr.TerminalNode
+
+	// IsParametersContext differentiates from other interfaces.
+	IsParametersContext()
+}
+
+type ParametersContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyParametersContext() *ParametersContext {
+	var p = new(ParametersContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = FormulaParserRULE_parameters
+	return p
+}
+
+func InitEmptyParametersContext(p *ParametersContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = FormulaParserRULE_parameters
+}
+
+func (*ParametersContext) IsParametersContext() {}
+
+func NewParametersContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ParametersContext {
+	var p = new(ParametersContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = FormulaParserRULE_parameters
+
+	return p
+}
+
+func (s *ParametersContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ParametersContext) AllVARIABLE() []antlr.TerminalNode {
+	return s.GetTokens(FormulaParserVARIABLE)
+}
+
+func (s *ParametersContext) VARIABLE(i int) antlr.TerminalNode {
+	return s.GetToken(FormulaParserVARIABLE, i)
+}
+
+func (s *ParametersContext) AllCOMMA() []antlr.TerminalNode {
+	return s.GetTokens(FormulaParserCOMMA)
+}
+
+func (s *ParametersContext) COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(FormulaParserCOMMA, i)
+}
+
+func (s *ParametersContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ParametersContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ParametersContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterParameters(s)
+	}
+}
+
+func (s *ParametersContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitParameters(s)
+	}
+}
+
+func (p *FormulaParser) Parameters() (localctx IParametersContext) {
+	localctx = NewParametersContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, FormulaParserRULE_parameters)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(64)
+		p.Match(FormulaParserVARIABLE)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	p.SetState(70)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == FormulaParserCOMMA {
+		{
+			p.SetState(65)
+			p.Match(FormulaParserCOMMA)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(66)
+			p.Match(FormulaParserVARIABLE)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(72)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IExpressionContext is an interface to support dynamic dispatch.
+type IExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+	// IsExpressionContext differentiates from other interfaces.
+	IsExpressionContext()
+}
+
+type ExpressionContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyExpressionContext() *ExpressionContext {
+	var p = new(ExpressionContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = FormulaParserRULE_expression
+	return p
+}
+
+func InitEmptyExpressionContext(p *ExpressionContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = FormulaParserRULE_expression
+}
+
+func (*ExpressionContext) IsExpressionContext() {}
+
+func NewExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExpressionContext {
+	var p = new(ExpressionContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = FormulaParserRULE_expression
+
+	return p
+}
+
+func (s *ExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ExpressionContext) CopyAll(ctx *ExpressionContext) {
+	s.CopyFrom(&ctx.BaseParserRuleContext)
+}
+
+func (s *ExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type LetterContext struct {
+	ExpressionContext
+}
+
+func NewLetterContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LetterContext {
+	var p = new(LetterContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *LetterContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LetterContext) VARIABLE() antlr.TerminalNode {
+	return s.GetToken(FormulaParserVARIABLE, 0)
+}
+
+func (s *LetterContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterLetter(s)
+	}
+}
+
+func (s *LetterContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitLetter(s)
+	}
+}
+
+type NegationContext struct {
+	ExpressionContext
+	negated IExpressionContext
+}
+
+func NewNegationContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *NegationContext {
+	var p = new(NegationContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *NegationContext) GetNegated() IExpressionContext { return s.negated }
+
+func (s *NegationContext) SetNegated(v IExpressionContext) { s.negated = v }
+
+func (s *NegationContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *NegationContext) NOT() antlr.TerminalNode {
+	return s.GetToken(FormulaParserNOT, 0)
+}
+
+func (s *NegationContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *NegationContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterNegation(s)
+	}
+}
+
+func (s *NegationContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitNegation(s)
+	}
+}
+
+type CardinalityContext struct {
+	ExpressionContext
+	kind     antlr.Token
+	bound    antlr.Token
+	operands IFormulasContext
+}
+
+func NewCardinalityContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CardinalityContext {
+	var p = new(CardinalityContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *CardinalityContext) GetKind() antlr.Token { return s.kind }
+
+func (s *CardinalityContext) GetBound() antlr.Token { return s.bound }
+
+func (s *CardinalityContext) SetKind(v antlr.Token) { s.kind = v }
+
+func (s *CardinalityContext) SetBound(v antlr.Token) { s.bound = v }
+
+func (s *CardinalityContext) GetOperands() IFormulasContext { return s.operands }
+
+func (s *CardinalityContext) SetOperands(v IFormulasContext) { s.operands = v }
+
+func (s *CardinalityContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CardinalityContext) OP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserOP, 0)
+}
+
+func (s *CardinalityContext) SEMICOLON() antlr.TerminalNode {
+	return s.GetToken(FormulaParserSEMICOLON, 0)
+}
+
+func (s *CardinalityContext) CP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserCP, 0)
+}
+
+func (s *CardinalityContext) VARIABLE() antlr.TerminalNode {
+	return s.GetToken(FormulaParserVARIABLE, 0)
+}
+
+func (s *CardinalityContext) Formulas() IFormulasContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IFormulasContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFormulasContext)
+}
+
+func (s *CardinalityContext) ATMOST() antlr.TerminalNode {
+	return s.GetToken(FormulaParserATMOST, 0)
+}
+
+func (s *CardinalityContext) ATLEAST() antlr.TerminalNode {
+	return s.GetToken(FormulaParserATLEAST, 0)
+}
+
+func (s *CardinalityContext) EXACTLY() antlr.TerminalNode {
+	return s.GetToken(FormulaParserEXACTLY, 0)
+}
+
+func (s *CardinalityContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterCardinality(s)
+	}
+}
+
+func (s *CardinalityContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitCardinality(s)
+	}
+}
+
+type TopContext struct {
+	ExpressionContext
+}
+
+func NewTopContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TopContext {
+	var p = new(TopContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *TopContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TopContext) TOP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserTOP, 0)
+}
+
+func (s *TopContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterTop(s)
+	}
+}
+
+func (s *TopContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitTop(s)
+	}
+}
+
+type ParenthesizedContext struct {
+	ExpressionContext
+}
+
+func NewParenthesizedContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ParenthesizedContext {
+	var p = new(ParenthesizedContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *ParenthesizedContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ParenthesizedContext) OP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserOP, 0)
+}
+
+func (s *ParenthesizedContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ParenthesizedContext) CP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserCP, 0)
+}
+
+func (s *ParenthesizedContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterParenthesized(s)
+	}
+}
+
+func (s *ParenthesizedContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitParenthesized(s)
+	}
+}
+
+type BottomContext struct {
+	ExpressionContext
+}
+
+func NewBottomContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BottomContext {
+	var p = new(BottomContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *BottomContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *BottomContext) BOTTOM() antlr.TerminalNode {
+	return s.GetToken(FormulaParserBOTTOM, 0)
+}
+
+func (s *BottomContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterBottom(s)
+	}
+}
+
+func (s *BottomContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitBottom(s)
+	}
+}
+
+type IteContext struct {
+	ExpressionContext
+	condition   IExpressionContext
+	consequent  IExpressionContext
+	alternative IExpressionContext
+}
+
+func NewIteContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IteContext {
+	var p = new(IteContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *IteContext) GetCondition() IExpressionContext { return s.condition }
+
+func (s *IteContext) GetConsequent() IExpressionContext { return s.consequent }
+
+func (s *IteContext) GetAlternative() IExpressionContext { return s.alternative }
+
+func (s *IteContext) SetCondition(v IExpressionContext) { s.condition = v }
+
+func (s *IteContext) SetConsequent(v IExpressionContext) { s.consequent = v }
+
+func (s *IteContext) SetAlternative(v IExpressionContext) { s.alternative = v }
+
+func (s *IteContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IteContext) ITE() antlr.TerminalNode {
+	return s.GetToken(FormulaParserITE, 0)
+}
+
+func (s *IteContext) OP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserOP, 0)
+}
+
+func (s *IteContext) AllCOMMA() []antlr.TerminalNode {
+	return s.GetTokens(FormulaParserCOMMA)
+}
+
+func (s *IteContext) COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(FormulaParserCOMMA, i)
+}
+
+func (s *IteContext) CP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserCP, 0)
+}
+
+func (s *IteContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *IteContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *IteContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterIte(s)
+	}
+}
+
+func (s *IteContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitIte(s)
+	}
+}
+
+type BinaryContext struct {
+	ExpressionContext
+	left  IExpressionContext
+	op    antlr.Token
+	right IExpressionContext
+}
+
+func NewBinaryContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BinaryContext {
+	var p = new(BinaryContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *BinaryContext) GetOp() antlr.Token { return s.op }
+
+func (s *BinaryContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *BinaryContext) GetLeft() IExpressionContext { return s.left }
+
+func (s *BinaryContext) GetRight() IExpressionContext { return s.right }
+
+func (s *BinaryContext) SetLeft(v IExpressionContext) { s.left = v }
+
+func (s *BinaryContext) SetRight(v IExpressionContext) { s.right = v }
+
+func (s *BinaryContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *BinaryContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *BinaryContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *BinaryContext) AND() antlr.TerminalNode {
+	return s.GetToken(FormulaParserAND, 0)
+}
+
+func (s *BinaryContext) NAND() antlr.TerminalNode {
+	return s.GetToken(FormulaParserNAND, 0)
+}
+
+func (s *BinaryContext) XOR() antlr.TerminalNode {
+	return s.GetToken(FormulaParserXOR, 0)
+}
+
+func (s *BinaryContext) OR() antlr.TerminalNode {
+	return s.GetToken(FormulaParserOR, 0)
+}
+
+func (s *BinaryContext) NOR() antlr.TerminalNode {
+	return s.GetToken(FormulaParserNOR, 0)
+}
+
+func (s *BinaryContext) IMPLIES() antlr.TerminalNode {
+	return s.GetToken(FormulaParserIMPLIES, 0)
+}
+
+func (s *BinaryContext) CONVERSE() antlr.TerminalNode {
+	return s.GetToken(FormulaParserCONVERSE, 0)
+}
+
+func (s *BinaryContext) NONIMPLIES() antlr.TerminalNode {
+	return s.GetToken(FormulaParserNONIMPLIES, 0)
+}
+
+func (s *BinaryContext) BICONDITIONAL() antlr.TerminalNode {
+	return s.GetToken(FormulaParserBICONDITIONAL, 0)
+}
+
+func (s *BinaryContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterBinary(s)
+	}
+}
+
+func (s *BinaryContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitBinary(s)
+	}
+}
+
+type ApplicationContext struct {
+	ExpressionContext
+	name      antlr.Token
+	arguments IFormulasContext
+}
+
+func NewApplicationContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ApplicationContext {
+	var p = new(ApplicationContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *ApplicationContext) GetName() antlr.Token { return s.name }
+
+func (s *ApplicationContext) SetName(v antlr.Token) { s.name = v }
+
+func (s *ApplicationContext) GetArguments() IFormulasContext { return s.arguments }
+
+func (s *ApplicationContext) SetArguments(v IFormulasContext) { s.arguments = v }
+
+func (s *ApplicationContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ApplicationContext) OP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserOP, 0)
+}
+
+func (s *ApplicationContext) CP() antlr.TerminalNode {
+	return s.GetToken(FormulaParserCP, 0)
+}
+
+func (s *ApplicationContext) VARIABLE() antlr.TerminalNode {
+	return s.GetToken(FormulaParserVARIABLE, 0)
+}
+
+func (s *ApplicationContext) Formulas() IFormulasContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IFormulasContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFormulasContext)
+}
+
+func (s *ApplicationContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.EnterApplication(s)
+	}
+}
+
+func (s *ApplicationContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(FormulaListener); ok {
+		listenerT.ExitApplication(s)
+	}
+}
+
+func (p *FormulaParser) Expression() (localctx IExpressionContext) {
+	return p.expression(0)
+}
+
+func (p *FormulaParser) expression(_p int) (localctx IExpressionContext) {
+	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
+
+	_parentState := p.GetState()
+	localctx = NewExpressionContext(p, p.GetParserRuleContext(), _parentState)
+	var _prevctx IExpressionContext = localctx
+	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
+	_startState := 10
+	p.EnterRecursionRule(localctx, 10, FormulaParserRULE_expression, _p)
+	var _la int
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(104)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 7, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewParenthesizedContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+
+		{
+			p.SetState(74)
+			p.Match(FormulaParserOP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(75)
+			p.expression(0)
+		}
+		{
+			p.SetState(76)
+			p.Match(FormulaParserCP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 2:
+		localctx = NewNegationContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(78)
+			p.Match(FormulaParserNOT)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(79)
+
+			var _x = p.expression(13)
+
+			localctx.(*NegationContext).negated = _x
+		}
+
+	case 3:
+		localctx = NewIteContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(80)
+			p.Match(FormulaParserITE)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(81)
+			p.Match(FormulaParserOP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(82)
+
+			var _x = p.expression(0)
+
+			localctx.(*IteContext).condition = _x
+		}
+		{
+			p.SetState(83)
+			p.Match(FormulaParserCOMMA)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(84)
+
+			var _x = p.expression(0)
+
+			localctx.(*IteContext).consequent = _x
+		}
+		{
+			p.SetState(85)
+			p.Match(FormulaParserCOMMA)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(86)
+
+			var _x = p.expression(0)
+
+			localctx.(*IteContext).alternative = _x
+		}
+		{
+			p.SetState(87)
+			p.Match(FormulaParserCP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 4:
+		localctx = NewCardinalityContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
 		{
-			p.SetState(9)
+			p.SetState(89)
 
 			var _lt = p.GetTokenStream().LT(1)
 
-			localctx.(*BinaryContext).op = _lt
+			localctx.(*CardinalityContext).kind = _lt
 
 			_la = p.GetTokenStream().LA(1)
 
-			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&1016) != 0) {
+			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&29360128) != 0) {
 				var _ri = p.GetErrorHandler().RecoverInline(p)
 
-				localctx.(*BinaryContext).op = _ri
+				localctx.(*CardinalityContext).kind = _ri
 			} else {
 				p.GetErrorHandler().ReportMatch(p)
 				p.Consume()
 			}
 		}
 		{
-			p.SetState(10)
+			p.SetState(90)
+			p.Match(FormulaParserOP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(91)
+
+			var _m = p.Match(FormulaParserVARIABLE)
+
+			localctx.(*CardinalityContext).bound = _m
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(92)
+			p.Match(FormulaParserSEMICOLON)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(93)
 
-			var _x = p.Expression()
+			var _x = p.Formulas()
 
-			localctx.(*BinaryContext).right = _x
+			localctx.(*CardinalityContext).operands = _x
 		}
 		{
-			p.SetState(11)
+			p.SetState(94)
 			p.Match(FormulaParserCP)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -557,30 +2169,51 @@ func (p *FormulaParser) Expression() (localctx IExpressionContext) {
 			}
 		}
 
-	case FormulaParserNOT:
-		localctx = NewNegationContext(p, localctx)
-		p.EnterOuterAlt(localctx, 2)
+	case 5:
+		localctx = NewApplicationContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
 		{
-			p.SetState(13)
-			p.Match(FormulaParserNOT)
+			p.SetState(96)
+
+			var _m = p.Match(FormulaParserVARIABLE)
+
+			localctx.(*ApplicationContext).name = _m
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(97)
+			p.Match(FormulaParserOP)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
 		{
-			p.SetState(14)
+			p.SetState(98)
 
-			var _x = p.Expression()
+			var _x = p.Formulas()
 
-			localctx.(*NegationContext).negated = _x
+			localctx.(*ApplicationContext).arguments = _x
+		}
+		{
+			p.SetState(99)
+			p.Match(FormulaParserCP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
 		}
 
-	case FormulaParserVARIABLE:
+	case 6:
 		localctx = NewLetterContext(p, localctx)
-		p.EnterOuterAlt(localctx, 3)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
 		{
-			p.SetState(15)
+			p.SetState(101)
 			p.Match(FormulaParserVARIABLE)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -588,10 +2221,275 @@ func (p *FormulaParser) Expression() (localctx IExpressionContext) {
 			}
 		}
 
-	default:
-		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	case 7:
+		localctx = NewTopContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(102)
+			p.Match(FormulaParserTOP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 8:
+		localctx = NewBottomContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(103)
+			p.Match(FormulaParserBOTTOM)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case antlr.ATNInvalidAltNumber:
+		goto errorExit
+	}
+
+	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
+	p.SetState(132)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 9, p.GetParserRuleContext())
+	if p.HasError() {
 		goto errorExit
 	}
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			if p.GetParseListeners() != nil {
+				p.TriggerExitRuleEvent()
+			}
+			_prevctx = localctx
+			p.SetState(130)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 8, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewBinaryContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*BinaryContext).left = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, FormulaParserRULE_expression)
+				p.SetState(106)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 12)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 12)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(107)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*BinaryContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == FormulaParserAND || _la == FormulaParserNAND) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*BinaryContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(108)
+
+					var _x = p.expression(13)
+
+					localctx.(*BinaryContext).right = _x
+				}
+
+			case 2:
+				localctx = NewBinaryContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*BinaryContext).left = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, FormulaParserRULE_expression)
+				p.SetState(110)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 11)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 11)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(111)
+
+					var _m = p.Match(FormulaParserXOR)
+
+					localctx.(*BinaryContext).op = _m
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(112)
+
+					var _x = p.expression(12)
+
+					localctx.(*BinaryContext).right = _x
+				}
+
+			case 3:
+				localctx = NewBinaryContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*BinaryContext).left = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, FormulaParserRULE_expression)
+				p.SetState(114)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(115)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*BinaryContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == FormulaParserOR || _la == FormulaParserNOR) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*BinaryContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(116)
+
+					var _x = p.expression(11)
+
+					localctx.(*BinaryContext).right = _x
+				}
+
+			case 4:
+				localctx = NewBinaryContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*BinaryContext).left = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, FormulaParserRULE_expression)
+				p.SetState(118)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(119)
+
+					var _m = p.Match(FormulaParserIMPLIES)
+
+					localctx.(*BinaryContext).op = _m
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(120)
+
+					var _x = p.expression(9)
+
+					localctx.(*BinaryContext).right = _x
+				}
+
+			case 5:
+				localctx = NewBinaryContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*BinaryContext).left = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, FormulaParserRULE_expression)
+				p.SetState(122)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(123)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*BinaryContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == FormulaParserCONVERSE || _la == FormulaParserNONIMPLIES) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*BinaryContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(124)
+
+					var _x = p.expression(9)
+
+					localctx.(*BinaryContext).right = _x
+				}
+
+			case 6:
+				localctx = NewBinaryContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*BinaryContext).left = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, FormulaParserRULE_expression)
+				p.SetState(126)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(127)
+
+					var _m = p.Match(FormulaParserBICONDITIONAL)
+
+					localctx.(*BinaryContext).op = _m
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(128)
+
+					var _x = p.expression(8)
+
+					localctx.(*BinaryContext).right = _x
+				}
+
+			case antlr.ATNInvalidAltNumber:
+				goto errorExit
+			}
+
+		}
+		p.SetState(134)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 9, p.GetParserRuleContext())
+		if p.HasError() {
+			goto errorExit
+		}
+	}
 
 errorExit:
 	if p.HasError() {
@@ -601,7 +2499,46 @@ errorExit:
 		p.GetErrorHandler().Recover(p, v)
 		p.SetError(nil)
 	}
-	p.ExitRule()
+	p.UnrollRecursionContexts(_parentctx)
 	return localctx
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
+
+func (p *FormulaParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
+	switch ruleIndex {
+	case 5:
+		var t *ExpressionContext = nil
+		if localctx != nil {
+			t = localctx.(*ExpressionContext)
+		}
+		return p.Expression_Sempred(t, predIndex)
+
+	default:
+		panic("No predicate with index: " + fmt.Sprint(ruleIndex))
+	}
+}
+
+func (p *FormulaParser) Expression_Sempred(localctx antlr.RuleContext, predIndex int) bool {
+	switch predIndex {
+	case 0:
+		return p.Precpred(p.GetParserRuleContext(), 12)
+
+	case 1:
+		return p.Precpred(p.GetParserRuleContext(), 11)
+
+	case 2:
+		return p.Precpred(p.GetParserRuleContext(), 10)
+
+	case 3:
+		return p.Precpred(p.GetParserRuleContext(), 9)
+
+	case 4:
+		return p.Precpred(p.GetParserRuleContext(), 8)
+
+	case 5:
+		return p.Precpred(p.GetParserRuleContext(), 7)
+
+	default:
+		panic("No predicate with index: " + fmt.Sprint(predIndex))
+	}
+}
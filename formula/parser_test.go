@@ -1,6 +1,10 @@
 package formula
 
-import "testing"
+import (
+	"errors"
+	"slices"
+	"testing"
+)
 
 var p = NewLetter("p")
 var q = NewLetter("q")
@@ -74,3 +78,489 @@ func TestParseFormula(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePrecedence(t *testing.T) {
+	r := NewLetter("r")
+	tests := []struct {
+		name   string
+		source string
+		want   Formula
+	}{
+		{
+			name:   "and over implication",
+			source: "p & q -> r",
+			want:   NewImplies(NewAnd(p, q), r),
+		},
+		{
+			name:   "not over and",
+			source: "!p & q",
+			want:   NewAnd(NewNot(p), q),
+		},
+		{
+			name:   "and over xor",
+			source: "p ^ q & r",
+			want:   NewXor(p, NewAnd(q, r)),
+		},
+		{
+			name:   "nand over xor",
+			source: "p !& q ^ r",
+			want:   NewXor(NewNand(p, q), r),
+		},
+		{
+			name:   "xor over or",
+			source: "p | q ^ r",
+			want:   NewOr(p, NewXor(q, r)),
+		},
+		{
+			name:   "nor over implication",
+			source: "p -> q !| r",
+			want:   NewImplies(p, NewNor(q, r)),
+		},
+		{
+			name:   "implication over biconditional",
+			source: "p <-> q -> r",
+			want:   NewBiconditional(p, NewImplies(q, r)),
+		},
+		{
+			name:   "same level and nand",
+			source: "p & q !& r",
+			want:   NewNand(NewAnd(p, q), r),
+		},
+		{
+			name:   "same level or nor",
+			source: "p !| q | r",
+			want:   NewOr(NewNor(p, q), r),
+		},
+		{
+			name:   "parentheses override precedence",
+			source: "p & (q -> r)",
+			want:   NewAnd(p, NewImplies(q, r)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseE(tt.source)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAssociativity(t *testing.T) {
+	r := NewLetter("r")
+	tests := []struct {
+		name   string
+		source string
+		want   Formula
+	}{
+		{
+			name:   "implication is right-associative",
+			source: "p -> q -> r",
+			want:   NewImplies(p, NewImplies(q, r)),
+		},
+		{
+			name:   "and chains are n-ary",
+			source: "p & q & r & p",
+			want:   NewNAry(And, p, q, r, p),
+		},
+		{
+			name:   "or chains are n-ary",
+			source: "p | q | r",
+			want:   NewNAry(Or, p, q, r),
+		},
+		{
+			name:   "parenthesized chains are binary",
+			source: "(p & q) & r | (q | r)",
+			want:   NewOr(NewAnd(NewAnd(p, q), r), NewOr(q, r)),
+		},
+		{
+			name:   "nand is left-associative",
+			source: "p & q !& r & p",
+			want:   NewAnd(NewNand(NewAnd(p, q), r), p),
+		},
+		{
+			name:   "chains of mixed operators",
+			source: "p & q & r !& p",
+			want:   NewNand(NewNAry(And, p, q, r), p),
+		},
+		{
+			name:   "xor chains are n-ary",
+			source: "p ^ q ^ r",
+			want:   NewNAry(Xor, p, q, r),
+		},
+		{
+			name:   "converse implication is left-associative",
+			source: "p <- q <- r",
+			want:   NewConverseImplies(NewConverseImplies(p, q), r),
+		},
+		{
+			name:   "converse and non-implication bind looser than implication",
+			source: "p -> q !-> r <- p <-> q",
+			want:   NewBiconditional(NewConverseImplies(NewNonImplies(NewImplies(p, q), r), p), q),
+		},
+		{
+			name:   "if-then-else",
+			source: "ite(p, q, !r) & ite(p & q, r, ite(q, r, p))",
+			want:   NewAnd(NewIte(p, q, NewNot(r)), NewIte(NewAnd(p, q), r, NewIte(q, r, p))),
+		},
+		{
+			name:   "cardinality constraints",
+			source: "atmost(1; p, q, r) | exactly(0; p & q) -> atleast(2; p, atmost(0; q))",
+			want: NewImplies(NewOr(NewAtMost(1, p, q, r), NewExactly(0, NewAnd(p, q))),
+				NewAtLeast(2, p, NewAtMost(0, q))),
+		},
+		{
+			name:   "biconditional is left-associative",
+			source: "p <-> q <-> r",
+			want:   NewBiconditional(NewBiconditional(p, q), r),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseE(tt.source)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRedundantParentheses(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   Formula
+	}{
+		{
+			name:   "letter",
+			source: "((p))",
+			want:   p,
+		},
+		{
+			name:   "negation",
+			source: "!(p)",
+			want:   NewNot(p),
+		},
+		{
+			name:   "binary",
+			source: "(((p) & (q)))",
+			want:   NewAnd(p, q),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseE(tt.source)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAlternativeOperators(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []string
+		want    Formula
+	}{
+		{
+			name:    "and",
+			sources: []string{"p ∧ q", `p /\ q`, "p and q", `p \land q`, `p \wedge q`},
+			want:    NewAnd(p, q),
+		},
+		{
+			name:    "or",
+			sources: []string{"p ∨ q", `p \/ q`, "p or q", `p \lor q`, `p \vee q`},
+			want:    NewOr(p, q),
+		},
+		{
+			name:    "not",
+			sources: []string{"¬p", "~p", "-p", "not p", `\neg p`, `\lnot p`},
+			want:    NewNot(p),
+		},
+		{
+			name:    "implication",
+			sources: []string{"p → q", "p ⇒ q", "p => q", `p \to q`, `p \rightarrow q`, `p \Rightarrow q`, `p \implies q`},
+			want:    NewImplies(p, q),
+		},
+		{
+			name:    "biconditional",
+			sources: []string{"p ↔ q", "p ⇔ q", "p <=> q", `p \leftrightarrow q`, `p \Leftrightarrow q`, `p \iff q`},
+			want:    NewBiconditional(p, q),
+		},
+		{
+			name:    "nand",
+			sources: []string{"p ↑ q", "p nand q", `p \uparrow q`},
+			want:    NewNand(p, q),
+		},
+		{
+			name:    "nor",
+			sources: []string{"p ↓ q", "p nor q", `p \downarrow q`},
+			want:    NewNor(p, q),
+		},
+		{
+			name:    "xor",
+			sources: []string{"p ⊕ q", "p ⊻ q", "p xor q", `p \oplus q`, `p \veebar q`},
+			want:    NewXor(p, q),
+		},
+		{
+			name:    "converse implication",
+			sources: []string{"p ← q", "p ⇐ q", "p <= q", `p \leftarrow q`, `p \Leftarrow q`},
+			want:    NewConverseImplies(p, q),
+		},
+		{
+			name:    "non-implication",
+			sources: []string{"p ↛ q", `p \nrightarrow q`},
+			want:    NewNonImplies(p, q),
+		},
+		{
+			name:    "cardinality constraint",
+			sources: []string{`\mathrm{atleast}\left(1; p, q\right)`, `\operatorname{atleast}(1; p, q)`},
+			want:    NewAtLeast(1, p, q),
+		},
+		{
+			name:    "latex parentheses",
+			sources: []string{`\left(p \land q\right)`, `\left(\left(p\right) \land q\right)`},
+			want:    NewAnd(p, q),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, source := range tt.sources {
+				got, err := ParseE(source)
+				if err != nil {
+					t.Fatalf("unexpected error parsing %q: %v", source, err)
+				}
+				if got != tt.want {
+					t.Errorf("%q: got %v, want %v", source, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseConstants(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []string
+		want    Formula
+	}{
+		{
+			name:    "top",
+			sources: []string{"T", "true", "⊤", `\top`},
+			want:    NewTop(),
+		},
+		{
+			name:    "bottom",
+			sources: []string{"F", "false", "⊥", `\bot`},
+			want:    NewBottom(),
+		},
+		{
+			name:    "in a formula",
+			sources: []string{"p & !T -> F", `p \land \neg \top \to \bot`},
+			want:    NewImplies(NewAnd(p, NewNot(NewTop())), NewBottom()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, source := range tt.sources {
+				got, err := ParseE(source)
+				if err != nil {
+					t.Fatalf("unexpected error parsing %q: %v", source, err)
+				}
+				if got != tt.want {
+					t.Errorf("%q: got %v, want %v", source, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseKeywordPrefixedLetters(t *testing.T) {
+	for _, name := range []string{
+		"android", "order", "nothing", "xor1", "nandor", "Top", "False", "truth", "therefore_", "atmost1", "exactlyone",
+	} {
+		if got := Parse(name); got != NewLetter(name) {
+			t.Errorf("got %v, want letter %s", got, name)
+		}
+	}
+}
+
+func TestParseE_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		line      int
+		column    int
+		offending string
+		expected  []string
+	}{
+		{
+			name:      "empty input",
+			source:    "",
+			line:      1,
+			column:    0,
+			offending: "<EOF>",
+			expected: []string{"'('", "'!'", "'T'", "'F'", "'let'", "'def'", "'ite'", "'atmost'", "'atleast'", "'exactly'",
+				"VARIABLE"},
+		},
+		{
+			name:      "missing right operand",
+			source:    "(p & )",
+			line:      1,
+			column:    5,
+			offending: ")",
+			expected:  []string{"'('", "'!'", "'T'", "'F'", "'ite'", "'atmost'", "'atleast'", "'exactly'", "VARIABLE"},
+		},
+		{
+			name:      "missing closing parenthesis",
+			source:    "(p & q",
+			line:      1,
+			column:    6,
+			offending: "<EOF>",
+			expected:  []string{"')'"},
+		},
+		{
+			name:      "unknown character",
+			source:    "(p & ?)",
+			line:      1,
+			column:    5,
+			offending: "?",
+		},
+		{
+			name:      "second line",
+			source:    "(p &\n q q)",
+			line:      2,
+			column:    3,
+			offending: "q",
+			expected:  []string{"')'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseE(tt.source)
+			if err == nil {
+				t.Fatalf("expected an error, got %v", got)
+			}
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected a *ParseError, got %T", err)
+			}
+
+			first := parseErr.Errors[0]
+			if first.Line != tt.line || first.Column != tt.column {
+				t.Errorf("got position %d:%d, want %d:%d", first.Line, first.Column, tt.line, tt.column)
+			}
+
+			if first.Offending != tt.offending {
+				t.Errorf("got offending %q, want %q", first.Offending, tt.offending)
+			}
+
+			if !slices.Equal(first.Expected, tt.expected) {
+				t.Errorf("got expected tokens %v, want %v", first.Expected, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseE_CollectsAllErrors(t *testing.T) {
+	_, err := ParseE("(p ? q) ?")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a *ParseError, got %v", err)
+	}
+
+	if len(parseErr.Errors) < 2 {
+		t.Errorf("expected every syntax error to be collected, got %v", parseErr.Errors)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if got := MustParse("(p & q)"); got != NewAnd(p, q) {
+		t.Errorf("got %v, want %v", got, NewAnd(p, q))
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("MustParse did not panic on malformed input")
+		}
+	}()
+	MustParse("(p & q")
+}
+
+func TestParseDefinitions(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"abbreviation", "let A = (p & q); A -> A", "((p & q) -> (p & q))"},
+		{"schema", "def Pierce(X, Y) = (((X -> Y) -> X) -> X); Pierce(p, q & r)", "(((p -> (q & r)) -> p) -> p)"},
+		{"definitions using definitions", "let A = p | q; def S(X) = X & A; let B = S(!p); B -> S(q)",
+			"((!p & (p | q)) -> (q & (p | q)))"},
+		{"parameters shadow abbreviations", "let X = r; def S(X) = X & q; S(p) | X", "((p & q) | r)"},
+		{"arguments are not substituted again", "def S(X, Y) = X & Y; S(Y, X)", "(Y & X)"},
+		{"over more lines", "let A = p;\n\ndef S(X) =\n  !X;\nS(A)", "!p"},
+		{"keyword prefixed letters", "letter & define", "(letter & define)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseE(tt.source)
+			if err != nil || got.String() != tt.want {
+				t.Errorf("ParseE(%q) = %v, %v, want %v", tt.source, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDefinitions_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		column int
+		msg    string
+	}{
+		{"undefined schema", "S(p)", 0, "undefined schema S"},
+		{"wrong number of arguments", "def S(X) = X; S(p, q)", 14, "S expects 1 arguments, found 2"},
+		{"schema without arguments", "def S(X) = X; S", 14, "S expects 1 arguments"},
+		{"abbreviation with arguments", "let A = p; A(q)", 11, "A expects 0 arguments, found 1"},
+		{"repeated definition", "let A = p; def A(X) = X; A", 15, "A is already defined"},
+		{"repeated parameter", "def S(X, X) = X; p", 9, "repeated parameter X"},
+		{
+			"bound that is not a number", "def S(X) = atmost(X; p, q); S(p)", 18,
+			"the bound of atmost must be a number, found X",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseE(tt.source)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseE(%q) = %v, %v, want a *ParseError", tt.source, got, err)
+			}
+			if first := parseErr.Errors[0]; first.Line != 1 || first.Column != tt.column || first.Msg != tt.msg {
+				t.Errorf("got %v, want line 1:%d %s", first, tt.column, tt.msg)
+			}
+		})
+	}
+}
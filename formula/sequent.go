@@ -0,0 +1,149 @@
+package formula
+
+import (
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/francodesource/propositional_tableaux/formula/parser"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Sequent is an argument from a list of premises to a list of conclusions. It is valid if every assignment that
+// satisfies all the premises satisfies at least one of the conclusions: so a sequent without conclusions is valid if
+// its premises are unsatisfiable, and one without premises is valid if the disjunction of its conclusions is.
+type Sequent struct {
+	Premises    []Formula
+	Conclusions []Formula
+}
+
+// String returns the sequent as "p, (p -> q) |- q", which ParseSequent reads back.
+func (s Sequent) String() string {
+	join := func(fs []Formula) string {
+		strs := make([]string, len(fs))
+		for i, f := range fs {
+			strs[i] = f.String()
+		}
+		return strings.Join(strs, ", ")
+	}
+	return strings.TrimSpace(join(s.Premises) + " |- " + join(s.Conclusions))
+}
+
+// Formula returns the Conjunction of the premises and of the negations of the conclusions, which is n-ary when they
+// are more than two: the sequent is valid if and only if this formula is unsatisfiable, and its tableau decides it.
+// The formula of a sequent without premises and conclusions is ⊤.
+func (s Sequent) Formula() Formula {
+	conjuncts := slices.Clone(s.Premises)
+	for _, f := range s.Conclusions {
+		conjuncts = append(conjuncts, NewNot(f))
+	}
+	return Conjunction(conjuncts...)
+}
+
+// lineSeparatedLexer is a FormulaLexer that reads a line break between two formulas as a comma, so that the formulas
+// of a sequent can also be written one per line. A line break elsewhere, like after an operator, is still whitespace.
+type lineSeparatedLexer struct {
+	*parser.FormulaLexer
+	last, pending antlr.Token
+}
+
+func (l *lineSeparatedLexer) NextToken() antlr.Token {
+	if l.pending != nil {
+		l.last, l.pending = l.pending, nil
+		return l.last
+	}
+
+	t := l.FormulaLexer.NextToken()
+	if l.last != nil && t.GetLine() > l.last.GetLine() && endsFormula(l.last) && startsFormula(t) {
+		l.pending = t
+		t = l.GetTokenFactory().Create(t.GetSource(), parser.FormulaLexerCOMMA, ",", antlr.TokenDefaultChannel,
+			t.GetStart(), t.GetStart()-1, l.last.GetLine(), l.last.GetColumn()+utf8.RuneCountInString(l.last.GetText()))
+	}
+	l.last = t
+	return t
+}
+
+// endsFormula reports whether a formula can end with the token.
+func endsFormula(t antlr.Token) bool {
+	switch t.GetTokenType() {
+	case parser.FormulaLexerVARIABLE, parser.FormulaLexerTOP, parser.FormulaLexerBOTTOM, parser.FormulaLexerCP:
+		return true
+	}
+	return false
+}
+
+// startsFormula reports whether a formula can start with the token.
+func startsFormula(t antlr.Token) bool {
+	switch t.GetTokenType() {
+	case parser.FormulaLexerVARIABLE, parser.FormulaLexerTOP, parser.FormulaLexerBOTTOM, parser.FormulaLexerOP,
+		parser.FormulaLexerNOT:
+		return true
+	}
+	return false
+}
+
+// ParseSequent parses a sequent: the premises and the conclusions are lists of formulas separated by commas, on the
+// two sides of the turnstile |-, which can also be written as ⊢, ∴, therefore, \vdash or \therefore. Both lists can
+// be empty. As ParseE, it returns a *ParseError containing all the syntax errors found.
+//
+// A line break between two formulas separates them like a comma, and the text from # to the end of the line is a
+// comment, so an argument can be written with a premise per line:
+//
+//	# modus ponens
+//	p
+//	p -> q
+//	therefore q
+//
+// A formula can still span more lines if they break after an operator or before a binary operator.
+// The keyword therefore cannot be used as a letter, and |- is always a turnstile: "p |-q" is the sequent from p to
+// q, and a disjunction with a negated operand needs a space, as in "p | -q |- r".
+//
+// As in ParseE, the sequent can be preceded by definitions.
+func ParseSequent(input string) (Sequent, error) {
+	s, _, err := ParseSequentAbbreviated(input)
+	return s, err
+}
+
+// ParseSequentAbbreviated is like ParseSequent, but it also returns the Abbreviations of the definitions used in the
+// input, as ParseAbbreviated does.
+func ParseSequentAbbreviated(input string) (Sequent, Abbreviations, error) {
+	errListener := &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	lexer := &lineSeparatedLexer{FormulaLexer: newLexer(input, errListener)}
+	p := newParser(lexer, errListener)
+
+	tree := p.Sequent()
+	if len(errListener.errors) > 0 {
+		return Sequent{}, nil, &ParseError{Input: input, Errors: errListener.errors}
+	}
+
+	listener := &formulaListener{}
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	if len(listener.errors) > 0 {
+		return Sequent{}, nil, &ParseError{Input: input, Errors: listener.errors}
+	}
+	premises := 0
+	if tree.GetPremises() != nil {
+		premises = len(tree.GetPremises().AllExpression())
+	}
+
+	// the listener pushes the formulas in order, so the premises are the first ones.
+	var s Sequent
+	if premises > 0 {
+		s.Premises = listener.stack[:premises]
+	}
+	if len(listener.stack) > premises {
+		s.Conclusions = listener.stack[premises:]
+	}
+	return s, listener.abbreviations, nil
+}
+
+// IsSequent reports whether the input contains a turnstile, so it must be parsed with ParseSequent instead of ParseE.
+func IsSequent(input string) bool {
+	errListener := &errorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	lexer := newLexer(input, errListener)
+	for t := lexer.NextToken(); t.GetTokenType() != antlr.TokenEOF; t = lexer.NextToken() {
+		if t.GetTokenType() == parser.FormulaLexerTURNSTILE {
+			return true
+		}
+	}
+	return false
+}
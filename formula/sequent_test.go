@@ -0,0 +1,193 @@
+package formula
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSequent(t *testing.T) {
+	r := NewLetter("r")
+	tests := []struct {
+		name   string
+		source string
+		want   Sequent
+	}{
+		{
+			name:   "premises and conclusion",
+			source: "p, (p -> q) |- q",
+			want:   Sequent{Premises: []Formula{p, NewImplies(p, q)}, Conclusions: []Formula{q}},
+		},
+		{
+			name:   "more conclusions",
+			source: "p | q ⊢ p, q",
+			want:   Sequent{Premises: []Formula{NewOr(p, q)}, Conclusions: []Formula{p, q}},
+		},
+		{
+			name:   "no premises",
+			source: `\vdash p | !p`,
+			want:   Sequent{Conclusions: []Formula{NewOr(p, NewNot(p))}},
+		},
+		{
+			name:   "no conclusions",
+			source: "p, !p ∴",
+			want:   Sequent{Premises: []Formula{p, NewNot(p)}},
+		},
+		{
+			name:   "empty",
+			source: "|-",
+			want:   Sequent{},
+		},
+		{
+			name:   "premise per line",
+			source: "# modus ponens\np\n\np -> q   # the rule\ntherefore q\n",
+			want:   Sequent{Premises: []Formula{p, NewImplies(p, q)}, Conclusions: []Formula{q}},
+		},
+		{
+			name:   "definitions",
+			source: "let A = p -> q;\ndef MP(X, Y) = X & (X -> Y);\nA\nMP(p, A) |- q",
+			want: Sequent{
+				Premises:    []Formula{NewImplies(p, q), NewAnd(p, NewImplies(p, NewImplies(p, q)))},
+				Conclusions: []Formula{q},
+			},
+		},
+		{
+			name:   "formulas over more lines",
+			source: "(p &\nq)\n| r\n!r\ntherefore\np,\nq",
+			want:   Sequent{Premises: []Formula{NewOr(NewAnd(p, q), r), NewNot(r)}, Conclusions: []Formula{p, q}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSequent(tt.source)
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSequent(%q) = %v, %v, want %v", tt.source, got, err, tt.want)
+			}
+			if !IsSequent(tt.source) {
+				t.Errorf("IsSequent(%q) = false", tt.source)
+			}
+		})
+	}
+}
+
+func TestParseSequent_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		line      int
+		column    int
+		offending string
+	}{
+		{"missing turnstile", "p, q", 1, 4, "<EOF>"},
+		{"missing comma", "p q |- r", 1, 2, "q"},
+		{"trailing comma", "p |- q,", 1, 7, "<EOF>"},
+		{"two turnstiles", "p |- q |- r", 1, 7, "|-"},
+		{"line break after a comma", "p\n|- q,\n", 3, 0, "<EOF>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSequent(tt.source)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseSequent(%q) = %v, %v, want a *ParseError", tt.source, got, err)
+			}
+			first := parseErr.Errors[0]
+			if first.Line != tt.line || first.Column != tt.column || first.Offending != tt.offending {
+				t.Errorf("got %q at %d:%d, want %q at %d:%d", first.Offending, first.Line, first.Column,
+					tt.offending, tt.line, tt.column)
+			}
+		})
+	}
+}
+
+func TestSequent_String(t *testing.T) {
+	for _, source := range []string{"p, (p -> q) |- q", "|- (p | !p)", "p, !p |-", "|-", "p |- q, ⊥"} {
+		s, err := ParseSequent(source)
+		if err != nil || s.String() != source {
+			t.Errorf("ParseSequent(%q).String() = %q, %v", source, s, err)
+		}
+	}
+}
+
+func TestSequent_Formula(t *testing.T) {
+	tests := []struct {
+		sequent Sequent
+		want    string
+	}{
+		{Sequent{Premises: []Formula{p, NewImplies(p, q)}, Conclusions: []Formula{q}}, "(p & (p -> q) & !q)"},
+		{Sequent{Conclusions: []Formula{p, q}}, "(!p & !q)"},
+		{Sequent{Premises: []Formula{p}}, "p"},
+		{Sequent{}, "⊤"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.sequent.Formula(); got.String() != tt.want {
+			t.Errorf("%v: Formula() = %v, want %v", tt.sequent, got, tt.want)
+		}
+	}
+}
+
+// TestTurnstile_Spacing checks that |- is the turnstile whatever the spaces around it: a sequent reads it as such,
+// while a formula rejects it with an error pointing to ParseSequent, and | -q is a disjunction with a negated operand.
+func TestTurnstile_Spacing(t *testing.T) {
+	r := NewLetter("r")
+	disjunction := NewOr(p, NewNot(q))
+	for _, source := range []string{"p | -q", "p | - q", "p |(-q)"} {
+		if got, err := ParseE(source); err != nil || got != disjunction {
+			t.Errorf("ParseE(%q) = %v, %v, want %v", source, got, err, disjunction)
+		}
+	}
+
+	errorTests := []struct {
+		source string
+		column int
+	}{
+		{"p |-q", 2},
+		{"p|-q", 1},
+		{"p |- q", 2},
+		{"(r |-q) & p", 3},
+		{"p ⊢ q", 2},
+		{"p therefore q", 2},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.source, func(t *testing.T) {
+			var parseErr *ParseError
+			_, err := ParseE(tt.source)
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseE(%q) error = %v, want a *ParseError", tt.source, err)
+			}
+			if len(parseErr.Errors) != 1 {
+				t.Fatalf("ParseE(%q) errors = %v, want only the turnstile", tt.source, parseErr.Errors)
+			}
+			if e := parseErr.Errors[0]; e.Column != tt.column || !strings.Contains(e.Msg, "ParseSequent") {
+				t.Errorf("ParseE(%q) error = %v at column %d, want one pointing to ParseSequent at column %d",
+					tt.source, e.Msg, e.Column, tt.column)
+			}
+		})
+	}
+
+	tests := []struct {
+		source string
+		want   Sequent
+	}{
+		{"p |-q", Sequent{Premises: []Formula{p}, Conclusions: []Formula{q}}},
+		{"p|-q", Sequent{Premises: []Formula{p}, Conclusions: []Formula{q}}},
+		{"p | -q |- r", Sequent{Premises: []Formula{disjunction}, Conclusions: []Formula{r}}},
+	}
+	for _, tt := range tests {
+		if got, err := ParseSequent(tt.source); err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseSequent(%q) = %v, %v, want %v", tt.source, got, err, tt.want)
+		}
+	}
+}
+
+func TestIsSequent(t *testing.T) {
+	for source, want := range map[string]bool{"p | -q": false, "p |-q": true, "therefore_p": false, "# |-\np": false} {
+		if got := IsSequent(source); got != want {
+			t.Errorf("IsSequent(%q) = %v, want %v", source, got, want)
+		}
+	}
+}
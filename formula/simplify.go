@@ -0,0 +1,471 @@
+package formula
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Rule is a rewriting rule applied by Simplify.
+type Rule int
+
+const (
+	DoubleNegation   Rule = iota // DoubleNegation rewrites !!A to A.
+	NegatedConstant              // NegatedConstant rewrites !T to F and !F to T.
+	Identity                     // Identity removes neutral constants, like in (A & T), (A | F) or (T -> A).
+	Annihilation                 // Annihilation rewrites to a constant the formulas with an absorbing one, like (A & F).
+	NegatingConstant             // NegatingConstant rewrites to !A the formulas like (A -> F), (A <-> F) or (A ^ T).
+	Idempotence                  // Idempotence removes repeated operands, like in (A & A) or (A | B | A).
+	Complementation              // Complementation rewrites formulas with complementary operands, like (A & !A) to F.
+	EqualOperands                // EqualOperands rewrites to a constant (A -> A), (A <-> A) and (A ^ A).
+	Absorption                   // Absorption rewrites (A & (A | B)) to A and (A | (A & B)) to A.
+	Flattening                   // Flattening rebuilds nested chains of &, | or ^ as one n-ary formula, like the parser.
+	CoreConnectives              // CoreConnectives rewrites all operators except &, |, ->, <-> and !. It is optional.
+	Conditional                  // Conditional rewrites ite(T, A, B) to A, ite(F, A, B) to B and ite(C, A, A) to A.
+	Counting                     // Counting removes constant operands of a constraint or decides it, like atmost(2; A, B).
+)
+
+func (r Rule) String() string {
+	switch r {
+	case DoubleNegation:
+		return "double negation"
+	case NegatedConstant:
+		return "negated constant"
+	case Identity:
+		return "identity"
+	case Annihilation:
+		return "annihilation"
+	case NegatingConstant:
+		return "negating constant"
+	case Idempotence:
+		return "idempotence"
+	case Complementation:
+		return "complementation"
+	case EqualOperands:
+		return "equal operands"
+	case Absorption:
+		return "absorption"
+	case Flattening:
+		return "flattening"
+	case CoreConnectives:
+		return "core connectives"
+	case Conditional:
+		return "conditional"
+	case Counting:
+		return "counting"
+	default:
+		panic(fmt.Errorf("unknown Rule %d", int(r)))
+	}
+}
+
+// Step is the application of a Rule during a simplification: the subformula Before was rewritten to After.
+type Step struct {
+	Rule          Rule
+	Before, After Formula
+}
+
+func (s Step) String() string {
+	return fmt.Sprintf("%v: %v => %v", s.Rule, s.Before, s.After)
+}
+
+// SimplifyOption configures Simplify.
+type SimplifyOption func(*simplifier)
+
+// WithCoreConnectives makes Simplify rewrite Nand, Nor, Xor, ConverseImplies, NonImplies and Ite with the core
+// connectives: (A !& B) becomes !(A & B), (A !| B) becomes !(A | B), (A ^ B) becomes !(A <-> B), (A <- B) becomes
+// (B -> A), (A !-> B) becomes !(A -> B) and ite(C, A, B) becomes ((C -> A) & (!C -> B)). An n-ary exclusive
+// disjunction is first rewritten as a chain of binary ones, a cardinality constraint is written with the Pairwise
+// encoding of CompileCardinality and a registered connective is replaced with its Definition.
+func WithCoreConnectives() SimplifyOption {
+	return func(s *simplifier) {
+		s.coreConnectives = true
+	}
+}
+
+type simplifier struct {
+	coreConnectives bool
+	steps           []Step
+}
+
+// Simplify rewrites the formula into an equivalent one applying the rules until none of them can be applied, and
+// returns the steps that were applied in order. Chains of & and | are considered as a whole, so (p & (q & p))
+// is simplified to (p & q) by idempotence.
+func Simplify(formula Formula, opts ...SimplifyOption) (Formula, []Step) {
+	s := &simplifier{}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	for {
+		n := len(s.steps)
+		formula = s.simplify(formula)
+		if len(s.steps) == n {
+			return formula, s.steps
+		}
+	}
+}
+
+// simplify simplifies the operands of the formula and then the formula itself.
+func (s *simplifier) simplify(formula Formula) Formula {
+	formula = plain(formula)
+	switch f := formula.(type) {
+	case Not:
+		return s.rewrite(NewNot(s.simplify(f.Negated())))
+	case Binary:
+		return s.rewrite(NewBinary(s.simplify(f.Left()), s.simplify(f.Right()), f.Op()))
+	case NAry:
+		operands := f.Operands()
+		for i, operand := range operands {
+			operands[i] = s.simplify(operand)
+		}
+		return s.rewrite(NewNAry(f.Op(), operands...))
+	case Ite:
+		return s.rewrite(NewIte(s.simplify(f.Condition()), s.simplify(f.Then()), s.simplify(f.Else())))
+	case Cardinality:
+		operands := f.Operands()
+		for i, operand := range operands {
+			operands[i] = s.simplify(operand)
+		}
+		return s.rewrite(NewCardinality(f.Kind(), f.Bound(), operands...))
+	case Custom:
+		operands := f.Operands()
+		for i, operand := range operands {
+			operands[i] = s.simplify(operand)
+		}
+		return s.rewrite(f.with(operands))
+	default:
+		return formula
+	}
+}
+
+// rewrite applies the rules to the root of the formula, whose operands are already simplified.
+func (s *simplifier) rewrite(formula Formula) Formula {
+	var res Formula
+	var rule Rule
+	var ok bool
+
+	switch f := formula.(type) {
+	case Not:
+		res, rule, ok = rewriteNot(f)
+	case Binary:
+		switch f.Op() {
+		case And, Or:
+			res, rule, ok = rewriteChain(f, f.Op())
+		default:
+			res, rule, ok = s.rewriteBinary(f)
+		}
+	case NAry:
+		if f.Op() == Xor {
+			res, rule, ok = s.rewriteParity(f)
+		} else {
+			res, rule, ok = rewriteChain(f, f.Op())
+		}
+	case Ite:
+		res, rule, ok = s.rewriteIte(f)
+	case Cardinality:
+		res, rule, ok = s.rewriteCardinality(f)
+	case Custom:
+		if s.coreConnectives {
+			res, rule, ok = f.Definition(), CoreConnectives, true
+		}
+	}
+
+	if !ok {
+		return formula
+	}
+	s.steps = append(s.steps, Step{Rule: rule, Before: formula, After: res})
+	// the result can contain new subformulas, like !A from (A -> F), that must be simplified as well.
+	return s.simplify(res)
+}
+
+func rewriteNot(f Not) (Formula, Rule, bool) {
+	switch inner := f.Negated().(type) {
+	case Not:
+		return inner.Negated(), DoubleNegation, true
+	case Top:
+		return NewBottom(), NegatedConstant, true
+	case Bottom:
+		return NewTop(), NegatedConstant, true
+	}
+	return nil, 0, false
+}
+
+// constant returns the constant with the given value.
+func constant(value bool) Formula {
+	if value {
+		return NewTop()
+	}
+	return NewBottom()
+}
+
+// rewriteBinary applies the rules for the binary operators other than And and Or.
+func (s *simplifier) rewriteBinary(f Binary) (Formula, Rule, bool) {
+	l, r := f.Left(), f.Right()
+
+	if f.Op() == ConverseImplies {
+		// (A <- B) is rewritten as (B -> A) would be.
+		if res, rule, ok := s.rewriteBinary(NewImplies(r, l)); ok && rule != CoreConnectives {
+			return res, rule, true
+		}
+	}
+
+	if IsConstant(l) || IsConstant(r) {
+		// k is the constant operand, c its value and a the other operand. When both are constants, k is the left one.
+		k, a, cIsLeft := l, r, true
+		if !IsConstant(l) {
+			k, a, cIsLeft = r, l, false
+		}
+		c := AsConstant(k)
+
+		switch f.Op() {
+		case Implies:
+			switch {
+			case cIsLeft && c:
+				return a, Identity, true
+			case cIsLeft && !c, !cIsLeft && c:
+				return NewTop(), Annihilation, true
+			default:
+				return NewNot(a), NegatingConstant, true
+			}
+		case Biconditional:
+			if c {
+				return a, Identity, true
+			}
+			return NewNot(a), NegatingConstant, true
+		case Xor:
+			if !c {
+				return a, Identity, true
+			}
+			return NewNot(a), NegatingConstant, true
+		case Nand:
+			if !c {
+				return NewTop(), Annihilation, true
+			}
+			return NewNot(a), NegatingConstant, true
+		case Nor:
+			if c {
+				return NewBottom(), Annihilation, true
+			}
+			return NewNot(a), NegatingConstant, true
+		case NonImplies:
+			switch {
+			case cIsLeft && c:
+				return NewNot(a), NegatingConstant, true
+			case cIsLeft && !c, !cIsLeft && c:
+				return NewBottom(), Annihilation, true
+			default:
+				return a, Identity, true
+			}
+		}
+	}
+
+	if l == r {
+		switch f.Op() {
+		case Implies, Biconditional:
+			return NewTop(), EqualOperands, true
+		case Xor:
+			return NewBottom(), EqualOperands, true
+		case Nand, Nor:
+			return NewNot(l), Idempotence, true
+		case NonImplies:
+			return NewBottom(), EqualOperands, true
+		}
+	}
+
+	if Complement(l) == r || Complement(r) == l {
+		switch f.Op() {
+		case Implies:
+			return r, Complementation, true // (A -> !A) is !A and (!A -> A) is A
+		case Biconditional:
+			return NewBottom(), Complementation, true
+		case Xor, Nand:
+			return NewTop(), Complementation, true
+		case Nor:
+			return NewBottom(), Complementation, true
+		case NonImplies:
+			return l, Complementation, true // (A !-> !A) is A and (!A !-> A) is !A
+		}
+	}
+
+	if s.coreConnectives {
+		switch f.Op() {
+		case Nand:
+			return NewNot(NewAnd(l, r)), CoreConnectives, true
+		case Nor:
+			return NewNot(NewOr(l, r)), CoreConnectives, true
+		case Xor:
+			return NewNot(NewBiconditional(l, r)), CoreConnectives, true
+		case ConverseImplies:
+			return NewImplies(r, l), CoreConnectives, true
+		case NonImplies:
+			return NewNot(NewImplies(l, r)), CoreConnectives, true
+		}
+	}
+
+	return nil, 0, false
+}
+
+// chainOperands returns the operands of the maximal chain of op rooted in the formula, from left to right: the chain
+// is made of binary and n-ary formulas with operator op.
+func chainOperands(formula Formula, op Operator) []Formula {
+	switch f := formula.(type) {
+	case Binary:
+		if f.Op() == op {
+			return append(chainOperands(f.Left(), op), chainOperands(f.Right(), op)...)
+		}
+	case NAry:
+		if f.Op() == op {
+			var res []Formula
+			for _, operand := range f.Operands() {
+				res = append(res, chainOperands(operand, op)...)
+			}
+			return res
+		}
+	}
+	return []Formula{formula}
+}
+
+// buildChain joins the operands with op as the parser does, with Conjunction or Disjunction. The operands must be at
+// least one.
+func buildChain(operands []Formula, op Operator) Formula {
+	if op == And {
+		return Conjunction(operands...)
+	}
+	return Disjunction(operands...)
+}
+
+// rewriteChain applies the rules to a chain of And or Or, considering all the operands of the chain at once.
+func rewriteChain(f Formula, op Operator) (Formula, Rule, bool) {
+	// neutral is the value of the constant that can be removed from the chain, !neutral is the absorbing one.
+	neutral := op == And
+	dual := Or
+	if op == Or {
+		dual = And
+	}
+
+	operands := chainOperands(f, op)
+
+	if built := buildChain(operands, op); built != f {
+		return built, Flattening, true
+	}
+
+	for _, operand := range operands {
+		if IsConstant(operand) && AsConstant(operand) != neutral {
+			return constant(!neutral), Annihilation, true
+		}
+		if slices.Contains(operands, Complement(operand)) {
+			return constant(!neutral), Complementation, true
+		}
+	}
+
+	without := func(remove func(i int, operand Formula) bool) []Formula {
+		var res []Formula
+		for i, operand := range operands {
+			if !remove(i, operand) {
+				res = append(res, operand)
+			}
+		}
+		return res
+	}
+
+	rules := []struct {
+		rule   Rule
+		remove func(i int, operand Formula) bool
+	}{
+		{Identity, func(_ int, operand Formula) bool {
+			return IsConstant(operand)
+		}},
+		{Idempotence, func(i int, operand Formula) bool {
+			return slices.Index(operands, operand) < i
+		}},
+		{Absorption, func(i int, operand Formula) bool {
+			// the operand is a chain of the dual operator containing another operand of the chain.
+			inner := chainOperands(operand, dual)
+			for j, other := range operands {
+				if j != i && len(inner) > 1 && slices.Contains(inner, other) {
+					return true
+				}
+			}
+			return false
+		}},
+	}
+
+	for _, r := range rules {
+		if remaining := without(r.remove); len(remaining) < len(operands) {
+			if len(remaining) == 0 {
+				return constant(neutral), r.rule, true
+			}
+			return buildChain(remaining, op), r.rule, true
+		}
+	}
+
+	return nil, 0, false
+}
+
+// rewriteParity applies the rules to an n-ary exclusive disjunction, considering all the operands of the chain at once.
+func (s *simplifier) rewriteParity(f NAry) (Formula, Rule, bool) {
+	if s.coreConnectives {
+		return f.binary(), CoreConnectives, true
+	}
+
+	operands := chainOperands(f, Xor)
+	if built := Parity(operands...); built != f {
+		return built, Flattening, true
+	}
+
+	for i, operand := range operands {
+		rest := slices.Delete(slices.Clone(operands), i, i+1)
+		if IsConstant(operand) && AsConstant(operand) {
+			return NewNot(Parity(rest...)), NegatingConstant, true
+		}
+		if IsConstant(operand) {
+			return Parity(rest...), Identity, true
+		}
+		// (A ^ B ^ A) is B, since A ^ A is F.
+		if j := slices.Index(rest, operand); j >= 0 {
+			return Parity(slices.Delete(rest, j, j+1)...), EqualOperands, true
+		}
+	}
+
+	return nil, 0, false
+}
+
+// rewriteIte applies the rules to an if-then-else.
+func (s *simplifier) rewriteIte(f Ite) (Formula, Rule, bool) {
+	c, a, b := f.Condition(), f.Then(), f.Else()
+
+	switch {
+	case IsConstant(c) && AsConstant(c):
+		return a, Conditional, true
+	case IsConstant(c):
+		return b, Conditional, true
+	case a == b:
+		return a, Conditional, true
+	case s.coreConnectives:
+		return NewAnd(NewImplies(c, a), NewImplies(NewNot(c), b)), CoreConnectives, true
+	}
+
+	return nil, 0, false
+}
+
+// rewriteCardinality applies the rules to a cardinality constraint: the true operands are removed decreasing the
+// bound, the false ones are removed, and the constraint becomes a constant when the bound decides it.
+func (s *simplifier) rewriteCardinality(f Cardinality) (Formula, Rule, bool) {
+	bound := f.Bound()
+	var operands []Formula
+	for _, operand := range f.Operands() {
+		switch {
+		case !IsConstant(operand):
+			operands = append(operands, operand)
+		case AsConstant(operand):
+			bound--
+		}
+	}
+
+	if _, decided := decide(f.Kind(), bound, 0, len(operands)); decided || len(operands) < f.Len() {
+		return cardinality(f.Kind(), bound, operands), Counting, true
+	}
+	if s.coreConnectives {
+		return pairwise(f), CoreConnectives, true
+	}
+	return nil, 0, false
+}
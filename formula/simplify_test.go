@@ -0,0 +1,157 @@
+package formula
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		rules []Rule
+	}{
+		{"double negation", "!!!!p", "p", []Rule{DoubleNegation, DoubleNegation}},
+		{"negated constant", "!T", "⊥", []Rule{NegatedConstant}},
+		{"idempotence", "p & p", "p", []Rule{Idempotence}},
+		{"idempotence in a chain", "p & (q & p)", "(p & q)", []Rule{Flattening, Idempotence}},
+		{"idempotence of nand", "p !& p", "!p", []Rule{Idempotence}},
+		{"absorption", "p & (p | q)", "p", []Rule{Absorption}},
+		{"absorption of or", "(p & q) | r | p", "(r | p)", []Rule{Absorption}},
+		{"complement", "p & !p", "⊥", []Rule{Complementation}},
+		{"excluded middle", "q | !q", "⊤", []Rule{Complementation}},
+		{"complement in a chain", "p | q | !p", "⊤", []Rule{Complementation}},
+		{"complement of implication", "p -> !p", "!p", []Rule{Complementation}},
+		{"identity", "p & T", "p", []Rule{Identity}},
+		{"identity of implication", "T -> p", "p", []Rule{Identity}},
+		{"annihilation", "p | q | T", "⊤", []Rule{Annihilation}},
+		{"annihilation of implication", "F -> p", "⊤", []Rule{Annihilation}},
+		{"negating constant", "p -> F", "!p", []Rule{NegatingConstant}},
+		{"negating constant and double negation", "!p ^ T", "p", []Rule{NegatingConstant, DoubleNegation}},
+		{"equal operands", "(p & q) <-> (p & q)", "⊤", []Rule{EqualOperands}},
+		{"flattening", "p | (q | r)", "(p | q | r)", []Rule{Flattening}},
+		{"parity", "p ^ q ^ (r ^ p) ^ F", "(q ^ r)", []Rule{Flattening, EqualOperands, Identity}},
+		{"negating constant in a parity", "p ^ T ^ q", "!(p ^ q)", []Rule{NegatingConstant}},
+		{"converse implication", "p <- (p & T)", "⊤", []Rule{Identity, EqualOperands}},
+		{"non-implication", "(p !-> F) !-> !p", "p", []Rule{Identity, Complementation}},
+		{"conditional", "ite(!T, p, ite(p, q, q))", "q", []Rule{NegatedConstant, Conditional, Conditional}},
+		{"counting", "atmost(1; p, T, F, q)", "atmost(0; p, q)", []Rule{Counting}},
+		{"counting decides the bound", "exactly(1; T, p & F) | atleast(3; p, q)", "⊤", []Rule{Annihilation, Counting,
+			Counting, Annihilation}},
+		{
+			name:  "n-ary chains",
+			input: "p & (q & r & (p | q)) & q & !(r & p)",
+			want:  "(p & q & r & !(r & p))",
+			rules: []Rule{Absorption, Flattening, Idempotence},
+		},
+		{"nothing to simplify", "p -> (q ^ r)", "(p -> (q ^ r))", nil},
+		{
+			name:  "cascade",
+			input: "!!(p & (T & p)) | (F ^ !q)",
+			want:  "(p | !q)",
+			rules: []Rule{Identity, Idempotence, DoubleNegation, Identity},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, steps := Simplify(Parse(tt.input))
+			if got.String() != tt.want {
+				t.Errorf("Simplify(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+
+			var rules []Rule
+			for _, step := range steps {
+				rules = append(rules, step.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.rules) {
+				t.Errorf("rules = %v, want %v", rules, tt.rules)
+			}
+		})
+	}
+}
+
+func TestSimplify_CoreConnectives(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"p !& q", "!(p & q)"},
+		{"p !| q", "!(p | q)"},
+		{"p ^ q", "!(p <-> q)"},
+		{"!(p !& q)", "(p & q)"},
+		{"p <- q", "(q -> p)"},
+		{"p !-> q", "!(p -> q)"},
+		{"p ^ q ^ r", "!(!(p <-> q) <-> r)"},
+		{"ite(p, q, r)", "((p -> q) & (!p -> r))"},
+		{"atmost(1; p, q, r)", "((!p | !q) & (!p | !r) & (!q | !r))"},
+		{"exactly(1; p, q)", "((p | q) & (!p | !q))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got, _ := Simplify(Parse(tt.input), WithCoreConnectives()); got.String() != tt.want {
+				t.Errorf("Simplify(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+			if got, _ := Simplify(Parse(tt.input)); got.String() == tt.want {
+				t.Errorf("Simplify(%v) without options rewrote the connectives", tt.input)
+			}
+		})
+	}
+}
+
+func TestStep_String(t *testing.T) {
+	_, steps := Simplify(Parse("!!p"))
+	if want := "double negation: !!p => p"; len(steps) != 1 || steps[0].String() != want {
+		t.Errorf("steps = %v, want [%v]", steps, want)
+	}
+}
+
+// TestSimplify_Equivalence checks that the simplified formula is equivalent to the original one, that the
+// simplification is a fixpoint and that every step of the trace is an equivalence.
+func TestSimplify_Equivalence(t *testing.T) {
+	f := func(f Formula, core bool) bool {
+		var opts []SimplifyOption
+		if core {
+			opts = append(opts, WithCoreConnectives())
+		}
+
+		simplified, steps := Simplify(f, opts...)
+		if again, more := Simplify(simplified, opts...); len(more) > 0 {
+			t.Errorf("%v: simplifying %v again gives %v with %v", f, simplified, again, more)
+			return false
+		}
+
+		for _, a := range assignments(f) {
+			want, _ := Eval(f, a)
+			if got, err := Eval(simplified, a); err != nil || got != want {
+				t.Errorf("%v: simplified to %v which differs under %v", f, simplified, a)
+				return false
+			}
+
+			for _, step := range steps {
+				before, _ := Eval(step.Before, a)
+				after, err := Eval(step.After, a)
+				if err != nil || before != after {
+					t.Errorf("%v: step %v is not an equivalence under %v", f, step, a)
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(GenerateRandom(r, r.Intn(30)))
+			values[1] = reflect.ValueOf(r.Intn(2) == 0)
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,39 @@
+package formula
+
+import "strconv"
+
+// Substitute replaces simultaneously every occurrence of the letters in sub with the corresponding formula.
+// The substituted formulas are not substituted again, so Substitute(p & q, {p: q, q: p}) is (q & p).
+// Letters that are not in sub are left unchanged.
+func Substitute(formula Formula, sub map[string]Formula) Formula {
+	return Transform(formula, func(f Formula) Formula {
+		if l, ok := f.(Letter); ok {
+			if replacement, ok := sub[l.Name()]; ok {
+				return replacement
+			}
+		}
+		return f
+	})
+}
+
+// Rename replaces simultaneously the name of every letter in names with the corresponding new name.
+// Letters that are not in names are left unchanged.
+func Rename(formula Formula, names map[string]string) Formula {
+	sub := make(map[string]Formula, len(names))
+	for from, to := range names {
+		sub[from] = NewLetter(to)
+	}
+	return Substitute(formula, sub)
+}
+
+// Canonicalize renames the letters of the formula to p1, p2, ..., pn in order of first occurrence, so formulas that
+// differ only by the names of their letters have the same canonical form.
+// It returns the canonical formula and the renaming from the original names to the canonical ones, which can be
+// inverted to translate back the assignments of the canonical formula.
+func Canonicalize(formula Formula) (Formula, map[string]string) {
+	names := make(map[string]string)
+	for i, name := range Letters(formula) {
+		names[name] = "p" + strconv.Itoa(i+1)
+	}
+	return Rename(formula, names), names
+}
@@ -0,0 +1,75 @@
+package formula
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestSubstitute(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		sub   map[string]Formula
+		want  string
+	}{
+		{
+			name:  "schema instance",
+			input: "A -> (B -> A)",
+			sub:   map[string]Formula{"A": Parse("p & q"), "B": Parse("!r")},
+			want:  "((p & q) -> (!r -> (p & q)))",
+		},
+		{
+			name:  "simultaneous",
+			input: "p & q",
+			sub:   map[string]Formula{"p": letters.q, "q": letters.p},
+			want:  "(q & p)",
+		},
+		{
+			name:  "substituted formulas are not substituted again",
+			input: "p",
+			sub:   map[string]Formula{"p": Parse("p | q"), "q": letters.r},
+			want:  "(p | q)",
+		},
+		{
+			name:  "missing letters are unchanged",
+			input: "!p ^ T",
+			sub:   map[string]Formula{"q": letters.r},
+			want:  "(!p ^ ⊤)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Substitute(Parse(tt.input), tt.sub); got.String() != tt.want {
+				t.Errorf("Substitute(%v, %v) = %v, want %v", tt.input, tt.sub, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	got := Rename(Parse("(p -> q) <-> r"), map[string]string{"p": "q", "q": "p", "s": "t"})
+	if want := "((q -> p) <-> r)"; got.String() != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	f, names := Canonicalize(Parse("(y -> x) | !y"))
+	if want := "((p1 -> p2) | !p1)"; f.String() != want {
+		t.Errorf("got %v, want %v", f, want)
+	}
+	if want := map[string]string{"y": "p1", "x": "p2"}; !maps.Equal(names, want) {
+		t.Errorf("got %v, want %v", names, want)
+	}
+
+	g, _ := Canonicalize(Parse("(b -> a) | !b"))
+	if f != g {
+		t.Errorf("formulas that differ only by letter names have different canonical forms %v and %v", f, g)
+	}
+
+	h, _ := Canonicalize(Parse("(b -> a) | !a"))
+	if f == h {
+		t.Errorf("different formulas have the same canonical form %v", f)
+	}
+}
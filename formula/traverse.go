@@ -0,0 +1,197 @@
+package formula
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Visitor is implemented by types that compute a value of type T for each kind of formula.
+// The methods receive the formula itself, and they can call Visit on the operands to recur.
+type Visitor[T any] interface {
+	VisitLetter(l Letter) T
+	VisitTop(t Top) T
+	VisitBottom(b Bottom) T
+	VisitNot(n Not) T
+	VisitBinary(b Binary) T
+	VisitNAry(n NAry) T
+	VisitIte(i Ite) T
+	VisitCardinality(c Cardinality) T
+	VisitCustom(c Custom) T
+}
+
+// Visit calls the method of the visitor that corresponds to the type of the formula.
+func Visit[T any](formula Formula, v Visitor[T]) T {
+	switch f := plain(formula).(type) {
+	case Letter:
+		return v.VisitLetter(f)
+	case Top:
+		return v.VisitTop(f)
+	case Bottom:
+		return v.VisitBottom(f)
+	case Not:
+		return v.VisitNot(f)
+	case Binary:
+		return v.VisitBinary(f)
+	case NAry:
+		return v.VisitNAry(f)
+	case Ite:
+		return v.VisitIte(f)
+	case Cardinality:
+		return v.VisitCardinality(f)
+	case Custom:
+		return v.VisitCustom(f)
+	default:
+		panic(fmt.Errorf("%v: %T is not a Formula", f, f))
+	}
+}
+
+// Operands returns the direct subformulas of the formula: none for letters and constants, the negated formula for
+// a negation, the left and right sides for a binary formula, all the operands for an n-ary formula, a cardinality
+// constraint or a registered connective and the condition, the then and the else formulas for an if-then-else.
+func Operands(formula Formula) []Formula {
+	switch f := plain(formula).(type) {
+	case Not:
+		return []Formula{f.Negated()}
+	case Binary:
+		return []Formula{f.Left(), f.Right()}
+	case NAry:
+		return f.Operands()
+	case Ite:
+		return []Formula{f.Condition(), f.Then(), f.Else()}
+	case Cardinality:
+		return f.Operands()
+	case Custom:
+		return f.Operands()
+	default:
+		return nil
+	}
+}
+
+// Order is the order in which Subformulas visits a formula.
+type Order int
+
+const (
+	PreOrder  Order = iota // PreOrder visits a formula before its operands.
+	PostOrder              // PostOrder visits a formula after its operands.
+)
+
+// Subformulas returns an iterator over all the occurrences of the subformulas of the formula, including the formula
+// itself, in the given order. The operands are visited from left to right.
+func Subformulas(formula Formula, order Order) iter.Seq[Formula] {
+	return func(yield func(Formula) bool) {
+		subformulas(formula, order, yield)
+	}
+}
+
+func subformulas(formula Formula, order Order, yield func(Formula) bool) bool {
+	formula = plain(formula)
+	if order == PreOrder && !yield(formula) {
+		return false
+	}
+	for _, operand := range Operands(formula) {
+		if !subformulas(operand, order, yield) {
+			return false
+		}
+	}
+	return order == PreOrder || yield(formula)
+}
+
+// Letters returns the names of the letters of the formula without repetitions, in order of first occurrence from
+// left to right.
+func Letters(formula Formula) []string {
+	var res []string
+	seen := make(map[string]bool)
+
+	for f := range Subformulas(formula, PreOrder) {
+		if l, ok := f.(Letter); ok && !seen[l.Name()] {
+			seen[l.Name()] = true
+			res = append(res, l.Name())
+		}
+	}
+
+	return res
+}
+
+// Fold computes a value for the formula bottom-up: fn is called on every subformula with the values already
+// computed for its operands, in the order returned by Operands.
+func Fold[T any](formula Formula, fn func(f Formula, operands []T) T) T {
+	var values []T
+	for _, operand := range Operands(formula) {
+		values = append(values, Fold(operand, fn))
+	}
+	return fn(plain(formula), values)
+}
+
+// Transform rebuilds the formula bottom-up: fn is called on every subformula after its operands have been
+// transformed, and its result replaces the subformula.
+func Transform(formula Formula, fn func(Formula) Formula) Formula {
+	formula = plain(formula)
+	switch f := formula.(type) {
+	case Not:
+		return fn(NewNot(Transform(f.Negated(), fn)))
+	case Binary:
+		return fn(NewBinary(Transform(f.Left(), fn), Transform(f.Right(), fn), f.Op()))
+	case NAry:
+		operands := f.Operands()
+		for i, operand := range operands {
+			operands[i] = Transform(operand, fn)
+		}
+		return fn(NewNAry(f.Op(), operands...))
+	case Ite:
+		return fn(NewIte(Transform(f.Condition(), fn), Transform(f.Then(), fn), Transform(f.Else(), fn)))
+	case Cardinality:
+		operands := f.Operands()
+		for i, operand := range operands {
+			operands[i] = Transform(operand, fn)
+		}
+		return fn(NewCardinality(f.Kind(), f.Bound(), operands...))
+	case Custom:
+		operands := f.Operands()
+		for i, operand := range operands {
+			operands[i] = Transform(operand, fn)
+		}
+		return fn(f.with(operands))
+	default:
+		return fn(formula)
+	}
+}
+
+// Size returns the number of nodes of the formula, where letters, constants, negations, binary and n-ary operators,
+// if-then-else, cardinality constraints and registered connectives count as one node.
+func Size(formula Formula) int {
+	return Fold(formula, func(_ Formula, operands []int) int {
+		res := 1
+		for _, size := range operands {
+			res += size
+		}
+		return res
+	})
+}
+
+// Depth returns the number of nodes in the longest path from the root of the formula to a letter or a constant,
+// so the depth of a letter is 1.
+func Depth(formula Formula) int {
+	return Fold(formula, func(_ Formula, operands []int) int {
+		res := 0
+		for _, depth := range operands {
+			res = max(res, depth)
+		}
+		return res + 1
+	})
+}
+
+// OperatorCounts returns the number of occurrences of every binary operator in the formula, where an n-ary formula
+// counts as one occurrence of its operator. Operators that do not occur are not in the map, and if-then-else formulas,
+// cardinality constraints and registered connectives are not counted.
+func OperatorCounts(formula Formula) map[Operator]int {
+	res := make(map[Operator]int)
+	for f := range Subformulas(formula, PreOrder) {
+		switch f := f.(type) {
+		case Binary:
+			res[f.Op()]++
+		case NAry:
+			res[f.Op()]++
+		}
+	}
+	return res
+}
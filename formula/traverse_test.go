@@ -0,0 +1,220 @@
+package formula
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestLetters(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"p", []string{"p"}},
+		{"T", nil},
+		{"(q & p) -> !(q | r)", []string{"q", "p", "r"}},
+		{"p10 ^ p2 ^ p10", []string{"p10", "p2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Letters(Parse(tt.input)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Letters(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubformulas(t *testing.T) {
+	f := Parse("!(p & q) | p")
+
+	tests := []struct {
+		name  string
+		order Order
+		want  []string
+	}{
+		{"pre-order", PreOrder, []string{"(!(p & q) | p)", "!(p & q)", "(p & q)", "p", "q", "p"}},
+		{"post-order", PostOrder, []string{"p", "q", "(p & q)", "!(p & q)", "p", "(!(p & q) | p)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for sub := range Subformulas(f, tt.order) {
+				got = append(got, sub.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("break", func(t *testing.T) {
+		var got []Formula
+		for sub := range Subformulas(f, PostOrder) {
+			got = append(got, sub)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if want := []Formula{letters.p, letters.q}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		input     string
+		size      int
+		depth     int
+		operators map[Operator]int
+	}{
+		{"p", 1, 1, map[Operator]int{}},
+		{"!!p", 3, 3, map[Operator]int{}},
+		{"(p & q) | (p & !r)", 8, 4, map[Operator]int{And: 2, Or: 1}},
+		{"p -> q -> r <-> F", 7, 4, map[Operator]int{Implies: 2, Biconditional: 1}},
+		{"ite(p, q ^ r ^ s, p !-> q)", 9, 3, map[Operator]int{Xor: 1, NonImplies: 1}},
+		{"exactly(2; p, q & r, !s)", 7, 3, map[Operator]int{And: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			f := Parse(tt.input)
+			if got := Size(f); got != tt.size {
+				t.Errorf("Size(%v) = %v, want %v", f, got, tt.size)
+			}
+			if got := Depth(f); got != tt.depth {
+				t.Errorf("Depth(%v) = %v, want %v", f, got, tt.depth)
+			}
+			if got := OperatorCounts(f); !maps.Equal(got, tt.operators) {
+				t.Errorf("OperatorCounts(%v) = %v, want %v", f, got, tt.operators)
+			}
+		})
+	}
+}
+
+// printer is a Visitor that prints formulas in prefix notation.
+type printer struct{}
+
+func (p printer) VisitLetter(l Letter) string { return l.Name() }
+func (p printer) VisitTop(Top) string         { return "true" }
+func (p printer) VisitBottom(Bottom) string   { return "false" }
+func (p printer) VisitNot(n Not) string       { return "not(" + Visit[string](n.Negated(), p) + ")" }
+func (p printer) VisitBinary(b Binary) string {
+	return fmt.Sprintf("%v(%s, %s)", b.Op(), Visit[string](b.Left(), p), Visit[string](b.Right(), p))
+}
+func (p printer) VisitNAry(n NAry) string {
+	operands := make([]string, n.Len())
+	for i, operand := range n.Operands() {
+		operands[i] = Visit[string](operand, p)
+	}
+	return fmt.Sprintf("%v(%s)", n.Op(), strings.Join(operands, ", "))
+}
+func (p printer) VisitIte(i Ite) string {
+	return fmt.Sprintf("ite(%s, %s, %s)", Visit[string](i.Condition(), p), Visit[string](i.Then(), p),
+		Visit[string](i.Else(), p))
+}
+func (p printer) VisitCardinality(c Cardinality) string {
+	operands := make([]string, c.Len())
+	for i, operand := range c.Operands() {
+		operands[i] = Visit[string](operand, p)
+	}
+	return fmt.Sprintf("%v%d(%s)", c.Kind(), c.Bound(), strings.Join(operands, ", "))
+}
+func (p printer) VisitCustom(c Custom) string {
+	operands := make([]string, c.Len())
+	for i, operand := range c.Operands() {
+		operands[i] = Visit[string](operand, p)
+	}
+	return fmt.Sprintf("%s(%s)", c.Name(), strings.Join(operands, ", "))
+}
+
+func TestVisit(t *testing.T) {
+	f := Parse("!(p & T) -> q | r | ite(p, F, q <- r) | atmost(1; p, !q) | stroke(p, q)")
+	want := "->(not(&(p, true)), |(q, r, ite(p, false, <-(q, r)), atmost1(p, not(q)), stroke(p, q)))"
+	if got := Visit[string](f, printer{}); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFold(t *testing.T) {
+	// counts the letters of the formula, with repetitions.
+	count := func(f Formula, operands []int) int {
+		if _, ok := f.(Letter); ok {
+			return 1
+		}
+		res := 0
+		for _, n := range operands {
+			res += n
+		}
+		return res
+	}
+
+	if got := Fold(Parse("(p & q) | !(p -> T)"), count); got != 3 {
+		t.Errorf("got %v letters, want 3", got)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	// replaces every letter with its negation and removes the double negations.
+	fn := func(f Formula) Formula {
+		switch f := f.(type) {
+		case Letter:
+			return NewNot(f)
+		case Not:
+			if inner, ok := f.Negated().(Not); ok {
+				return inner.Negated()
+			}
+		}
+		return f
+	}
+
+	if got, want := Transform(Parse("!p & (q | T)"), fn).String(), "(p & (!q | ⊤))"; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// TestTraversal_Consistency checks that the traversal functions agree with each other on random formulas.
+func TestTraversal_Consistency(t *testing.T) {
+	f := func(f Formula) bool {
+		pre := slices.Collect(Subformulas(f, PreOrder))
+		post := slices.Collect(Subformulas(f, PostOrder))
+
+		if len(pre) != Size(f) || len(post) != Size(f) || pre[0] != f || post[len(post)-1] != f {
+			t.Errorf("%v: inconsistent subformulas %v and %v", f, pre, post)
+			return false
+		}
+
+		operators := 0
+		for _, n := range OperatorCounts(f) {
+			operators += n
+		}
+		if operators > Size(f)/2 || Depth(f) > Size(f) {
+			t.Errorf("%v: %d operators, size %d, depth %d", f, operators, Size(f), Depth(f))
+			return false
+		}
+
+		if identity := Transform(f, func(f Formula) Formula { return f }); identity != f {
+			t.Errorf("%v: identity transform returned %v", f, identity)
+			return false
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(generateRandomNAry(r, r.Intn(50)))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
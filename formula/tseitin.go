@@ -0,0 +1,276 @@
+package formula
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FreshLetterPrefix is the prefix of the names of the letters introduced by the definitional encodings.
+// If a formula already contains a letter with the same name, the fresh letter skips it.
+const FreshLetterPrefix = "_d"
+
+// Encoding is a clause set produced by a definitional encoding of a formula. The clause set is equisatisfiable
+// with the formula, and it is a conjunction of clauses, where every clause is a disjunction of literals, like ToCNF.
+type Encoding struct {
+	Clauses [][]Literal
+	// Definitions maps the name of every fresh letter to the subformula that it stands for.
+	Definitions map[string]Formula
+}
+
+// Project returns a copy of the assignment without the fresh letters, so that a model of the clauses becomes a
+// model of the original formula.
+func (e Encoding) Project(assignment map[string]bool) map[string]bool {
+	res := make(map[string]bool, len(assignment))
+	for name, value := range assignment {
+		if _, ok := e.Definitions[name]; !ok {
+			res[name] = value
+		}
+	}
+	return res
+}
+
+// polarity is a bitmask of the polarities of the occurrences of a subformula.
+type polarity byte
+
+const (
+	positive polarity = 1 << iota
+	negative
+	both = positive | negative
+)
+
+func (p polarity) flip() polarity {
+	return p&positive<<1 | p&negative>>1
+}
+
+// Tseitin returns the Tseitin encoding of the formula: every subformula that is neither a literal nor a negation
+// is replaced by a fresh letter x, with the clauses of x <-> subformula. Negations are encoded with the complement
+// of the literal of their operand and repeated subformulas share the same letter, so the encoding is linear in the
+// size of the formula. An n-ary exclusive disjunction is encoded as the equivalent chain of binary ones, since its
+// definition would have exponentially many clauses, and a cardinality constraint is encoded as the if-then-else of its
+// Branch, whose constraints on the remaining operands are shared, so that its size is O(n·k). A registered connective
+// is encoded as its Definition. The models of the clauses, projected on the original letters, are exactly the models
+// of the formula.
+func Tseitin(formula Formula) Encoding {
+	return definitional(formula, false)
+}
+
+// PlaistedGreenbaum returns the Plaisted–Greenbaum encoding of the formula: like Tseitin, but only the direction
+// of x <-> subformula required by the polarity of the subformula is encoded, so x -> subformula for subformulas
+// that occur only positively and subformula -> x for the ones that occur only negatively. The clause set is
+// smaller and still equisatisfiable with the formula, and its models projected on the original letters are models
+// of the formula, but not every model of the formula can be extended to a model of the clauses.
+func PlaistedGreenbaum(formula Formula) Encoding {
+	return definitional(formula, true)
+}
+
+// encoder holds the state of a definitional encoding.
+type encoder struct {
+	Encoding
+	polarities map[Formula]polarity
+	literals   map[Formula]Literal
+	used       map[string]bool // used contains the names of the letters of the formula and the fresh letters.
+	next       int
+}
+
+func definitional(formula Formula, polarityAware bool) Encoding {
+	e := &encoder{
+		Encoding:   Encoding{Clauses: [][]Literal{}, Definitions: make(map[string]Formula)},
+		polarities: make(map[Formula]polarity),
+		literals:   make(map[Formula]Literal),
+		used:       make(map[string]bool),
+	}
+	e.collect(formula, positive, polarityAware)
+
+	root := e.encode(formula)
+	e.Clauses = append(e.Clauses, []Literal{root})
+
+	return e.Encoding
+}
+
+// collect records the names of the letters and the polarities of the subformulas. If polarityAware is false every
+// subformula has both polarities.
+func (e *encoder) collect(formula Formula, pol polarity, polarityAware bool) {
+	formula = plain(formula)
+	if !polarityAware {
+		pol = both
+	}
+	if e.polarities[formula]&pol == pol {
+		return // already visited with these polarities
+	}
+	e.polarities[formula] |= pol
+
+	switch f := formula.(type) {
+	case Letter:
+		e.used[f.Name()] = true
+	case Not:
+		e.collect(f.Negated(), pol.flip(), polarityAware)
+	case Binary:
+		switch f.Op() {
+		case And, Or:
+			e.collect(f.Left(), pol, polarityAware)
+			e.collect(f.Right(), pol, polarityAware)
+		case Implies:
+			e.collect(f.Left(), pol.flip(), polarityAware)
+			e.collect(f.Right(), pol, polarityAware)
+		case Nand, Nor:
+			e.collect(f.Left(), pol.flip(), polarityAware)
+			e.collect(f.Right(), pol.flip(), polarityAware)
+		case ConverseImplies, NonImplies:
+			e.collect(f.Left(), pol, polarityAware)
+			e.collect(f.Right(), pol.flip(), polarityAware)
+		case Biconditional, Xor:
+			e.collect(f.Left(), both, polarityAware)
+			e.collect(f.Right(), both, polarityAware)
+		}
+	case NAry:
+		if f.Op() == Xor {
+			e.collect(f.binary(), pol, polarityAware)
+			return
+		}
+		for _, operand := range f.Operands() {
+			e.collect(operand, pol, polarityAware)
+		}
+	case Ite:
+		e.collect(f.Condition(), both, polarityAware)
+		e.collect(f.Then(), pol, polarityAware)
+		e.collect(f.Else(), pol, polarityAware)
+	case Cardinality:
+		e.collect(f.Branch(), pol, polarityAware)
+	case Custom:
+		e.collect(f.Definition(), pol, polarityAware)
+	}
+}
+
+// fresh returns a new letter that is not used in the formula.
+func (e *encoder) fresh() Literal {
+	for {
+		e.next++
+		name := FreshLetterPrefix + strconv.Itoa(e.next)
+		if !e.used[name] {
+			e.used[name] = true
+			return Literal{Name: name}
+		}
+	}
+}
+
+// encode returns the literal that stands for the formula, adding the definitions of its subformulas.
+func (e *encoder) encode(formula Formula) Literal {
+	formula = plain(formula)
+	if lit, ok := e.literals[formula]; ok {
+		return lit
+	}
+
+	var x Literal
+	var clauses [][]Literal
+
+	switch f := formula.(type) {
+	case Letter:
+		x = Literal{Name: f.Name()}
+	case Not:
+		x = e.encode(f.Negated())
+		x.Neg = !x.Neg
+	case Top:
+		x = e.fresh()
+		clauses = [][]Literal{{x}}
+	case Bottom:
+		x = e.fresh()
+		clauses = [][]Literal{{neg(x)}}
+	case Binary:
+		a, b := e.encode(f.Left()), e.encode(f.Right())
+		x = e.fresh()
+		clauses = definitionClauses(x, a, b, f.Op())
+	case NAry:
+		if f.Op() == Xor {
+			x = e.encode(f.binary())
+			break
+		}
+		operands := make([]Literal, f.Len())
+		for i, operand := range f.Operands() {
+			operands[i] = e.encode(operand)
+		}
+		x = e.fresh()
+		clauses = naryDefinitionClauses(x, operands, f.Op())
+	case Ite:
+		c, a, b := e.encode(f.Condition()), e.encode(f.Then()), e.encode(f.Else())
+		x = e.fresh()
+		clauses = [][]Literal{{neg(x), neg(c), a}, {neg(x), c, b}, {x, neg(c), neg(a)}, {x, c, neg(b)}}
+	case Cardinality:
+		x = e.encode(f.Branch())
+	case Custom:
+		x = e.encode(f.Definition())
+	default:
+		panic(fmt.Errorf("%v: %T is not a Formula", f, f))
+	}
+
+	if clauses != nil {
+		e.Definitions[x.Name] = formula
+		pol := e.polarities[formula]
+		for _, clause := range clauses {
+			// the clauses containing !x encode x -> formula, the ones containing x encode formula -> x.
+			if pol&positive != 0 && containsLiteral(clause, neg(x)) || pol&negative != 0 && containsLiteral(clause, x) {
+				e.Clauses = append(e.Clauses, clause)
+			}
+		}
+	}
+
+	e.literals[formula] = x
+	return x
+}
+
+// definitionClauses returns the clauses of x <-> (a op b).
+func definitionClauses(x, a, b Literal, op Operator) [][]Literal {
+	switch op {
+	case And:
+		return [][]Literal{{neg(x), a}, {neg(x), b}, {x, neg(a), neg(b)}}
+	case Or:
+		return [][]Literal{{neg(x), a, b}, {x, neg(a)}, {x, neg(b)}}
+	case Implies:
+		return [][]Literal{{neg(x), neg(a), b}, {x, a}, {x, neg(b)}}
+	case Nand:
+		return [][]Literal{{neg(x), neg(a), neg(b)}, {x, a}, {x, b}}
+	case Nor:
+		return [][]Literal{{neg(x), neg(a)}, {neg(x), neg(b)}, {x, a, b}}
+	case Biconditional:
+		return [][]Literal{{neg(x), neg(a), b}, {neg(x), a, neg(b)}, {x, a, b}, {x, neg(a), neg(b)}}
+	case Xor:
+		return [][]Literal{{neg(x), a, b}, {neg(x), neg(a), neg(b)}, {x, neg(a), b}, {x, a, neg(b)}}
+	case ConverseImplies:
+		return [][]Literal{{neg(x), a, neg(b)}, {x, neg(a)}, {x, b}}
+	case NonImplies:
+		return [][]Literal{{neg(x), a}, {neg(x), neg(b)}, {x, neg(a), b}}
+	default:
+		panic(fmt.Errorf("unknown operator %v", op))
+	}
+}
+
+// naryDefinitionClauses returns the clauses of x <-> (a1 op ... op an), where op is And or Or.
+func naryDefinitionClauses(x Literal, operands []Literal, op Operator) [][]Literal {
+	// the definition of a disjunction is the one of the conjunction of the complements, with x complemented.
+	if op == Or {
+		x = neg(x)
+	}
+	long := []Literal{x}
+	var res [][]Literal
+	for _, a := range operands {
+		if op == Or {
+			a = neg(a)
+		}
+		res = append(res, []Literal{neg(x), a})
+		long = append(long, neg(a))
+	}
+	return append(res, long)
+}
+
+// neg returns the complement of the literal.
+func neg(lit Literal) Literal {
+	return Literal{Name: lit.Name, Neg: !lit.Neg}
+}
+
+func containsLiteral(clause []Literal, lit Literal) bool {
+	for _, l := range clause {
+		if l == lit {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package formula
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// clauseLetters returns the names of the letters of the clauses, in order of first occurrence.
+func clauseLetters(clauses [][]Literal) []string {
+	var res []string
+	for _, clause := range clauses {
+		for _, lit := range clause {
+			if !slices.Contains(res, lit.Name) {
+				res = append(res, lit.Name)
+			}
+		}
+	}
+	return res
+}
+
+// extend assigns to every fresh letter of the encoding the value of the subformula it stands for.
+func extend(e Encoding, assignment map[string]bool) map[string]bool {
+	res := make(map[string]bool, len(assignment)+len(e.Definitions))
+	for name, value := range assignment {
+		res[name] = value
+	}
+	for name, f := range e.Definitions {
+		res[name], _ = Eval(f, assignment)
+	}
+	return res
+}
+
+func TestTseitin(t *testing.T) {
+	x, y := Literal{Name: FreshLetterPrefix + "1"}, Literal{Name: FreshLetterPrefix + "2"}
+	p, q, r := Literal{Name: "p"}, Literal{Name: "q"}, Literal{Name: "r"}
+
+	tests := []struct {
+		name     string
+		encode   func(Formula) Encoding
+		input    Formula
+		want     [][]Literal
+		wantDefs map[string]Formula
+	}{
+		{
+			name:     "letter",
+			encode:   Tseitin,
+			input:    letters.p,
+			want:     [][]Literal{{p}},
+			wantDefs: map[string]Formula{},
+		},
+		{
+			name:     "negated letter",
+			encode:   Tseitin,
+			input:    NewNot(letters.p),
+			want:     [][]Literal{{neg(p)}},
+			wantDefs: map[string]Formula{},
+		},
+		{
+			name:     "and",
+			encode:   Tseitin,
+			input:    NewAnd(letters.p, letters.q),
+			want:     [][]Literal{{neg(x), p}, {neg(x), q}, {x, neg(p), neg(q)}, {x}},
+			wantDefs: map[string]Formula{x.Name: NewAnd(letters.p, letters.q)},
+		},
+		{
+			name:     "bottom",
+			encode:   Tseitin,
+			input:    NewBottom(),
+			want:     [][]Literal{{neg(x)}, {x}},
+			wantDefs: map[string]Formula{x.Name: NewBottom()},
+		},
+		{
+			name:     "positive top",
+			encode:   PlaistedGreenbaum,
+			input:    NewOr(letters.p, NewTop()),
+			want:     [][]Literal{{neg(y), p, x}, {y}},
+			wantDefs: map[string]Formula{x.Name: NewTop(), y.Name: NewOr(letters.p, NewTop())},
+		},
+		{
+			name:     "positive and",
+			encode:   PlaistedGreenbaum,
+			input:    NewAnd(letters.p, letters.q),
+			want:     [][]Literal{{neg(x), p}, {neg(x), q}, {x}},
+			wantDefs: map[string]Formula{x.Name: NewAnd(letters.p, letters.q)},
+		},
+		{
+			name:     "negative and",
+			encode:   PlaistedGreenbaum,
+			input:    NewNot(NewAnd(letters.p, letters.q)),
+			want:     [][]Literal{{x, neg(p), neg(q)}, {neg(x)}},
+			wantDefs: map[string]Formula{x.Name: NewAnd(letters.p, letters.q)},
+		},
+		{
+			name:     "n-ary or",
+			encode:   Tseitin,
+			input:    NewNAry(Or, letters.p, letters.q, letters.r),
+			want:     [][]Literal{{x, neg(p)}, {x, neg(q)}, {x, neg(r)}, {neg(x), p, q, r}, {x}},
+			wantDefs: map[string]Formula{x.Name: NewNAry(Or, letters.p, letters.q, letters.r)},
+		},
+		{
+			name:     "negative n-ary and",
+			encode:   PlaistedGreenbaum,
+			input:    NewNot(NewNAry(And, letters.p, letters.q, letters.r)),
+			want:     [][]Literal{{x, neg(p), neg(q), neg(r)}, {neg(x)}},
+			wantDefs: map[string]Formula{x.Name: NewNAry(And, letters.p, letters.q, letters.r)},
+		},
+		{
+			name:     "positive if-then-else",
+			encode:   PlaistedGreenbaum,
+			input:    NewIte(letters.p, letters.q, letters.r),
+			want:     [][]Literal{{neg(x), neg(p), q}, {neg(x), p, r}, {x}},
+			wantDefs: map[string]Formula{x.Name: NewIte(letters.p, letters.q, letters.r)},
+		},
+		{
+			name:     "positive biconditional",
+			encode:   PlaistedGreenbaum,
+			input:    NewBiconditional(letters.p, letters.q),
+			want:     [][]Literal{{neg(x), neg(p), q}, {neg(x), p, neg(q)}, {x}},
+			wantDefs: map[string]Formula{x.Name: NewBiconditional(letters.p, letters.q)},
+		},
+		{
+			name:   "operands of a biconditional have both polarities",
+			encode: PlaistedGreenbaum,
+			input:  NewBiconditional(NewNot(letters.p), NewAnd(letters.p, letters.q)),
+			want: [][]Literal{
+				{neg(x), p}, {neg(x), q}, {x, neg(p), neg(q)},
+				{neg(y), p, x}, {neg(y), neg(p), neg(x)}, {y},
+			},
+			wantDefs: map[string]Formula{
+				x.Name: NewAnd(letters.p, letters.q),
+				y.Name: NewBiconditional(NewNot(letters.p), NewAnd(letters.p, letters.q)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.encode(tt.input)
+			if !reflect.DeepEqual(got.Clauses, tt.want) {
+				t.Errorf("clauses = %v, want %v", got.Clauses, tt.want)
+			}
+			if !reflect.DeepEqual(got.Definitions, tt.wantDefs) {
+				t.Errorf("definitions = %v, want %v", got.Definitions, tt.wantDefs)
+			}
+		})
+	}
+}
+
+func TestTseitin_FreshLetters(t *testing.T) {
+	clash := NewLetter(FreshLetterPrefix + "1")
+	f := NewOr(NewAnd(clash, letters.p), NewAnd(clash, letters.p))
+
+	e := Tseitin(f)
+	if _, ok := e.Definitions[clash.Name()]; ok {
+		t.Errorf("fresh letter %v clashes with a letter of %v", clash, f)
+	}
+
+	// the repeated subformula (_d1 & p) is encoded once, so there are two fresh letters.
+	if len(e.Definitions) != 2 {
+		t.Errorf("expected 2 fresh letters, got %v", e.Definitions)
+	}
+}
+
+// TestDefinitionalEncodings_Equisatisfiable checks the models of the encodings against the models of the formula.
+func TestDefinitionalEncodings_Equisatisfiable(t *testing.T) {
+	f := func(f Formula) bool {
+		tseitin, pg := Tseitin(f), PlaistedGreenbaum(f)
+
+		// every model of the formula extended with the definitions is a model of both encodings, and the Tseitin
+		// encoding is false under the extension of every other assignment.
+		for _, a := range assignments(f) {
+			want, _ := Eval(f, a)
+			if evalClauses(tseitin.Clauses, true, extend(tseitin, a)) != want {
+				t.Errorf("%v: Tseitin encoding differs under %v", f, a)
+				return false
+			}
+			if want && !evalClauses(pg.Clauses, true, extend(pg, a)) {
+				t.Errorf("%v: Plaisted-Greenbaum encoding is false under %v", f, a)
+				return false
+			}
+		}
+
+		// every model of the encodings projected on the letters of the formula is a model of the formula.
+		for _, e := range []Encoding{tseitin, pg} {
+			names := clauseLetters(e.Clauses)
+			for mask := 0; mask < 1<<len(names); mask++ {
+				a := make(map[string]bool, len(names))
+				for i, name := range names {
+					a[name] = mask&(1<<i) != 0
+				}
+				if !evalClauses(e.Clauses, true, a) {
+					continue
+				}
+				if ok, err := Eval(f, e.Project(a)); !ok || err != nil {
+					t.Errorf("%v: projected model %v is not a model (%v)", f, e.Project(a), err)
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(generateRandomNAry(r, r.Intn(8)))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,6 +1,9 @@
 package testutil
 
-import "github.com/francodesource/propositional_tableaux/formula"
+import (
+	"github.com/francodesource/propositional_tableaux/formula"
+	"math/rand"
+)
 
 /*
    Predefined letters for testing
@@ -30,3 +33,29 @@ var T = formula.NewLetter("T")
 var T1 = formula.NewLetter("T1")
 var T2 = formula.NewLetter("T2")
 var T3 = formula.NewLetter("T3")
+
+// GenerateRandomNAry generates a random formula like formula.GenerateRandom, where half of the times the chains of &
+// and | are n-ary formulas as the parser reads them.
+func GenerateRandomNAry(r *rand.Rand, size int) formula.Formula {
+	f := formula.GenerateRandom(r, size)
+	if r.Intn(2) == 0 {
+		return f
+	}
+	return formula.Transform(f, func(f formula.Formula) formula.Formula {
+		b, ok := f.(formula.Binary)
+		if !ok || b.Op() != formula.And && b.Op() != formula.Or {
+			return f
+		}
+		var operands []formula.Formula
+		for _, operand := range formula.Operands(b) {
+			if n, ok := operand.(formula.NAry); ok && n.Op() == b.Op() {
+				operands = append(operands, n.Operands()...)
+			} else if o, ok := operand.(formula.Binary); ok && o.Op() == b.Op() {
+				operands = append(operands, o.Left(), o.Right())
+			} else {
+				operands = append(operands, operand)
+			}
+		}
+		return formula.NewNAry(b.Op(), operands...)
+	})
+}
@@ -0,0 +1,161 @@
+package smtlib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sexpr is an S-expression: an atom or a list of S-expressions.
+type sexpr struct {
+	atom         string   // atom is a symbol, keyword, numeral or string literal, without quotes.
+	kind         atomKind // kind is the kind of the atom.
+	list         []sexpr  // list contains the elements of a list.
+	isList       bool
+	line, column int
+}
+
+type atomKind int
+
+const (
+	symbol  atomKind = iota // symbol is a simple or quoted symbol, like p or |p q|.
+	keyword                 // keyword is a keyword like :named.
+	numeral                 // numeral is a numeral or a decimal, like 1 or 1.5.
+	str                     // str is a string literal, without the quotes.
+)
+
+func (e sexpr) String() string {
+	if !e.isList {
+		if e.kind == str {
+			return `"` + strings.ReplaceAll(e.atom, `"`, `""`) + `"`
+		}
+		return e.atom
+	}
+	parts := make([]string, len(e.list))
+	for i, x := range e.list {
+		parts[i] = x.String()
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+// is reports whether the S-expression is the given unquoted symbol.
+func (e sexpr) is(name string) bool {
+	return !e.isList && e.kind == symbol && e.atom == name
+}
+
+// parseSexprs parses all the S-expressions of the input, skipping whitespace and ; comments.
+func parseSexprs(input string) ([]sexpr, error) {
+	p := &sexprParser{input: input, line: 1}
+	var res []sexpr
+	for {
+		p.skipSpace()
+		if p.pos == len(p.input) {
+			return res, nil
+		}
+		e, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+}
+
+type sexprParser struct {
+	input           string
+	pos             int
+	line, lineStart int
+}
+
+func (p *sexprParser) errorf(line, column int, format string, args ...any) error {
+	return &SyntaxError{Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// advance moves forward of n bytes, keeping track of the lines.
+func (p *sexprParser) advance(n int) {
+	for _, c := range []byte(p.input[p.pos : p.pos+n]) {
+		p.pos++
+		if c == '\n' {
+			p.line, p.lineStart = p.line+1, p.pos
+		}
+	}
+}
+
+func (p *sexprParser) skipSpace() {
+	for p.pos < len(p.input) {
+		switch c := p.input[p.pos]; {
+		case c == ';':
+			end := strings.IndexByte(p.input[p.pos:], '\n')
+			if end < 0 {
+				end = len(p.input) - p.pos
+			}
+			p.advance(end)
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.advance(1)
+		default:
+			return
+		}
+	}
+}
+
+// parse parses an S-expression starting at the current position, which is not a space.
+func (p *sexprParser) parse() (sexpr, error) {
+	line, column := p.line, p.pos-p.lineStart
+	rest := p.input[p.pos:]
+
+	switch rest[0] {
+	case '(':
+		p.advance(1)
+		e := sexpr{isList: true, list: []sexpr{}, line: line, column: column}
+		for {
+			p.skipSpace()
+			if p.pos == len(p.input) {
+				return sexpr{}, p.errorf(line, column, "unclosed parenthesis")
+			}
+			if p.input[p.pos] == ')' {
+				p.advance(1)
+				return e, nil
+			}
+			x, err := p.parse()
+			if err != nil {
+				return sexpr{}, err
+			}
+			e.list = append(e.list, x)
+		}
+	case ')':
+		return sexpr{}, p.errorf(line, column, "unexpected )")
+	case '|':
+		end := strings.IndexAny(rest[1:], `|\`)
+		if end < 0 || rest[1+end] != '|' {
+			return sexpr{}, p.errorf(line, column, "unterminated quoted symbol")
+		}
+		p.advance(end + 2)
+		return sexpr{atom: rest[1 : 1+end], kind: symbol, line: line, column: column}, nil
+	case '"':
+		var sb strings.Builder
+		for i := 1; i < len(rest); i++ {
+			if rest[i] != '"' {
+				sb.WriteByte(rest[i])
+			} else if i+1 < len(rest) && rest[i+1] == '"' {
+				sb.WriteByte('"')
+				i++
+			} else {
+				p.advance(i + 1)
+				return sexpr{atom: sb.String(), kind: str, line: line, column: column}, nil
+			}
+		}
+		return sexpr{}, p.errorf(line, column, "unterminated string literal")
+	}
+
+	end := strings.IndexAny(rest, " \t\r\n()|\";")
+	if end < 0 {
+		end = len(rest)
+	}
+	p.advance(end)
+	e := sexpr{atom: rest[:end], kind: symbol, line: line, column: column}
+	switch {
+	case strings.HasPrefix(e.atom, ":"):
+		e.kind = keyword
+	case e.atom[0] >= '0' && e.atom[0] <= '9':
+		e.kind = numeral
+	}
+	return e, nil
+}
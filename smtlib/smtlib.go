@@ -0,0 +1,464 @@
+// Package smtlib reads and writes scripts of the Boolean fragment of SMT-LIB 2, the language of SMT solvers:
+//
+//	(set-logic QF_UF)
+//	(declare-const p Bool)
+//	(declare-const q Bool)
+//	(assert (=> p q))
+//	(assert (let ((r (not q))) (and p r)))
+//	(check-sat)
+//	(get-model)
+//
+// The constants are Boolean, declared with declare-const or declare-fun without arguments, and become letters.
+// The terms can use true, false, not, and, or, xor, =>, =, distinct, ite, let and the ! annotations, and define-fun
+// without arguments defines a name for a term. A Script is run with the buffered analytic tableaux, printing sat or
+// unsat for every check-sat and the model of the last satisfiable check-sat for get-model.
+package smtlib
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/francodesource/propositional_tableaux/formula"
+	"github.com/francodesource/propositional_tableaux/tableaux"
+	"io"
+	"strings"
+)
+
+// SyntaxError is the error returned by Read when the script is malformed or is not in the Boolean fragment.
+type SyntaxError struct {
+	Line   int    // Line is the line of the error, starting from 1.
+	Column int    // Column is the position of the error in its line in bytes, starting from 0.
+	Msg    string // Msg describes the error.
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("smtlib: line %d:%d %s", e.Line, e.Column, e.Msg)
+}
+
+func errorf(e sexpr, format string, args ...any) error {
+	return &SyntaxError{Line: e.line, Column: e.column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Command is a command of a script that affects its output.
+type Command struct {
+	Name    string          // Name is declare-const, assert, check-sat, get-model or exit.
+	Symbol  string          // Symbol is the constant declared by declare-const.
+	Formula formula.Formula // Formula is the formula asserted by assert.
+}
+
+// Script is an SMT-LIB script. Commands that do not affect the output, like set-logic and set-info, are not kept,
+// declare-fun is kept as declare-const and the names defined with define-fun are replaced by their formulas.
+type Script struct {
+	Commands []Command
+}
+
+// Read reads a script. It returns a *SyntaxError if the script is malformed, if it uses sorts other than Bool,
+// functions with arguments or quantifiers, or if it refers to undeclared constants.
+func Read(r io.Reader) (*Script, error) {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	exprs, err := parseSexprs(string(input))
+	if err != nil {
+		return nil, err
+	}
+
+	rd := &reader{declared: make(map[string]bool), defined: make(map[string]formula.Formula)}
+	s := &Script{}
+	for _, e := range exprs {
+		c, err := rd.command(e)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			s.Commands = append(s.Commands, *c)
+		}
+	}
+	return s, nil
+}
+
+// reader holds the constants declared and the names defined so far.
+type reader struct {
+	declared map[string]bool
+	defined  map[string]formula.Formula
+}
+
+// command reads a command, returning nil for the commands that are not kept in the Script.
+func (r *reader) command(e sexpr) (*Command, error) {
+	if !e.isList || len(e.list) == 0 || e.list[0].isList || e.list[0].kind != symbol {
+		return nil, errorf(e, "expected a command, found %v", e)
+	}
+	name, args := e.list[0].atom, e.list[1:]
+	arity := func(n int) error {
+		if len(args) != n {
+			return errorf(e, "%s expects %d arguments, found %d", name, n, len(args))
+		}
+		return nil
+	}
+
+	switch name {
+	case "set-logic", "set-info", "set-option", "get-info", "get-option":
+		return nil, nil
+	case "declare-const", "declare-fun", "define-fun":
+		var params, sort sexpr
+		if name == "declare-const" {
+			if err := arity(2); err != nil {
+				return nil, err
+			}
+			params, sort = sexpr{isList: true}, args[1]
+		} else if name == "declare-fun" {
+			if err := arity(3); err != nil {
+				return nil, err
+			}
+			params, sort = args[1], args[2]
+		} else {
+			if err := arity(4); err != nil {
+				return nil, err
+			}
+			params, sort = args[1], args[2]
+		}
+
+		sym := args[0]
+		if sym.isList || sym.kind != symbol {
+			return nil, errorf(sym, "expected a symbol, found %v", sym)
+		}
+		if !params.isList || len(params.list) > 0 {
+			return nil, errorf(params, "only constants are supported, found %s with arguments", sym.atom)
+		}
+		if !sort.is("Bool") {
+			return nil, errorf(sort, "only Bool constants are supported, found %v", sort)
+		}
+		if r.declared[sym.atom] || r.defined[sym.atom] != nil || coreSymbols[sym.atom] {
+			return nil, errorf(sym, "%s is already declared", sym.atom)
+		}
+
+		if name == "define-fun" {
+			f, err := r.term(args[3], nil)
+			if err != nil {
+				return nil, err
+			}
+			r.defined[sym.atom] = f
+			return nil, nil
+		}
+		r.declared[sym.atom] = true
+		return &Command{Name: "declare-const", Symbol: sym.atom}, nil
+	case "assert":
+		if err := arity(1); err != nil {
+			return nil, err
+		}
+		f, err := r.term(args[0], nil)
+		if err != nil {
+			return nil, err
+		}
+		return &Command{Name: name, Formula: f}, nil
+	case "check-sat", "get-model", "exit":
+		if err := arity(0); err != nil {
+			return nil, err
+		}
+		return &Command{Name: name}, nil
+	default:
+		return nil, errorf(e.list[0], "unsupported command %s", name)
+	}
+}
+
+// coreSymbols are the symbols of the Core theory, which cannot be declared.
+var coreSymbols = map[string]bool{
+	"true": true, "false": true, "not": true, "and": true, "or": true, "xor": true, "=>": true, "=": true,
+	"distinct": true, "ite": true,
+}
+
+// term reads a Boolean term, where bound contains the names bound by the enclosing let terms.
+func (r *reader) term(e sexpr, bound map[string]formula.Formula) (formula.Formula, error) {
+	if !e.isList {
+		if e.kind != symbol {
+			return nil, errorf(e, "expected a Boolean term, found %v", e)
+		}
+		if f, ok := bound[e.atom]; ok {
+			return f, nil
+		}
+		switch {
+		case e.atom == "true":
+			return formula.NewTop(), nil
+		case e.atom == "false":
+			return formula.NewBottom(), nil
+		case r.defined[e.atom] != nil:
+			return r.defined[e.atom], nil
+		case r.declared[e.atom]:
+			return formula.NewLetter(e.atom), nil
+		}
+		return nil, errorf(e, "unknown constant %s", e.atom)
+	}
+
+	if len(e.list) == 0 || e.list[0].isList || e.list[0].kind != symbol {
+		return nil, errorf(e, "expected a Boolean term, found %v", e)
+	}
+	head, args := e.list[0].atom, e.list[1:]
+
+	switch head {
+	case "!":
+		if len(args) == 0 {
+			return nil, errorf(e, "! expects a term and its attributes")
+		}
+		return r.term(args[0], bound)
+	case "let":
+		return r.let(e, bound)
+	case "forall", "exists":
+		return nil, errorf(e, "quantifiers are not supported")
+	}
+
+	operands := make([]formula.Formula, len(args))
+	for i, arg := range args {
+		f, err := r.term(arg, bound)
+		if err != nil {
+			return nil, err
+		}
+		operands[i] = f
+	}
+	atLeast := func(n int) error {
+		if len(operands) < n {
+			return errorf(e, "%s expects at least %d arguments, found %d", head, n, len(operands))
+		}
+		return nil
+	}
+
+	switch head {
+	case "not":
+		if len(operands) != 1 {
+			return nil, errorf(e, "not expects 1 argument, found %d", len(operands))
+		}
+		return formula.NewNot(operands[0]), nil
+	case "and", "or":
+		// like most solvers, (and) is true, (or) is false and a single argument is the argument itself.
+		if head == "and" {
+			return formula.Conjunction(operands...), nil
+		}
+		return formula.Disjunction(operands...), nil
+	case "xor":
+		if err := atLeast(2); err != nil {
+			return nil, err
+		}
+		return formula.Parity(operands...), nil
+	case "=>":
+		if err := atLeast(2); err != nil {
+			return nil, err
+		}
+		res := operands[len(operands)-1]
+		for i := len(operands) - 2; i >= 0; i-- {
+			res = formula.NewImplies(operands[i], res)
+		}
+		return res, nil
+	case "=", "distinct":
+		if err := atLeast(2); err != nil {
+			return nil, err
+		}
+		// = is chainable, while distinct is pairwise.
+		var pairs []formula.Formula
+		for i := range operands {
+			if head == "=" && i > 0 {
+				pairs = append(pairs, formula.NewBiconditional(operands[i-1], operands[i]))
+			}
+			for j := i + 1; head == "distinct" && j < len(operands); j++ {
+				pairs = append(pairs, formula.NewXor(operands[i], operands[j]))
+			}
+		}
+		return formula.Conjunction(pairs...), nil
+	case "ite":
+		if len(operands) != 3 {
+			return nil, errorf(e, "ite expects 3 arguments, found %d", len(operands))
+		}
+		return formula.NewIte(operands[0], operands[1], operands[2]), nil
+	}
+	return nil, errorf(e.list[0], "unknown function %s", head)
+}
+
+// let reads a let term. The bindings are parallel: their terms are read in the enclosing scope.
+func (r *reader) let(e sexpr, bound map[string]formula.Formula) (formula.Formula, error) {
+	if len(e.list) != 3 || !e.list[1].isList || len(e.list[1].list) == 0 {
+		return nil, errorf(e, "let expects a list of bindings and a term")
+	}
+
+	inner := make(map[string]formula.Formula, len(bound)+len(e.list[1].list))
+	for name, f := range bound {
+		inner[name] = f
+	}
+	seen := make(map[string]bool)
+	for _, b := range e.list[1].list {
+		if !b.isList || len(b.list) != 2 || b.list[0].isList || b.list[0].kind != symbol {
+			return nil, errorf(b, "expected a binding (name term), found %v", b)
+		}
+		name := b.list[0].atom
+		if seen[name] {
+			return nil, errorf(b, "%s is bound twice", name)
+		}
+		seen[name] = true
+		f, err := r.term(b.list[1], bound)
+		if err != nil {
+			return nil, err
+		}
+		inner[name] = f
+	}
+	return r.term(e.list[2], inner)
+}
+
+// Run runs the script, writing the responses to w: sat or unsat for every check-sat, and the values of the
+// constants declared so far in the model found by the last check-sat for get-model, like
+//
+//	(model
+//	  (define-fun p () Bool true)
+//	  (define-fun q () Bool false)
+//	)
+//
+// The satisfiability of the conjunction of the assertions is decided with the buffered analytic tableaux, built with
+// the given options, and the constants that the tableau does not assign are false in the model. If there is no model,
+// like after an unsat check-sat, get-model writes an error response as SMT solvers do. The script stops at exit, and
+// at a check-sat whose construction is stopped by the options: Run writes unknown and returns the error of
+// tableaux.BuildBufferTableaux.
+func (s *Script) Run(w io.Writer, opts ...tableaux.Option) error {
+	bw := bufio.NewWriter(w)
+	var declared []string
+	var assertions []formula.Formula
+	var model tableaux.Assignment
+	hasModel := false
+
+	for _, c := range s.Commands {
+		switch c.Name {
+		case "declare-const":
+			declared = append(declared, c.Symbol)
+		case "assert":
+			assertions = append(assertions, c.Formula)
+		case "check-sat":
+			var f formula.Formula = formula.NewTop()
+			for i, a := range assertions {
+				if i == 0 {
+					f = a
+				} else {
+					f = formula.NewAnd(f, a)
+				}
+			}
+			tab, err := tableaux.BuildBufferTableaux(f, opts...)
+			if err != nil {
+				bw.WriteString("unknown\n")
+				if flushErr := bw.Flush(); flushErr != nil {
+					return flushErr
+				}
+				return err
+			}
+			assignments := tab.Eval()
+			hasModel = len(assignments) > 0
+			if hasModel {
+				model = assignments[0]
+				bw.WriteString("sat\n")
+			} else {
+				bw.WriteString("unsat\n")
+			}
+		case "get-model":
+			if !hasModel {
+				bw.WriteString("(error \"model is not available\")\n")
+				continue
+			}
+			bw.WriteString("(model\n")
+			for _, name := range declared {
+				_, _ = fmt.Fprintf(bw, "  (define-fun %s () Bool %t)\n", quote(name), model[name])
+			}
+			bw.WriteString(")\n")
+		case "exit":
+			return bw.Flush()
+		}
+	}
+	return bw.Flush()
+}
+
+// Write writes a script that declares the letters of the formula in order of first occurrence, asserts it and checks
+// its satisfiability.
+func Write(w io.Writer, f formula.Formula) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("(set-logic QF_UF)\n")
+	for _, name := range formula.Letters(f) {
+		_, _ = fmt.Fprintf(bw, "(declare-const %s Bool)\n", quote(name))
+	}
+	_, _ = fmt.Fprintf(bw, "(assert %s)\n(check-sat)\n", Term(f))
+	return bw.Flush()
+}
+
+// Term returns the formula as an SMT-LIB term. The operators without an SMT-LIB function are expressed with
+// negations and implications: p !& q is (not (and p q)), p !| q is (not (or p q)), p <- q is (=> q p) and p !-> q is
+// (not (=> p q)). The cardinality constraints, which have no SMT-LIB function in the logic QF_UF, are written with the
+// Pairwise encoding of formula.CompileCardinality, and the registered connectives with their Definition.
+func Term(f formula.Formula) string {
+	switch f := f.(type) {
+	case *formula.Interned:
+		return Term(f.Formula())
+	case formula.Letter:
+		return quote(f.Name())
+	case formula.Top:
+		return "true"
+	case formula.Bottom:
+		return "false"
+	case formula.Not:
+		return "(not " + Term(f.Negated()) + ")"
+	case formula.Binary:
+		l, r := Term(f.Left()), Term(f.Right())
+		switch f.Op() {
+		case formula.And:
+			return "(and " + l + " " + r + ")"
+		case formula.Or:
+			return "(or " + l + " " + r + ")"
+		case formula.Implies:
+			return "(=> " + l + " " + r + ")"
+		case formula.Nand:
+			return "(not (and " + l + " " + r + "))"
+		case formula.Nor:
+			return "(not (or " + l + " " + r + "))"
+		case formula.Biconditional:
+			return "(= " + l + " " + r + ")"
+		case formula.Xor:
+			return "(xor " + l + " " + r + ")"
+		case formula.ConverseImplies:
+			return "(=> " + r + " " + l + ")"
+		case formula.NonImplies:
+			return "(not (=> " + l + " " + r + "))"
+		}
+	case formula.NAry:
+		terms := make([]string, f.Len())
+		for i, operand := range f.Operands() {
+			terms[i] = Term(operand)
+		}
+		switch f.Op() {
+		case formula.And:
+			return "(and " + strings.Join(terms, " ") + ")"
+		case formula.Or:
+			return "(or " + strings.Join(terms, " ") + ")"
+		case formula.Xor:
+			return "(xor " + strings.Join(terms, " ") + ")"
+		}
+	case formula.Ite:
+		return "(ite " + Term(f.Condition()) + " " + Term(f.Then()) + " " + Term(f.Else()) + ")"
+	case formula.Cardinality:
+		compiled, _ := formula.CompileCardinality(f, formula.Pairwise)
+		return Term(compiled)
+	case formula.Custom:
+		return Term(f.Definition())
+	}
+	panic(fmt.Errorf("cannot write %v: unknown formula %T", f, f))
+}
+
+// reservedWords are the reserved words of SMT-LIB, which must be quoted to be used as symbols.
+var reservedWords = map[string]bool{
+	"_": true, "!": true, "as": true, "let": true, "exists": true, "forall": true, "match": true, "par": true,
+	"BINARY": true, "DECIMAL": true, "HEXADECIMAL": true, "NUMERAL": true, "STRING": true,
+}
+
+// quote returns the name as a symbol: the name itself if it is a simple symbol, otherwise the name between |.
+func quote(name string) string {
+	simple := name != "" && !reservedWords[name] && (name[0] < '0' || name[0] > '9')
+	for _, c := range name {
+		letter := 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+		if !letter && !strings.ContainsRune("~!@$%^&*_-+=<>.?/", c) {
+			simple = false
+		}
+	}
+	if simple {
+		return name
+	}
+	return "|" + name + "|"
+}
@@ -0,0 +1,226 @@
+package smtlib
+
+import (
+	"bytes"
+	"errors"
+	"github.com/francodesource/propositional_tableaux/formula"
+	"github.com/francodesource/propositional_tableaux/tableaux"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// assertion reads a script declaring p, q and r and asserting the given term, and returns the asserted formula.
+func assertion(t *testing.T, term string) formula.Formula {
+	t.Helper()
+	s, err := Read(strings.NewReader("(declare-const p Bool)(declare-fun q () Bool)(declare-const r Bool)\n" +
+		"(assert " + term + ")"))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	return s.Commands[len(s.Commands)-1].Formula
+}
+
+func TestRead_Terms(t *testing.T) {
+	tests := []struct {
+		term string
+		want string
+	}{
+		{"p", "p"},
+		{"(not true)", "!⊤"},
+		{"(and p q r)", "(p & q & r)"},
+		{"(or p)", "p"},
+		{"(and)", "⊤"},
+		{"(or)", "⊥"},
+		{"(xor p q false)", "(p ^ q ^ ⊥)"},
+		{"(=> p q r)", "(p -> (q -> r))"},
+		{"(= p q r)", "((p <-> q) & (q <-> r))"},
+		{"(distinct p q r)", "((p ^ q) & (p ^ r) & (q ^ r))"},
+		{"(ite p q r)", "ite(p, q, r)"},
+		{"(let ((a (not p)) (p q)) (and a p))", "(!p & q)"},
+		{"(let ((a p)) (let ((a (not a))) a))", "!p"},
+		{"(! (or p q) :named c1)", "(p | q)"},
+		{"(|and| |p| |q|)", "(p & q)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			got := assertion(t, tt.term)
+			if got.String() != tt.want {
+				t.Errorf("term %s = %v, want %v", tt.term, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	input := `; a comment
+(set-logic QF_UF)
+(set-info :status sat)
+(declare-const |p 1| Bool)
+(define-fun d () Bool (not |p 1|))
+(assert d)
+(check-sat)
+(get-model)
+(exit)
+`
+	s, err := Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := &Script{Commands: []Command{
+		{Name: "declare-const", Symbol: "p 1"},
+		{Name: "assert", Formula: formula.NewNot(formula.NewLetter("p 1"))},
+		{Name: "check-sat"},
+		{Name: "get-model"},
+		{Name: "exit"},
+	}}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("Read() = %+v, want %+v", s, want)
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  SyntaxError
+	}{
+		{"unclosed parenthesis", "(assert\n(and p", SyntaxError{2, 0, "unclosed parenthesis"}},
+		{"unexpected parenthesis", "(check-sat))", SyntaxError{1, 11, "unexpected )"}},
+		{"not a command", "check-sat", SyntaxError{1, 0, "expected a command, found check-sat"}},
+		{"unsupported command", "(push 1)", SyntaxError{1, 1, "unsupported command push"}},
+		{"int constant", "(declare-const x Int)", SyntaxError{1, 17, "only Bool constants are supported, found Int"}},
+		{
+			"function", "(declare-fun f (Bool) Bool)",
+			SyntaxError{1, 15, "only constants are supported, found f with arguments"},
+		},
+		{"redeclaration", "(declare-const p Bool)\n(declare-const p Bool)", SyntaxError{2, 15, "p is already declared"}},
+		{"undeclared constant", "(assert (or p true))", SyntaxError{1, 12, "unknown constant p"}},
+		{"unknown function", "(assert (f true))", SyntaxError{1, 9, "unknown function f"}},
+		{"quantifier", "(assert (forall ((x Bool)) x))", SyntaxError{1, 8, "quantifiers are not supported"}},
+		{"numeral", "(assert 1)", SyntaxError{1, 8, "expected a Boolean term, found 1"}},
+		{"arity", "(assert (not true false))", SyntaxError{1, 8, "not expects 1 argument, found 2"}},
+		{"let scope", "(assert (let ((a true) (b a)) b))", SyntaxError{1, 26, "unknown constant a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.input))
+			var se *SyntaxError
+			if !errors.As(err, &se) || *se != tt.want {
+				t.Errorf("Read() error = %v, want %v", err, &tt.want)
+			}
+		})
+	}
+}
+
+func TestScript_Run(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "sat with model",
+			input: "(declare-const p Bool)(declare-const q Bool)(assert (and p (not q)))(check-sat)(get-model)",
+			want:  "sat\n(model\n  (define-fun p () Bool true)\n  (define-fun q () Bool false)\n)\n",
+		},
+		{
+			name:  "unsat without model",
+			input: "(declare-const p Bool)(assert p)(check-sat)(assert (not p))(check-sat)(get-model)",
+			want:  "sat\nunsat\n(error \"model is not available\")\n",
+		},
+		{
+			name:  "no assertions",
+			input: "(check-sat)(get-model)",
+			want:  "sat\n(model\n)\n",
+		},
+		{
+			name:  "exit",
+			input: "(declare-const |1| Bool)(assert (not |1|))(check-sat)(get-model)(exit)(check-sat)",
+			want:  "sat\n(model\n  (define-fun |1| () Bool false)\n)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Read(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := s.Run(&buf); err != nil || buf.String() != tt.want {
+				t.Errorf("Run() = %q, %v, want %q", buf.String(), err, tt.want)
+			}
+		})
+	}
+
+	s, err := Read(strings.NewReader("(declare-const p Bool)(assert (or p (not p)))(check-sat)(check-sat)"))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := s.Run(&buf, tableaux.WithMaxNodes(1)); buf.String() != "unknown\n" ||
+		!errors.Is(err, tableaux.ErrLimitExceeded) {
+		t.Errorf("Run(WithMaxNodes(1)) = %q, %v, want unknown and ErrLimitExceeded", buf.String(), err)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	f := formula.NewNand(formula.NewImplies(formula.NewLetter("p"), formula.NewLetter("let")),
+		formula.NewNor(formula.NewBiconditional(formula.NewLetter("p"), formula.NewTop()),
+			formula.NewXor(formula.NewBottom(), formula.NewLetter("1"))))
+	want := "(set-logic QF_UF)\n(declare-const p Bool)\n(declare-const |let| Bool)\n(declare-const |1| Bool)\n" +
+		"(assert (not (and (=> p |let|) (not (or (= p true) (xor false |1|))))))\n(check-sat)\n"
+
+	var buf bytes.Buffer
+	if err := Write(&buf, f); err != nil || buf.String() != want {
+		t.Errorf("Write() = %q, %v, want %q", buf.String(), err, want)
+	}
+	terms := map[string]string{
+		"p & q & !(p | q | r)": "(and p q (not (or p q r)))",
+		"p ^ q ^ ite(p, q, r)": "(xor p q (ite p q r))",
+		"(p <- q) | (p !-> q)": "(or (=> q p) (not (=> p q)))",
+	}
+	for input, want := range terms {
+		if got := Term(formula.MustParse(input)); got != want {
+			t.Errorf("Term(%v) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// TestWrite_RoundTrip checks that reading a written formula gives an equivalent formula, deciding the equivalence
+// with the buffered tableaux.
+func TestWrite_RoundTrip(t *testing.T) {
+	f := func(f formula.Formula) bool {
+		var buf bytes.Buffer
+		if err := Write(&buf, f); err != nil {
+			t.Fatal(err)
+		}
+		s, err := Read(&buf)
+		if err != nil {
+			t.Errorf("Read(Write(%v)) error = %v", f, err)
+			return false
+		}
+		read := s.Commands[len(s.Commands)-2].Formula
+		if tab, _ := tableaux.BuildBufferTableaux(formula.NewNot(formula.NewBiconditional(f, read))); len(tab.Eval()) > 0 {
+			t.Errorf("Read(Write(%v)) = %v is not equivalent", f, read)
+			return false
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		MaxCount: 50,
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(formula.GenerateRandom(r, r.Intn(12)+1))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
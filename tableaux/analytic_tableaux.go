@@ -4,7 +4,6 @@ import (
 	"github.com/francodesource/propositional_tableaux/formula"
 	"github.com/francodesource/propositional_tableaux/tableaux/tsets"
 	"iter"
-	"maps"
 )
 
 // AnalyticNode represents a node in an analytic tableaux.
@@ -38,8 +37,26 @@ func (a *AnalyticNode) Right() Node {
 	return a.right
 }
 
+// Children returns the child nodes of the current node from left to right: the analytic tableaux apply the rules of
+// n-ary formulas as if they were binary, so a node has at most two children.
+func (a *AnalyticNode) Children() []Node {
+	var res []Node
+	for _, child := range []*AnalyticNode{a.left, a.right} {
+		if child != nil {
+			res = append(res, child)
+		}
+	}
+	return res
+}
+
 // Formulas returns an iterator over all formulas contained in the current node.
 func (a *AnalyticNode) Formulas() iter.Seq[formula.Formula] {
+	return sortedFormulas(a.formulas)
+}
+
+// storedFormulas returns an iterator over the formulas as they are stored in the node, which are interned if the node
+// was built by BuildAnalyticTableaux.
+func (a *AnalyticNode) storedFormulas() iter.Seq[formula.Formula] {
 	return combineIterators(a.formulas.IterLiterals(), a.formulas.IterAlpha(), a.formulas.IterBeta())
 }
 
@@ -81,6 +98,11 @@ func (a *AnalyticNode) MarkAsOpen() {
 	a.mark = Open
 }
 
+// MarkAsUnfinished marks the current node as unfinished.
+func (a *AnalyticNode) MarkAsUnfinished() {
+	a.mark = Unfinished
+}
+
 func (a *AnalyticNode) String() string {
 	var res string
 
@@ -101,36 +123,31 @@ func (a *AnalyticNode) String() string {
 	return res
 }
 
-// ChooseAlphaFormula chooses a random alpha formula from the current node branch.
-// Returns nil if no alpha formula is available
-func (a *AnalyticNode) ChooseAlphaFormula(visited map[formula.Formula]bool) (res formula.Formula) {
-	for alpha := range a.formulas.IterAlpha() {
-		if !visited[alpha] {
-			return alpha
-		}
-	}
-
-	if a.father != nil {
-		return a.father.ChooseAlphaFormula(visited)
-	}
+// ChooseAlphaFormula chooses among the alpha formulas of the current node branch that are not visited the one that
+// AlphaFirst expands, the least by formula.Compare. The formulas are the plain ones returned by Formulas, which are
+// the keys of visited. Returns nil if no alpha formula is available
+func (a *AnalyticNode) ChooseAlphaFormula(visited map[formula.Formula]bool) formula.Formula {
+	return leastUnvisited(a.branchFormulas(), formula.Alpha, visited)
+}
 
-	return
+// ChooseBetaFormula chooses among the beta formulas of the current node branch that are not visited the one that
+// AlphaFirst expands, the least by formula.Compare. The formulas are the plain ones returned by Formulas, which are
+// the keys of visited. Returns nil if no beta formula is available
+func (a *AnalyticNode) ChooseBetaFormula(visited map[formula.Formula]bool) formula.Formula {
+	return leastUnvisited(a.branchFormulas(), formula.Beta, visited)
 }
 
-// ChooseBetaFormula chooses a random beta formula from the current node branch.
-// Returns nil if no beta formula is available
-func (a *AnalyticNode) ChooseBetaFormula(visited map[formula.Formula]bool) (res formula.Formula) {
-	for beta := range a.formulas.IterBeta() {
-		if !visited[beta] {
-			return beta
+// branchFormulas returns an iterator over the plain formulas of the current node and of its ancestors.
+func (a *AnalyticNode) branchFormulas() iter.Seq[formula.Formula] {
+	return func(yield func(formula.Formula) bool) {
+		for node := a; node != nil; node = node.father {
+			for f := range plainFormulas(node.storedFormulas()) {
+				if !yield(f) {
+					return
+				}
+			}
 		}
 	}
-
-	if a.father != nil {
-		return a.father.ChooseBetaFormula(visited)
-	}
-
-	return
 }
 
 // IsLeaf checks if the current node is a leaf.
@@ -148,6 +165,11 @@ func (a *AnalyticNode) IsOpen() bool {
 	return a.IsLeaf() && a.mark == Open
 }
 
+// IsUnfinished checks if the current node is marked as unfinished.
+func (a *AnalyticNode) IsUnfinished() bool {
+	return a.IsLeaf() && a.mark == Unfinished
+}
+
 func assignLiteral(literal formula.Literal) bool {
 	if literal.Neg {
 		return false
@@ -168,7 +190,8 @@ func (a *AnalyticNode) collectLiterals(literals map[formula.Literal]bool) {
 
 func (a *AnalyticNode) eval() []Assignment {
 
-	if a.IsClosed() {
+	// an unfinished leaf may still be closed by the rules that were not applied.
+	if a.IsClosed() || a.IsUnfinished() {
 		return []Assignment{}
 	}
 
@@ -197,15 +220,30 @@ func (a *AnalyticNode) eval() []Assignment {
 }
 
 // Eval evaluates the analytic tableaux and returns all satisfying assignments cleaned of redundant assignments.
+// The unfinished leaves of a tableaux whose construction was stopped give no assignments, as in SemanticNode.Eval.
 func (a *AnalyticNode) Eval() []Assignment {
 	return CleanAssignments(a.eval())
 }
 
-func buildAnalyticTableaux(a *AnalyticNode, visited map[formula.Formula]bool) {
-	alpha := a.ChooseAlphaFormula(visited)
+// buildAnalyticTableaux expands the node choosing the formula to expand with the strategy among the candidates of the
+// branch, that holds the formulas from the root to the node, and depth is the depth of the node.
+func buildAnalyticTableaux(a *AnalyticNode, b *branch, depth int, c *construction) {
+	var alpha, beta *formula.Interned
+	if f := choose(c.strategy, b); f != nil && f.Class() == formula.Alpha {
+		alpha = f
+	} else {
+		beta = f
+	}
+
+	if alpha != nil && !c.expand(depth, 1) || beta != nil && !c.expand(depth, 2) {
+		a.MarkAsUnfinished()
+		return
+	}
 
 	if alpha != nil {
-		visited[alpha] = true
+		r := b.remove(alpha, false)
+		defer b.restore(r)
+		m := b.mark()
 
 		left, right := ApplyRule(alpha)
 		newSet := tsets.NewTSet()
@@ -217,17 +255,19 @@ func buildAnalyticTableaux(a *AnalyticNode, visited map[formula.Formula]bool) {
 		}
 
 		if !a.left.BranchHasComplementPairOf(left, right) {
-			buildAnalyticTableaux(a.left, visited)
+			b.add(left, right)
+			buildAnalyticTableaux(a.left, b, depth+1, c)
+			b.undo(m)
 		} else {
 			a.left.MarkAsClosed()
 		}
 		return
 	}
 
-	beta := a.ChooseBetaFormula(visited)
-
 	if beta != nil {
-		visited[beta] = true
+		r := b.remove(beta, false)
+		defer b.restore(r)
+		m := b.mark()
 		left, right := ApplyRule(beta)
 
 		leftSet := tsets.NewTSet()
@@ -247,13 +287,17 @@ func buildAnalyticTableaux(a *AnalyticNode, visited map[formula.Formula]bool) {
 		}
 
 		if !a.left.BranchHasComplementPairOf(left) {
-			buildAnalyticTableaux(a.left, maps.Clone(visited))
+			b.add(left)
+			buildAnalyticTableaux(a.left, b, depth+1, c)
+			b.undo(m)
 		} else {
 			a.left.MarkAsClosed()
 		}
 
 		if !a.right.BranchHasComplementPairOf(right) {
-			buildAnalyticTableaux(a.right, maps.Clone(visited))
+			b.add(right)
+			buildAnalyticTableaux(a.right, b, depth+1, c)
+			b.undo(m)
 		} else {
 			a.right.MarkAsClosed()
 		}
@@ -261,29 +305,42 @@ func buildAnalyticTableaux(a *AnalyticNode, visited map[formula.Formula]bool) {
 	}
 
 	// If it reaches this point it means that it is not possible to apply any rule so the algorithm has finished,
-	// Except for the special case where an alpha formula contains two equals subformulas: the second one will already mark
-	// as visited, and it will create a leaf that does not contain just literals. So I check for this and enforce the
+	// Except for the special case where an alpha formula contains two equals subformulas: the second one will already be
+	// expanded, and it will create a leaf that does not contain just literals. So I check for this and enforce the
 	// application of the rule.
 
-	if !a.formulas.HasOnlyLiterals() {
-		newVisited := maps.Clone(visited)
-		for f := range a.Formulas() {
-			newVisited[f] = false
-		}
-		buildAnalyticTableaux(a, newVisited)
+	if a.formulas.HasAlpha() || a.formulas.HasBeta() {
+		m := b.mark()
+		b.retry(a.storedFormulas())
+		buildAnalyticTableaux(a, b, depth, c)
+		b.undo(m)
+		return
 	}
 
 	a.MarkAsOpen()
 
 }
 
-// BuildAnalyticTableaux builds an analytic tableaux for the given formula and returns the root node.
-func BuildAnalyticTableaux(f formula.Formula) *AnalyticNode {
+// BuildAnalyticTableaux builds an analytic tableaux for the given formula and returns the root node. The formula to
+// expand in every node is chosen by the strategy of the options, AlphaFirst if there is none. If the construction is
+// stopped by a limit or by the context of the options, it returns the partial tableaux, whose unexpanded leaves are
+// marked as Unfinished, and an error wrapping ErrLimitExceeded or the error of the context.
+func BuildAnalyticTableaux(f formula.Formula, opts ...Option) (*AnalyticNode, error) {
+	c, cancel := newConstruction(opts)
+	defer cancel()
+
 	set := tsets.NewTSet()
-	set.Add(f)
+	f = formula.NewFactory().Intern(f)
+	closed := set.Add(f) // f can be a false constant
 
 	res := &AnalyticNode{formulas: set}
-	buildAnalyticTableaux(res, make(map[formula.Formula]bool))
+	if closed {
+		res.MarkAsClosed()
+		return res, nil
+	}
+	b := newBranch()
+	b.add(f)
+	buildAnalyticTableaux(res, b, 1, c)
 
-	return res
+	return res, c.err
 }
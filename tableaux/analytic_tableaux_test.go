@@ -3,6 +3,7 @@ package tableaux
 import (
 	"fmt"
 	"github.com/francodesource/propositional_tableaux/formula"
+	"github.com/francodesource/propositional_tableaux/tableaux/tsets"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -12,8 +13,8 @@ import (
 // TestBuildAnalyticTableaux_SemanticCompare checks that the assignments discovered by the analytic tableaux are the same of the semantic one
 func TestBuildAnalyticTableaux_SemanticCompare(t *testing.T) {
 	f := func(f formula.Formula) bool {
-		semanticTab := BuildSemanticTableaux(f)
-		analyticTab := BuildAnalyticTableaux(f)
+		semanticTab := must(BuildSemanticTableaux(f))
+		analyticTab := must(BuildAnalyticTableaux(f))
 
 		sAssignments := semanticTab.Eval()
 		aAssignments := analyticTab.Eval()
@@ -39,7 +40,7 @@ func TestBuildAnalyticTableaux_SemanticCompare(t *testing.T) {
 // TestBuildAnalyticTableaux_Assignments checks that the assignments obtained by an analytic tableaux, satisfy the formula.
 func TestBuildAnalyticTableaux_Assignments(t *testing.T) {
 	f := func(f formula.Formula) bool {
-		tab := BuildAnalyticTableaux(f)
+		tab := must(BuildAnalyticTableaux(f))
 		assignments := tab.Eval()
 
 		for _, a := range assignments {
@@ -68,7 +69,7 @@ func TestBuildAnalyticTableaux_Assignments(t *testing.T) {
 // TestBuildAnalyticTableaux_TruthTables compares analytic tableaux results with the one of the truth tables
 func TestBuildAnalyticTableaux_TruthTables(t *testing.T) {
 	f := func(f formula.Formula) bool {
-		tab := BuildAnalyticTableaux(f)
+		tab := must(BuildAnalyticTableaux(f))
 
 		return compareTableauxWithTruthTables(t, f, tab)
 	}
@@ -87,7 +88,7 @@ func TestBuildAnalyticTableaux_TruthTables(t *testing.T) {
 
 func TestAnalyticTableauxMarks(t *testing.T) {
 	f := func(f formula.Formula) bool {
-		tab := BuildAnalyticTableaux(f)
+		tab := must(BuildAnalyticTableaux(f))
 
 		res := testTableauxMarks(t, tab)
 
@@ -109,3 +110,36 @@ func TestAnalyticTableauxMarks(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+// TestAnalyticNode_ChooseFormula checks that the node chooses the least unvisited formula by formula.Compare among
+// the ones of its branch, as AlphaFirst does.
+func TestAnalyticNode_ChooseFormula(t *testing.T) {
+	qAndR, pOrQ, pAndQ, rOrS := formula.Parse("q & r"), formula.Parse("p | q"), formula.Parse("p & q"),
+		formula.Parse("r | s")
+	rootSet, childSet := tsets.NewTSet(), tsets.NewTSet()
+	rootSet.Add(qAndR, pOrQ, formula.Parse("p"))
+	childSet.Add(rOrS, pAndQ)
+	root := &AnalyticNode{formulas: rootSet}
+	child := &AnalyticNode{formulas: childSet, father: root}
+
+	tests := []struct {
+		name        string
+		visited     map[formula.Formula]bool
+		alpha, beta formula.Formula
+	}{
+		{"none visited", nil, pAndQ, pOrQ},
+		{"least visited", map[formula.Formula]bool{pAndQ: true, pOrQ: true}, qAndR, rOrS},
+		{"all visited", map[formula.Formula]bool{pAndQ: true, pOrQ: true, qAndR: true, rOrS: true}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := child.ChooseAlphaFormula(tt.visited); got != tt.alpha {
+				t.Errorf("ChooseAlphaFormula() = %v, want %v", got, tt.alpha)
+			}
+			if got := child.ChooseBetaFormula(tt.visited); got != tt.beta {
+				t.Errorf("ChooseBetaFormula() = %v, want %v", got, tt.beta)
+			}
+		})
+	}
+}
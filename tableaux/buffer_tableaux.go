@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/francodesource/propositional_tableaux/formula"
 	"iter"
-	"maps"
 	"slices"
 	"strings"
 )
@@ -39,8 +38,12 @@ func (b BufferSet) String() string {
 
 // Add adds a formula to the BufferSet.
 // Panics if more than two different formulas are added.
-// If the formula is already contained it will not be added.
+// If the formula is already contained or if it is a true constant (⊤ or ¬⊥) it will not be added.
 func (b BufferSet) Add(f formula.Formula) {
+	if f != nil && formula.IsConstant(f) && formula.AsConstant(f) {
+		return
+	}
+
 	if b[0] == nil {
 		b[0] = f
 		return
@@ -68,8 +71,13 @@ func (b BufferSet) Has(f formula.Formula) bool {
 }
 
 // HasComplementOf returns true if the BufferSet contains the complement of at least one of the given formulas.
+// A false constant (⊥ or ¬⊤) is considered to be complementary to any BufferSet.
 func (b BufferSet) HasComplementOf(fs ...formula.Formula) bool {
 	for _, f := range fs {
+		if formula.IsConstant(f) && !formula.AsConstant(f) {
+			return true
+		}
+
 		if b.Has(formula.Complement(f)) {
 			return true
 		}
@@ -78,8 +86,9 @@ func (b BufferSet) HasComplementOf(fs ...formula.Formula) bool {
 	return false
 }
 
+// HasOnlyLiterals returns true if the BufferSet contains only literals or constants.
 func (b BufferSet) HasOnlyLiterals() bool {
-	return (b[0] == nil || formula.IsLiteral(b[0])) && (b[1] == nil || formula.IsLiteral(b[1]))
+	return (b[0] == nil || b[0].Class() == formula.LiteralClass) && (b[1] == nil || b[1].Class() == formula.LiteralClass)
 }
 
 // BufferNode is a node in a buffered analytic tableaux.
@@ -113,8 +122,26 @@ func (b *BufferNode) Right() Node {
 	return b.right
 }
 
+// Children returns the child nodes of the current node from left to right: the buffered tableaux apply the rules of
+// n-ary formulas as if they were binary, so a node has at most two children.
+func (b *BufferNode) Children() []Node {
+	var res []Node
+	for _, child := range []*BufferNode{b.left, b.right} {
+		if child != nil {
+			res = append(res, child)
+		}
+	}
+	return res
+}
+
 // Formulas returns an iterator over all formulas contained in the current node, filtered of nil values.
 func (b *BufferNode) Formulas() iter.Seq[formula.Formula] {
+	return plainFormulas(b.storedFormulas())
+}
+
+// storedFormulas returns an iterator over the formulas as they are stored in the node, filtered of nil values.
+// They are interned if the node was built by BuildBufferTableaux.
+func (b *BufferNode) storedFormulas() iter.Seq[formula.Formula] {
 	res := make([]formula.Formula, 0, 2)
 
 	for _, f := range b.formulas {
@@ -141,36 +168,31 @@ func (b *BufferNode) BranchHasComplementPairOf(fs ...formula.Formula) bool {
 	return false
 }
 
-// ChooseAlphaFormula chooses a random alpha formula from the current node branch.
-// Returns nil if no alpha formula is available
-func (b *BufferNode) ChooseAlphaFormula(visited map[formula.Formula]bool) (res formula.Formula) {
-	for _, f := range b.formulas {
-		if f != nil && f.Class() == formula.Alpha && !visited[f] {
-			return f
-		}
-	}
-
-	if b.father != nil {
-		return b.father.ChooseAlphaFormula(visited)
-	}
+// ChooseAlphaFormula chooses among the alpha formulas of the current node branch that are not visited the one that
+// AlphaFirst expands, the least by formula.Compare. The formulas are the plain ones returned by Formulas, which are
+// the keys of visited. Returns nil if no alpha formula is available
+func (b *BufferNode) ChooseAlphaFormula(visited map[formula.Formula]bool) formula.Formula {
+	return leastUnvisited(b.branchFormulas(), formula.Alpha, visited)
+}
 
-	return
+// ChooseBetaFormula chooses among the beta formulas of the current node branch that are not visited the one that
+// AlphaFirst expands, the least by formula.Compare. The formulas are the plain ones returned by Formulas, which are
+// the keys of visited. Returns nil if no beta formula is available
+func (b *BufferNode) ChooseBetaFormula(visited map[formula.Formula]bool) formula.Formula {
+	return leastUnvisited(b.branchFormulas(), formula.Beta, visited)
 }
 
-// ChooseBetaFormula chooses a random beta formula from the current node branch.
-// Returns nil if no beta formula is available
-func (b *BufferNode) ChooseBetaFormula(visited map[formula.Formula]bool) (res formula.Formula) {
-	for _, f := range b.formulas {
-		if f != nil && f.Class() == formula.Beta && !visited[f] {
-			return f
+// branchFormulas returns an iterator over the plain formulas of the current node and of its ancestors.
+func (b *BufferNode) branchFormulas() iter.Seq[formula.Formula] {
+	return func(yield func(formula.Formula) bool) {
+		for node := b; node != nil; node = node.father {
+			for f := range plainFormulas(node.storedFormulas()) {
+				if !yield(f) {
+					return
+				}
+			}
 		}
 	}
-
-	if b.father != nil {
-		return b.father.ChooseBetaFormula(visited)
-	}
-
-	return
 }
 
 // MarkAsClosed marks the current node as closed.
@@ -183,6 +205,11 @@ func (b *BufferNode) MarkAsOpen() {
 	b.mark = Open
 }
 
+// MarkAsUnfinished marks the current node as unfinished.
+func (b *BufferNode) MarkAsUnfinished() {
+	b.mark = Unfinished
+}
+
 // IsLeaf returns true if the current node is a leaf (i.e., has no children).
 func (b *BufferNode) IsLeaf() bool {
 	return b.left == nil && b.right == nil
@@ -198,6 +225,11 @@ func (b *BufferNode) IsOpen() bool {
 	return b.IsLeaf() && b.mark == Open
 }
 
+// IsUnfinished returns true if the current node is an unfinished leaf.
+func (b *BufferNode) IsUnfinished() bool {
+	return b.IsLeaf() && b.mark == Unfinished
+}
+
 func (b *BufferNode) String() string {
 	var res string
 
@@ -232,7 +264,8 @@ func (b *BufferNode) collectLiterals(literals map[formula.Literal]bool) {
 
 func (b *BufferNode) eval() []Assignment {
 
-	if b.IsClosed() {
+	// an unfinished leaf may still be closed by the rules that were not applied.
+	if b.IsClosed() || b.IsUnfinished() {
 		return []Assignment{}
 	}
 
@@ -261,15 +294,30 @@ func (b *BufferNode) eval() []Assignment {
 }
 
 // Eval evaluates the buffered tableaux and returns all satisfying assignments cleaned of the redundant assignments.
+// The unfinished leaves of a tableaux whose construction was stopped give no assignments, as in SemanticNode.Eval.
 func (b *BufferNode) Eval() []Assignment {
 	return CleanAssignments(b.eval())
 }
 
-func buildBufferedTableaux(a *BufferNode, visited map[formula.Formula]bool) {
-	alpha := a.ChooseAlphaFormula(visited)
+// buildBufferedTableaux expands the node choosing the formula to expand with the strategy among the candidates of the
+// branch, that holds the formulas from the root to the node, and depth is the depth of the node.
+func buildBufferedTableaux(a *BufferNode, b *branch, depth int, c *construction) {
+	var alpha, beta *formula.Interned
+	if f := choose(c.strategy, b); f != nil && f.Class() == formula.Alpha {
+		alpha = f
+	} else {
+		beta = f
+	}
+
+	if alpha != nil && !c.expand(depth, 1) || beta != nil && !c.expand(depth, 2) {
+		a.MarkAsUnfinished()
+		return
+	}
 
 	if alpha != nil {
-		visited[alpha] = true
+		r := b.remove(alpha, false)
+		defer b.restore(r)
+		m := b.mark()
 
 		left, right := ApplyRule(alpha)
 		newBuff := NewBufferSet(left, right)
@@ -280,17 +328,19 @@ func buildBufferedTableaux(a *BufferNode, visited map[formula.Formula]bool) {
 		}
 
 		if !a.left.BranchHasComplementPairOf(left, right) {
-			buildBufferedTableaux(a.left, visited)
+			b.add(left, right)
+			buildBufferedTableaux(a.left, b, depth+1, c)
+			b.undo(m)
 		} else {
 			a.left.MarkAsClosed()
 		}
 		return
 	}
 
-	beta := a.ChooseBetaFormula(visited)
-
 	if beta != nil {
-		visited[beta] = true
+		r := b.remove(beta, false)
+		defer b.restore(r)
+		m := b.mark()
 		left, right := ApplyRule(beta)
 
 		leftSet := NewBufferSet(left)
@@ -308,13 +358,17 @@ func buildBufferedTableaux(a *BufferNode, visited map[formula.Formula]bool) {
 		}
 
 		if !a.left.BranchHasComplementPairOf(left) {
-			buildBufferedTableaux(a.left, maps.Clone(visited))
+			b.add(left)
+			buildBufferedTableaux(a.left, b, depth+1, c)
+			b.undo(m)
 		} else {
 			a.left.MarkAsClosed()
 		}
 
 		if !a.right.BranchHasComplementPairOf(right) {
-			buildBufferedTableaux(a.right, maps.Clone(visited))
+			b.add(right)
+			buildBufferedTableaux(a.right, b, depth+1, c)
+			b.undo(m)
 		} else {
 			a.right.MarkAsClosed()
 		}
@@ -322,28 +376,41 @@ func buildBufferedTableaux(a *BufferNode, visited map[formula.Formula]bool) {
 	}
 
 	// If it reaches this point it means that it is not possible to apply any rule so the algorithm has finished,
-	// Except for the special case where an alpha formula contains two equals subformulas: the second one will already mark
-	// as visited, and it will create a leaf that does not contain just literals. So I check for this and enforce the
+	// Except for the special case where an alpha formula contains two equals subformulas: the second one will already be
+	// expanded, and it will create a leaf that does not contain just literals. So I check for this and enforce the
 	// application of the rule.
 
 	if !a.formulas.HasOnlyLiterals() {
-		newVisited := maps.Clone(visited)
-		for f := range a.Formulas() {
-			newVisited[f] = false
-		}
-		buildBufferedTableaux(a, newVisited)
+		m := b.mark()
+		b.retry(a.storedFormulas())
+		buildBufferedTableaux(a, b, depth, c)
+		b.undo(m)
+		return
 	}
 
 	a.MarkAsOpen()
 
 }
 
-// BuildBufferTableaux builds a buffered analytic tableaux for the given formula.
-func BuildBufferTableaux(f formula.Formula) *BufferNode {
+// BuildBufferTableaux builds a buffered analytic tableaux for the given formula. The formula to expand in every node is
+// chosen by the strategy of the options, AlphaFirst if there is none. If the construction is stopped by a limit or by
+// the context of the options, it returns the partial tableaux, whose unexpanded leaves are marked as Unfinished, and an
+// error wrapping ErrLimitExceeded or the error of the context.
+func BuildBufferTableaux(f formula.Formula, opts ...Option) (*BufferNode, error) {
+	c, cancel := newConstruction(opts)
+	defer cancel()
+
+	f = formula.NewFactory().Intern(f)
 	set := NewBufferSet(f)
 
 	res := &BufferNode{formulas: set}
-	buildBufferedTableaux(res, make(map[formula.Formula]bool))
+	if res.BranchHasComplementPairOf(f) { // f can be a false constant
+		res.MarkAsClosed()
+		return res, nil
+	}
+	b := newBranch()
+	b.add(f)
+	buildBufferedTableaux(res, b, 1, c)
 
-	return res
+	return res, c.err
 }
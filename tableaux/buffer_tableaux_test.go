@@ -123,8 +123,8 @@ func TestBufferSet_HasComplementOf(t *testing.T) {
 // TestBuildBufferTableaux_SemanticCompare checks that the assignments discovered by the buffered analytic tableaux are the same of the semantic one
 func TestBuildBufferTableaux_SemanticCompare(t *testing.T) {
 	f := func(f formula.Formula) bool {
-		semanticTab := BuildSemanticTableaux(f)
-		analyticTab := BuildBufferTableaux(f)
+		semanticTab := must(BuildSemanticTableaux(f))
+		analyticTab := must(BuildBufferTableaux(f))
 
 		sAssignments := semanticTab.Eval()
 		bAssignments := analyticTab.Eval()
@@ -150,8 +150,8 @@ func TestBuildBufferTableaux_SemanticCompare(t *testing.T) {
 // TestBuildBufferTableaux_AnalyticCompare checks that the assignments discovered by the buffered analytic tableaux are the same of the analytic one
 func TestBuildBufferTableaux_AnalyticCompare(t *testing.T) {
 	f := func(f formula.Formula) bool {
-		semanticTab := BuildAnalyticTableaux(f)
-		analyticTab := BuildBufferTableaux(f)
+		semanticTab := must(BuildAnalyticTableaux(f))
+		analyticTab := must(BuildBufferTableaux(f))
 
 		sAssignments := semanticTab.Eval()
 		bAssignments := analyticTab.Eval()
@@ -177,7 +177,7 @@ func TestBuildBufferTableaux_AnalyticCompare(t *testing.T) {
 
 func TestBufferTableauxMarks(t *testing.T) {
 	f := func(f formula.Formula) bool {
-		tab := BuildBufferTableaux(f)
+		tab := must(BuildBufferTableaux(f))
 
 		res := testTableauxMarks(t, tab)
 
@@ -199,3 +199,33 @@ func TestBufferTableauxMarks(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+// TestBufferNode_ChooseFormula checks that the node chooses the least unvisited formula by formula.Compare among the
+// ones of its branch, as AlphaFirst does.
+func TestBufferNode_ChooseFormula(t *testing.T) {
+	qAndR, pOrQ, pAndQ, rOrS := formula.Parse("q & r"), formula.Parse("p | q"), formula.Parse("p & q"),
+		formula.Parse("r | s")
+	root := &BufferNode{formulas: NewBufferSet(qAndR, pOrQ)}
+	child := &BufferNode{formulas: NewBufferSet(rOrS, pAndQ), father: root}
+
+	tests := []struct {
+		name        string
+		visited     map[formula.Formula]bool
+		alpha, beta formula.Formula
+	}{
+		{"none visited", nil, pAndQ, pOrQ},
+		{"least visited", map[formula.Formula]bool{pAndQ: true, pOrQ: true}, qAndR, rOrS},
+		{"all visited", map[formula.Formula]bool{pAndQ: true, pOrQ: true, qAndR: true, rOrS: true}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := child.ChooseAlphaFormula(tt.visited); got != tt.alpha {
+				t.Errorf("ChooseAlphaFormula() = %v, want %v", got, tt.alpha)
+			}
+			if got := child.ChooseBetaFormula(tt.visited); got != tt.beta {
+				t.Errorf("ChooseBetaFormula() = %v, want %v", got, tt.beta)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/francodesource/propositional_tableaux/formula"
 	"iter"
 	"maps"
+	"slices"
 	"strings"
 )
 
@@ -22,10 +23,11 @@ func New(formulas ...formula.Formula) FormulaSet {
 	return FormulaSet{values: values}
 }
 
+// String returns the formulas of the set in the order of formula.Compare, so equal sets give the same string.
 func (s FormulaSet) String() string {
 	strs := make([]string, 0, len(s.values))
 
-	for f := range s.Iter() {
+	for f := range s.Sorted() {
 		strs = append(strs, f.String())
 	}
 
@@ -43,11 +45,17 @@ func (s FormulaSet) HasComplementaryOf(literal formula.Formula) bool {
 	return s.Has(formula.Complement(literal))
 }
 
-// Iter returns an iterator over the formulas in the set.
+// Iter returns an iterator over the formulas in the set in no particular order, which can change between runs.
 func (s FormulaSet) Iter() iter.Seq[formula.Formula] {
 	return maps.Keys(s.values)
 }
 
+// Sorted returns an iterator over the formulas in the set in the order of formula.Compare, so that the iteration
+// order does not change between runs. Unlike Iter, it sorts the whole set, so it is meant for printing.
+func (s FormulaSet) Sorted() iter.Seq[formula.Formula] {
+	return slices.Values(slices.SortedFunc(maps.Keys(s.values), formula.Compare))
+}
+
 // Add adds all the passed formulas in-place and returns itself.
 // If a formula is already contained it will be overwritten.
 // If a formula is nil it won't be added.
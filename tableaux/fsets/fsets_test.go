@@ -4,6 +4,7 @@ import (
 	"github.com/francodesource/propositional_tableaux/formula"
 	tu "github.com/francodesource/propositional_tableaux/internal/testutil"
 	"maps"
+	"slices"
 	"testing"
 )
 
@@ -23,6 +24,11 @@ func TestFormulaSet_String(t *testing.T) {
 			New(formula.NewLetter("p")),
 			"{p}",
 		},
+		{
+			"ordered",
+			New(formula.NewNot(tu.R), formula.NewAnd(tu.P, tu.Q), tu.Q, formula.NewBottom(), tu.P),
+			"{⊥, P, Q, !R, (P & Q)}",
+		},
 	}
 
 	for _, tt := range tests {
@@ -34,6 +40,18 @@ func TestFormulaSet_String(t *testing.T) {
 	}
 }
 
+func TestFormulaSet_Sorted(t *testing.T) {
+	set := New(formula.NewNot(tu.R), formula.NewAnd(tu.P, tu.Q), tu.Q, formula.NewBottom(), tu.P)
+	want := []formula.Formula{formula.NewBottom(), tu.P, tu.Q, formula.NewNot(tu.R), formula.NewAnd(tu.P, tu.Q)}
+
+	if got := slices.Collect(set.Sorted()); !slices.Equal(got, want) {
+		t.Errorf("Sorted() = %v, want %v", got, want)
+	}
+	if got := slices.Collect(set.Iter()); len(got) != len(want) || !set.Has(got[0]) {
+		t.Errorf("Iter() = %v, want the formulas of %v", got, set)
+	}
+}
+
 func TestFormulaSet_HasComplementaryOf(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,114 @@
+package tableaux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrLimitExceeded is returned by the builders, wrapped, when the tableaux would exceed the limit set by
+// WithMaxNodes or WithMaxDepth.
+var ErrLimitExceeded = errors.New("tableaux limit exceeded")
+
+// Option configures the construction of a tableaux by BuildSemanticTableaux, BuildAnalyticTableaux and
+// BuildBufferTableaux.
+type Option func(*options)
+
+type options struct {
+	strategy           Strategy
+	ctx                context.Context
+	timeout            time.Duration
+	maxNodes, maxDepth int
+}
+
+// WithStrategy makes the builders choose the formula to expand next with the strategy instead of AlphaFirst.
+func WithStrategy(strategy Strategy) Option {
+	return func(o *options) {
+		o.strategy = strategy
+	}
+}
+
+// WithContext makes the builders stop when the context is done, returning the partial tableaux and ctx.Err().
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// WithTimeout makes the builders stop after the given duration, returning the partial tableaux and
+// context.DeadlineExceeded. A duration <= 0 means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithMaxNodes makes the builders stop before the tableaux has more than n nodes, returning the partial tableaux and
+// an error wrapping ErrLimitExceeded. A limit <= 0 means no limit.
+func WithMaxNodes(n int) Option {
+	return func(o *options) {
+		o.maxNodes = n
+	}
+}
+
+// WithMaxDepth makes the builders leave unexpanded the nodes at depth d, where the root has depth 1, so that no
+// branch is longer than d nodes. The other branches are still expanded, and the builders return the partial tableaux
+// and an error wrapping ErrLimitExceeded. A limit <= 0 means no limit.
+func WithMaxDepth(d int) Option {
+	return func(o *options) {
+		o.maxDepth = d
+	}
+}
+
+// construction holds the state shared by the recursive calls that build a tableaux.
+type construction struct {
+	strategy           Strategy
+	ctx                context.Context
+	maxNodes, maxDepth int
+
+	nodes   int   // nodes is the number of nodes built so far.
+	stopped bool  // stopped is true when the construction was stopped by the node limit or by the context.
+	err     error // err is the reason why some nodes were not expanded.
+}
+
+// newConstruction returns the construction configured by opts for a tableaux made of its root, and the function that
+// releases the resources of its context.
+func newConstruction(opts []Option) (*construction, context.CancelFunc) {
+	o := &options{strategy: AlphaFirst, ctx: context.Background()}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ctx, cancel := o.ctx, context.CancelFunc(func() {})
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+	}
+	return &construction{strategy: o.strategy, ctx: ctx, maxNodes: o.maxNodes, maxDepth: o.maxDepth, nodes: 1}, cancel
+}
+
+// expand reports whether a node at the given depth can be expanded by a rule that adds the given number of children,
+// and counts them. Otherwise, it records the reason in c.err: the node limit and the end of the context stop the whole
+// construction, while the depth limit only stops the branch.
+func (c *construction) expand(depth, children int) bool {
+	if c.stopped {
+		return false
+	}
+	if err := c.ctx.Err(); err != nil {
+		c.stopped, c.err = true, err
+		return false
+	}
+	if c.maxNodes > 0 && c.nodes+children > c.maxNodes {
+		c.stopped, c.err = true, fmt.Errorf("%w: more than %d nodes", ErrLimitExceeded, c.maxNodes)
+		return false
+	}
+	if c.maxDepth > 0 && depth >= c.maxDepth {
+		if c.err == nil {
+			c.err = fmt.Errorf("%w: deeper than %d nodes", ErrLimitExceeded, c.maxDepth)
+		}
+		return false
+	}
+
+	c.nodes += children
+	return true
+}
@@ -0,0 +1,199 @@
+package tableaux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/francodesource/propositional_tableaux/formula"
+	tu "github.com/francodesource/propositional_tableaux/internal/testutil"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+// builders returns the three builders with the given options.
+func builders(opts ...Option) map[string]func(formula.Formula) (Node, error) {
+	return map[string]func(formula.Formula) (Node, error){
+		"semantic": func(f formula.Formula) (Node, error) { return BuildSemanticTableaux(f, opts...) },
+		"analytic": func(f formula.Formula) (Node, error) { return BuildAnalyticTableaux(f, opts...) },
+		"buffer":   func(f formula.Formula) (Node, error) { return BuildBufferTableaux(f, opts...) },
+	}
+}
+
+// height returns the number of nodes of the longest branch of the tableaux.
+func height(tab Node) int {
+	res := 0
+	for _, child := range Children(tab) {
+		res = max(res, height(child))
+	}
+	return res + 1
+}
+
+// leaves returns the number of leaves of the tableaux with the given mark.
+func leaves(tab Node, mark Mark) int {
+	if tab.IsLeaf() {
+		if leafMark(tab) == mark {
+			return 1
+		}
+		return 0
+	}
+
+	res := 0
+	for _, child := range Children(tab) {
+		res += leaves(child, mark)
+	}
+	return res
+}
+
+// TestBuildTableaux_Limits checks that a stopped tableaux respects the limit, has unfinished leaves, and that its
+// assignments extend the ones of the complete tableaux, which are cleaned of the redundant ones.
+func TestBuildTableaux_Limits(t *testing.T) {
+	f := func(f formula.Formula, limit int) bool {
+		for name, build := range builders() {
+			complete, _ := build(f)
+			full := complete.Eval()
+
+			for _, tt := range []struct {
+				option Option
+				within func(tab Node) bool
+			}{
+				{WithMaxNodes(limit), func(tab Node) bool { return countNodes(tab) <= limit }},
+				{WithMaxDepth(limit), func(tab Node) bool { return height(tab) <= limit }},
+			} {
+				tab, err := builders(tt.option)[name](f)
+				if !tt.within(tab) {
+					t.Errorf("%s tableaux of %v exceeds the limit %d", name, f, limit)
+					return false
+				}
+				if stopped := leaves(tab, Unfinished) > 0; stopped != errors.Is(err, ErrLimitExceeded) {
+					t.Errorf("%s tableaux of %v with limit %d: error %v, unfinished leaves %v", name, f, limit, err,
+						stopped)
+					return false
+				}
+				if err == nil && fmt.Sprint(tab) != fmt.Sprint(complete) {
+					t.Errorf("%s tableaux of %v with limit %d is different from the complete one", name, f, limit)
+					return false
+				}
+				for _, a := range tab.Eval() {
+					if !slices.ContainsFunc(full, func(b Assignment) bool { return a.IsSupersetOf(b) }) {
+						t.Errorf("%s tableaux of %v with limit %d: %v does not extend the complete tableaux",
+							name, f, limit, a)
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(tu.GenerateRandomNAry(r, r.Intn(FormulaMaxSize/4)))
+			values[1] = reflect.ValueOf(r.Intn(20) + 1)
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBuildTableaux_MaxNodes(t *testing.T) {
+	f := formula.NewAnd(formula.NewOr(tu.P, tu.Q), formula.NewOr(tu.R, tu.S))
+	for name, build := range builders(WithMaxNodes(3)) {
+		t.Run(name, func(t *testing.T) {
+			tab, err := build(f)
+			if !errors.Is(err, ErrLimitExceeded) || err.Error() != "tableaux limit exceeded: more than 3 nodes" {
+				t.Errorf("error = %v, want ErrLimitExceeded", err)
+			}
+			// the root and the child of the alpha rule, since a beta rule would add two nodes.
+			if got := countNodes(tab); got != 2 || leaves(tab, Unfinished) != 1 {
+				t.Errorf("the tableaux has %d nodes and %d unfinished leaves:\n%v", got, leaves(tab, Unfinished),
+					DefaultAsciiTree(tab))
+			}
+			if assignments := tab.Eval(); len(assignments) != 0 {
+				t.Errorf("Eval() = %v, want no assignments", assignments)
+			}
+		})
+	}
+}
+
+// TestBuildTableaux_MaxDepth checks that the depth limit stops only the branches that reach it.
+func TestBuildTableaux_MaxDepth(t *testing.T) {
+	f := formula.NewOr(formula.NewAnd(tu.P, formula.NewNot(tu.P)), formula.NewOr(tu.Q, formula.NewAnd(tu.R, tu.S)))
+	for name, build := range builders(WithMaxDepth(3)) {
+		t.Run(name, func(t *testing.T) {
+			tab, err := build(f)
+			if !errors.Is(err, ErrLimitExceeded) || err.Error() != "tableaux limit exceeded: deeper than 3 nodes" {
+				t.Errorf("error = %v, want ErrLimitExceeded", err)
+			}
+			if leaves(tab, Closed) != 1 || leaves(tab, Unfinished) == 0 || height(tab) != 3 {
+				t.Errorf("the branches were not expanded up to depth 3:\n%v", DefaultAsciiTree(tab))
+			}
+		})
+	}
+}
+
+func TestBuildTableaux_Context(t *testing.T) {
+	f := formula.NewAnd(formula.NewOr(tu.P, tu.Q), formula.NewOr(tu.R, tu.S))
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	// a strategy that takes a millisecond to choose runs out of a millisecond timeout at its second choice at most.
+	slow := WithStrategy(StrategyFunc(func([]*formula.Interned, []*formula.Interned) int {
+		time.Sleep(time.Millisecond)
+		return 0
+	}))
+
+	tests := []struct {
+		name string
+		opts []Option
+		want error
+	}{
+		{"canceled", []Option{WithContext(canceled)}, context.Canceled},
+		{"expired", []Option{WithContext(expired)}, context.DeadlineExceeded},
+		{"timeout", []Option{WithTimeout(time.Millisecond), slow}, context.DeadlineExceeded},
+		{"no timeout", []Option{WithTimeout(time.Minute)}, nil},
+	}
+
+	for _, tt := range tests {
+		for name, build := range builders(tt.opts...) {
+			t.Run(tt.name+" "+name, func(t *testing.T) {
+				tab, err := build(f)
+				if err != tt.want {
+					t.Errorf("error = %v, want %v", err, tt.want)
+				}
+				if stopped := leaves(tab, Unfinished) > 0; stopped != (tt.want != nil) {
+					t.Errorf("the tableaux has unfinished leaves = %v:\n%v", stopped, DefaultAsciiTree(tab))
+				}
+			})
+		}
+	}
+}
+
+func TestRender_Unfinished(t *testing.T) {
+	tab, _ := BuildSemanticTableaux(formula.NewOr(tu.P, tu.Q), WithMaxNodes(1))
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"default", fmt.Sprint(tab), "mark: Unfinished"},
+		{"ascii", DefaultAsciiTree(tab).String(), "UNFINISHED"},
+		{"unicode", UnicodeAsciiTree(tab).String(), "◌"},
+		{"tex", TexForestTree(tab), `\cdots`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(tt.got, tt.want) {
+				t.Errorf("got\n%v\nwant it to contain %v", tt.got, tt.want)
+			}
+		})
+	}
+}
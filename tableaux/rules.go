@@ -31,6 +31,14 @@ func not_implies(left, right formula.Formula) (formula.Formula, formula.Formula)
 	return left, formula.NewNot(right)
 }
 
+func converse_implies(left, right formula.Formula) (formula.Formula, formula.Formula) {
+	return left, formula.NewNot(right)
+}
+
+func not_converse_implies(left, right formula.Formula) (formula.Formula, formula.Formula) {
+	return formula.NewNot(left), right
+}
+
 func biconditional(left, right formula.Formula) (formula.Formula, formula.Formula) {
 	return formula.NewImplies(left, right), formula.NewImplies(right, left)
 }
@@ -39,7 +47,7 @@ func not_biconditional(left, right formula.Formula) (formula.Formula, formula.Fo
 	return formula.NewNot(formula.NewImplies(left, right)), formula.NewNot(formula.NewImplies(right, left))
 }
 
-const OperatorsCount = 7
+const OperatorsCount = 9
 
 // alphaRules is the set of rules for alpha formulas. It assumes negation and consider inner operator.
 // Double negation is considered to be a special case, so it is not contained in this array.
@@ -51,6 +59,8 @@ var alphaRules = [OperatorsCount]Rule{
 	not_or, // not or is the same as nor
 	biconditional,
 	biconditional, // biconditional is '=', xor is '!=' so negated xor is the same as biconditional
+	not_converse_implies,
+	not_implies, // non-implication is the same as negated implication
 }
 
 var betaRules = [OperatorsCount]Rule{
@@ -61,6 +71,8 @@ var betaRules = [OperatorsCount]Rule{
 	or,
 	not_biconditional,
 	not_biconditional,
+	converse_implies,
+	implies, // negated non-implication is the same as implication
 }
 
 func applyAlphaOrBetaRule(op formula.Operator, class formula.Classification,
@@ -77,22 +89,294 @@ func applyAlphaOrBetaRule(op formula.Operator, class formula.Classification,
 }
 
 // ApplyRule apply the correct tableaux-building rule alpha_and returns the resulting two formulas.
-// The second formula can be nil for the double negation case, that returns only a single formula.
+// The second formula can be nil for the double negation case, that returns only a single formula,
+// and for the negation of a truth constant, that returns the opposite constant.
+// An n-ary formula is split in its first operand and in the formula of the other operands, as if it were a chain of
+// binary formulas associated to the right: use Expand to get all the operands at once.
+// An if-then-else ite(C, A, B) is a beta formula that returns (C & A) and (!C & B), and its negation returns
+// (C & !A) and (!C & !B). A cardinality constraint is a beta formula that branches on its first operand like the
+// if-then-else of its Branch: atmost(1; p, q, r) returns (p & atmost(0; q, r)) and (!p & atmost(1; q, r)).
+// A registered connective, or its negation, returns the first component of its Decomposition and the conjunction,
+// for an alpha rule, or the disjunction, for a beta rule, of the others; an alpha rule with one component returns nil
+// as the second formula.
 // Panics if the type is not formula.Not beta_or formula.Binary beta_or if the formula is a LiteralClass.
+// If the formula is interned, the resulting formulas are interned by the same factory.
 func ApplyRule(f formula.Formula) (formula.Formula, formula.Formula) {
+	if class, components, ok := customComponents(f); ok {
+		return splitComponents(f, class, components)
+	}
+
 	switch f := f.(type) {
+	case *formula.Interned:
+		return applyInternedRule(f)
 	case formula.Not:
 		switch inner := f.Negated().(type) {
 		case formula.Not:
 			return inner.Negated(), nil // double negation special case
+		case formula.Top:
+			return formula.NewBottom(), nil // the negation of a constant is the opposite constant
+		case formula.Bottom:
+			return formula.NewTop(), nil
 		case formula.Binary:
 			return applyAlphaOrBetaRule(inner.Op(), f.Class(), inner.Left(), inner.Right())
+		case formula.NAry:
+			first, rest := splitNAry(inner)
+			return applyAlphaOrBetaRule(inner.Op(), f.Class(), first, rest)
+		case formula.Ite:
+			return iteRule(inner, true)
+		case formula.Cardinality:
+			return iteRule(inner.Branch(), true)
 		default:
 			panic(fmt.Errorf("cannot apply formula to %v: %T", inner, inner))
 		}
 	case formula.Binary:
 		return applyAlphaOrBetaRule(f.Op(), f.Class(), f.Left(), f.Right())
+	case formula.NAry:
+		first, rest := splitNAry(f)
+		return applyAlphaOrBetaRule(f.Op(), f.Class(), first, rest)
+	case formula.Ite:
+		return iteRule(f, false)
+	case formula.Cardinality:
+		return iteRule(f.Branch(), false)
 	default:
 		panic(fmt.Errorf("cannot apply formula to %v: %T", f, f))
 	}
 }
+
+// customComponents returns the class and the components of the Decomposition of a registered connective or of its
+// negation, interned by the factory of the formula if it is interned, and false if the formula is neither.
+// It panics if the rule has no components, or only one for a beta rule.
+func customComponents(f formula.Formula) (formula.Classification, []formula.Formula, bool) {
+	var d formula.Decomposition
+	var components []formula.Formula
+	negated := false
+
+	if n, ok := f.(*formula.Interned); ok {
+		if _, ok := n.Formula().(formula.Not); ok {
+			n, negated = n.Operands()[0], true
+		}
+		c, ok := n.Formula().(formula.Custom)
+		if !ok {
+			return 0, nil, false
+		}
+		// the components are built on the interned operands, so that interning them again does not walk the operands.
+		operands := make([]formula.Formula, len(n.Operands()))
+		for i, operand := range n.Operands() {
+			operands[i] = operand
+		}
+		d = c.Connective().Decomposition(negated)
+		components = d.Components(operands)
+		for i, component := range components {
+			components[i] = n.Factory().Intern(component)
+		}
+	} else {
+		if not, ok := f.(formula.Not); ok {
+			f, negated = not.Negated(), true
+		}
+		c, ok := f.(formula.Custom)
+		if !ok {
+			return 0, nil, false
+		}
+		d = c.Connective().Decomposition(negated)
+		components = d.Components(c.Operands())
+	}
+
+	if len(components) == 0 || len(components) == 1 && d.Class == formula.Beta {
+		panic(fmt.Errorf("the %v rule of %v has %d components", d.Class, f, len(components)))
+	}
+	return d.Class, components, true
+}
+
+// splitComponents returns the first component and the conjunction or the disjunction of the others, depending on the
+// class of the rule, interned by the factory of f if it is interned.
+func splitComponents(f formula.Formula, class formula.Classification,
+	components []formula.Formula) (formula.Formula, formula.Formula) {
+	if len(components) == 1 {
+		return components[0], nil
+	}
+
+	rest := formula.Disjunction(components[1:]...)
+	if class == formula.Alpha {
+		rest = formula.Conjunction(components[1:]...)
+	}
+	if n, ok := f.(*formula.Interned); ok {
+		rest = n.Factory().Intern(rest)
+	}
+	return components[0], rest
+}
+
+// iteRule returns the branches of the if-then-else, or of its negation if negated is true.
+func iteRule(f formula.Ite, negated bool) (formula.Formula, formula.Formula) {
+	then, els := f.Then(), f.Else()
+	if negated {
+		then, els = formula.NewNot(then), formula.NewNot(els)
+	}
+	return formula.NewAnd(f.Condition(), then), formula.NewAnd(formula.NewNot(f.Condition()), els)
+}
+
+// splitNAry returns the first operand of the n-ary formula and the formula of the other operands, which is n-ary
+// only if they are more than two.
+func splitNAry(f formula.NAry) (formula.Formula, formula.Formula) {
+	operands := f.Operands()
+	switch f.Op() {
+	case formula.And:
+		return operands[0], formula.Conjunction(operands[1:]...)
+	case formula.Or:
+		return operands[0], formula.Disjunction(operands[1:]...)
+	default:
+		return operands[0], formula.Parity(operands[1:]...)
+	}
+}
+
+// Expand applies the rule of the formula like ApplyRule, but it expands n-ary conjunctions and disjunctions at once:
+// for an alpha formula it returns the formulas to add to the branch and for a beta formula the formulas of the new
+// branches, one for every operand of an n-ary disjunction or of a negated n-ary conjunction and one for every
+// component of the Decomposition of a registered connective. For the other formulas it returns the results of
+// ApplyRule that are not nil. If the formula is interned, the resulting formulas are interned by the same factory.
+func Expand(f formula.Formula) []formula.Formula {
+	if _, components, ok := customComponents(f); ok {
+		return components
+	}
+
+	var operands []formula.Formula
+	negated := false
+
+	switch f := f.(type) {
+	case *formula.Interned:
+		inner := f
+		if _, ok := f.Formula().(formula.Not); ok {
+			inner, negated = f.Operands()[0], true
+		}
+		if n, ok := inner.Formula().(formula.NAry); !ok || n.Op() == formula.Xor {
+			break
+		}
+		for _, operand := range inner.Operands() {
+			if negated {
+				operand = f.Factory().Not(operand)
+			}
+			operands = append(operands, operand)
+		}
+		return operands
+	case formula.Not:
+		if inner, ok := f.Negated().(formula.NAry); ok && inner.Op() != formula.Xor {
+			for _, operand := range inner.Operands() {
+				operands = append(operands, formula.NewNot(operand))
+			}
+			return operands
+		}
+	case formula.NAry:
+		if f.Op() != formula.Xor {
+			return f.Operands()
+		}
+	}
+
+	left, right := ApplyRule(f)
+	if right == nil {
+		return []formula.Formula{left}
+	}
+	return []formula.Formula{left, right}
+}
+
+// leftPlaceholder and rightPlaceholder stand for the operands of an interned formula when applying the rules, so that
+// the rules are written once for plain and interned formulas.
+var leftPlaceholder, rightPlaceholder = formula.NewLetter("\x00left"), formula.NewLetter("\x00right")
+
+// applyInternedRule applies the rule to an interned formula, with the same cases of ApplyRule. The alpha and beta rules
+// are applied to the placeholders and the results are rebuilt with the factory of the formula: since the results
+// of a rule are at most three nodes deep, it takes constant time.
+func applyInternedRule(n *formula.Interned) (formula.Formula, formula.Formula) {
+	fac := n.Factory()
+	op, operands := formula.Operator(0), n.Operands()
+
+	switch f := n.Formula().(type) {
+	case formula.Not:
+		inner := operands[0]
+		switch innerFormula := inner.Formula().(type) {
+		case formula.Not:
+			return inner.Operands()[0], nil
+		case formula.Top:
+			return fac.Bottom(), nil
+		case formula.Bottom:
+			return fac.Top(), nil
+		case formula.Binary:
+			op, operands = innerFormula.Op(), inner.Operands()
+		case formula.NAry:
+			op, operands = innerFormula.Op(), splitInterned(inner)
+		case formula.Ite:
+			return internedIteRule(fac, inner.Operands(), true)
+		case formula.Cardinality:
+			return internedIteRule(fac, internedBranch(inner), true)
+		default:
+			panic(fmt.Errorf("cannot apply formula to %v: %T", inner, inner))
+		}
+	case formula.Binary:
+		op = f.Op()
+	case formula.NAry:
+		op, operands = f.Op(), splitInterned(n)
+	case formula.Ite:
+		return internedIteRule(fac, operands, false)
+	case formula.Cardinality:
+		return internedIteRule(fac, internedBranch(n), false)
+	default:
+		panic(fmt.Errorf("cannot apply formula to %v: %T", n, f))
+	}
+
+	left, right := applyAlphaOrBetaRule(op, n.Class(), leftPlaceholder, rightPlaceholder)
+	return rebuildInterned(fac, left, operands[0], operands[1]), rebuildInterned(fac, right, operands[0], operands[1])
+}
+
+// splitInterned is splitNAry for an interned n-ary formula: it returns the first operand and the formula of the
+// other operands, joined like Conjunction, Disjunction and Parity do.
+func splitInterned(n *formula.Interned) []*formula.Interned {
+	op, operands := n.Formula().(formula.NAry).Op(), n.Operands()
+	switch rest := operands[1:]; len(rest) {
+	case 1:
+		return []*formula.Interned{operands[0], rest[0]}
+	case 2:
+		return []*formula.Interned{operands[0], n.Factory().Binary(op, rest[0], rest[1])}
+	default:
+		return []*formula.Interned{operands[0], n.Factory().NAry(op, rest...)}
+	}
+}
+
+// internedIteRule is iteRule for an interned if-then-else, given its condition, then and else formulas.
+func internedIteRule(fac *formula.Factory, operands []*formula.Interned,
+	negated bool) (formula.Formula, formula.Formula) {
+	c, then, els := operands[0], operands[1], operands[2]
+	if negated {
+		then, els = fac.Not(then), fac.Not(els)
+	}
+	return fac.Binary(formula.And, c, then), fac.Binary(formula.And, fac.Not(c), els)
+}
+
+// internedBranch returns the condition, the then and the else formulas of the Branch of an interned cardinality
+// constraint, interned by its factory.
+func internedBranch(n *formula.Interned) []*formula.Interned {
+	fac, operands := n.Factory(), n.Operands()
+	branch := n.Formula().(formula.Cardinality).Branch()
+	// the constraints on the other operands reuse their interned operands.
+	rest := func(f formula.Formula) *formula.Interned {
+		if c, ok := f.(formula.Cardinality); ok {
+			return fac.Cardinality(c.Kind(), c.Bound(), operands[1:]...)
+		}
+		return fac.Intern(f)
+	}
+	return []*formula.Interned{operands[0], rest(branch.Then()), rest(branch.Else())}
+}
+
+// rebuildInterned interns the result of a rule replacing the placeholders with the operands l and r.
+func rebuildInterned(fac *formula.Factory, f formula.Formula, l, r *formula.Interned) *formula.Interned {
+	switch f := f.(type) {
+	case formula.Letter:
+		if f == leftPlaceholder {
+			return l
+		}
+		return r
+	case formula.Not:
+		return fac.Not(rebuildInterned(fac, f.Negated(), l, r))
+	case formula.Binary:
+		return fac.Binary(f.Op(), rebuildInterned(fac, f.Left(), l, r), rebuildInterned(fac, f.Right(), l, r))
+	default:
+		panic(fmt.Errorf("unexpected %v in the result of a rule", f))
+	}
+}
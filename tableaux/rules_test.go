@@ -3,6 +3,7 @@ package tableaux
 import (
 	"github.com/francodesource/propositional_tableaux/formula"
 	tu "github.com/francodesource/propositional_tableaux/internal/testutil"
+	"slices"
 	"testing"
 )
 
@@ -18,6 +19,47 @@ var B = formula.NewOr(
 	formula.NewAnd(tu.Q, tu.Q2),
 )
 
+// maj is the majority of three formulas and stroke is !(A & B), registered for the tests of the package: maj has
+// beta rules with three branches, stroke the default rules that replace it with its definition.
+var maj, stroke = registerConnectives()
+
+func registerConnectives() (string, string) {
+	majority := formula.Connective{
+		Name:  "maj",
+		Arity: 3,
+		Definition: func(operands []formula.Formula) formula.Formula {
+			a, b, c := operands[0], operands[1], operands[2]
+			return formula.NewNAry(formula.Or, formula.NewAnd(a, b), formula.NewAnd(a, c), formula.NewAnd(b, c))
+		},
+		Positive: formula.Decomposition{Class: formula.Beta, Components: func(operands []formula.Formula) []formula.Formula {
+			return pairs(operands[0], operands[1], operands[2])
+		}},
+		Negative: formula.Decomposition{Class: formula.Beta, Components: func(operands []formula.Formula) []formula.Formula {
+			return pairs(formula.NewNot(operands[0]), formula.NewNot(operands[1]), formula.NewNot(operands[2]))
+		}},
+	}
+	sheffer := formula.Connective{
+		Name:  "stroke",
+		Arity: 2,
+		Definition: func(operands []formula.Formula) formula.Formula {
+			return formula.NewNot(formula.NewAnd(operands[0], operands[1]))
+		},
+		Unicode: "⊼",
+		LaTeX:   `\barwedge`,
+	}
+	for _, c := range []formula.Connective{majority, sheffer} {
+		if err := formula.RegisterConnective(c); err != nil {
+			panic(err)
+		}
+	}
+	return majority.Name, sheffer.Name
+}
+
+// pairs returns the conjunctions of every two of the formulas.
+func pairs(a, b, c formula.Formula) []formula.Formula {
+	return []formula.Formula{formula.NewAnd(a, b), formula.NewAnd(a, c), formula.NewAnd(b, c)}
+}
+
 func TestApplyRule(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -30,6 +72,16 @@ func TestApplyRule(t *testing.T) {
 			input:    formula.NewNot(formula.NewNot(A)),
 			expected: [2]formula.Formula{A, nil},
 		},
+		{
+			name:     "negated top",
+			input:    formula.NewNot(formula.NewTop()),
+			expected: [2]formula.Formula{formula.NewBottom(), nil},
+		},
+		{
+			name:     "negated bottom",
+			input:    formula.NewNot(formula.NewBottom()),
+			expected: [2]formula.Formula{formula.NewTop(), nil},
+		},
 		{
 			name:     "and",
 			input:    formula.NewAnd(A, B),
@@ -87,6 +139,47 @@ func TestApplyRule(t *testing.T) {
 				formula.NewImplies(B, A),
 			},
 		},
+		{
+			name:  "n-ary and",
+			input: formula.NewNAry(formula.And, A, B, tu.R),
+			expected: [2]formula.Formula{
+				A,
+				formula.NewAnd(B, tu.R),
+			},
+		},
+		{
+			name: "negated n-ary or",
+			input: formula.NewNot(
+				formula.NewNAry(formula.Or, A, B, tu.R, tu.S)),
+			expected: [2]formula.Formula{
+				formula.NewNot(A),
+				formula.NewNot(formula.NewNAry(formula.Or, B, tu.R, tu.S)),
+			},
+		},
+		{
+			name:  "non-implication",
+			input: formula.NewNonImplies(A, B),
+			expected: [2]formula.Formula{
+				A,
+				formula.NewNot(B),
+			},
+		},
+		{
+			name:  "negated converse implication",
+			input: formula.NewNot(formula.NewConverseImplies(A, B)),
+			expected: [2]formula.Formula{
+				formula.NewNot(A),
+				B,
+			},
+		},
+		{
+			name:  "negated n-ary xor",
+			input: formula.NewNot(formula.NewNAry(formula.Xor, A, B, tu.R)),
+			expected: [2]formula.Formula{
+				formula.NewImplies(A, formula.NewXor(B, tu.R)),
+				formula.NewImplies(formula.NewXor(B, tu.R), A),
+			},
+		},
 		// BETA RULES
 		{
 			name: "negated and",
@@ -150,6 +243,97 @@ func TestApplyRule(t *testing.T) {
 				formula.NewNot(formula.NewImplies(B, A)),
 			},
 		},
+		{
+			name:  "converse implication",
+			input: formula.NewConverseImplies(A, B),
+			expected: [2]formula.Formula{
+				A,
+				formula.NewNot(B),
+			},
+		},
+		{
+			name:  "negated non-implication",
+			input: formula.NewNot(formula.NewNonImplies(A, B)),
+			expected: [2]formula.Formula{
+				formula.NewNot(A),
+				B,
+			},
+		},
+		{
+			name:  "n-ary xor",
+			input: formula.NewNAry(formula.Xor, A, B, tu.R),
+			expected: [2]formula.Formula{
+				formula.NewNot(formula.NewImplies(A, formula.NewXor(B, tu.R))),
+				formula.NewNot(formula.NewImplies(formula.NewXor(B, tu.R), A)),
+			},
+		},
+		{
+			name:  "if-then-else",
+			input: formula.NewIte(tu.P, A, B),
+			expected: [2]formula.Formula{
+				formula.NewAnd(tu.P, A),
+				formula.NewAnd(formula.NewNot(tu.P), B),
+			},
+		},
+		{
+			name:  "negated if-then-else",
+			input: formula.NewNot(formula.NewIte(tu.P, A, B)),
+			expected: [2]formula.Formula{
+				formula.NewAnd(tu.P, formula.NewNot(A)),
+				formula.NewAnd(formula.NewNot(tu.P), formula.NewNot(B)),
+			},
+		},
+		{
+			name:  "cardinality constraint",
+			input: formula.NewAtMost(1, A, B, tu.R),
+			expected: [2]formula.Formula{
+				formula.NewAnd(A, formula.NewAtMost(0, B, tu.R)),
+				formula.NewAnd(formula.NewNot(A), formula.NewAtMost(1, B, tu.R)),
+			},
+		},
+		{
+			name:  "negated cardinality constraint",
+			input: formula.NewNot(formula.NewExactly(1, A, B)),
+			expected: [2]formula.Formula{
+				formula.NewAnd(A, formula.NewNot(formula.NewExactly(0, B))),
+				formula.NewAnd(formula.NewNot(A), formula.NewNot(formula.NewExactly(1, B))),
+			},
+		},
+		{
+			name:  "cardinality constraint decided on a branch",
+			input: formula.NewAtLeast(2, A, B),
+			expected: [2]formula.Formula{
+				formula.NewAnd(A, formula.NewAtLeast(1, B)),
+				formula.NewAnd(formula.NewNot(A), formula.NewBottom()),
+			},
+		},
+		{
+			name:  "registered connective",
+			input: formula.NewCustom(maj, A, B, tu.R),
+			expected: [2]formula.Formula{
+				formula.NewAnd(A, B),
+				formula.NewOr(formula.NewAnd(A, tu.R), formula.NewAnd(B, tu.R)),
+			},
+		},
+		{
+			name:  "negated registered connective",
+			input: formula.NewNot(formula.NewCustom(maj, A, B, tu.R)),
+			expected: [2]formula.Formula{
+				formula.NewAnd(formula.NewNot(A), formula.NewNot(B)),
+				formula.NewOr(formula.NewAnd(formula.NewNot(A), formula.NewNot(tu.R)),
+					formula.NewAnd(formula.NewNot(B), formula.NewNot(tu.R))),
+			},
+		},
+		{
+			name:     "registered connective with the default rule",
+			input:    formula.NewCustom(stroke, A, B),
+			expected: [2]formula.Formula{formula.NewNot(formula.NewAnd(A, B)), nil},
+		},
+		{
+			name:     "negated registered connective with the default rule",
+			input:    formula.NewNot(formula.NewCustom(stroke, A, B)),
+			expected: [2]formula.Formula{formula.NewNot(formula.NewNot(formula.NewAnd(A, B))), nil},
+		},
 	}
 
 	for _, tt := range tests {
@@ -162,6 +346,87 @@ func TestApplyRule(t *testing.T) {
 			if right != tt.expected[1] {
 				t.Errorf("ApplyRule(%v) right = %v, want %v", tt.input, right, tt.expected[1])
 			}
+
+			// the rules applied to an interned formula give the interned results.
+			fac := formula.NewFactory()
+			left, right = ApplyRule(fac.Intern(tt.input))
+			if left != fac.Intern(tt.expected[0]) {
+				t.Errorf("ApplyRule(%v) interned left = %v, want %v", tt.input, left, tt.expected[0])
+			}
+
+			if tt.expected[1] == nil && right != nil || tt.expected[1] != nil && right != fac.Intern(tt.expected[1]) {
+				t.Errorf("ApplyRule(%v) interned right = %v, want %v", tt.input, right, tt.expected[1])
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    formula.Formula
+		expected []formula.Formula
+	}{
+		{
+			name:     "double negation",
+			input:    formula.NewNot(formula.NewNot(A)),
+			expected: []formula.Formula{A},
+		},
+		{
+			name:     "binary",
+			input:    formula.NewImplies(A, B),
+			expected: []formula.Formula{formula.NewNot(A), B},
+		},
+		{
+			name:     "n-ary and",
+			input:    formula.NewNAry(formula.And, A, B, tu.R),
+			expected: []formula.Formula{A, B, tu.R},
+		},
+		{
+			name:     "n-ary or",
+			input:    formula.NewNAry(formula.Or, A, B, tu.R, tu.S),
+			expected: []formula.Formula{A, B, tu.R, tu.S},
+		},
+		{
+			name:     "negated n-ary and",
+			input:    formula.NewNot(formula.NewNAry(formula.And, A, B, tu.R)),
+			expected: []formula.Formula{formula.NewNot(A), formula.NewNot(B), formula.NewNot(tu.R)},
+		},
+		{
+			name:  "n-ary xor",
+			input: formula.NewNAry(formula.Xor, A, B, tu.R),
+			expected: []formula.Formula{
+				formula.NewNot(formula.NewImplies(A, formula.NewXor(B, tu.R))),
+				formula.NewNot(formula.NewImplies(formula.NewXor(B, tu.R), A)),
+			},
+		},
+		{
+			name:     "registered connective",
+			input:    formula.NewCustom(maj, A, B, tu.R),
+			expected: pairs(A, B, tu.R),
+		},
+		{
+			name:     "registered connective with the default rule",
+			input:    formula.NewNot(formula.NewCustom(stroke, A, B)),
+			expected: []formula.Formula{formula.NewNot(formula.NewNot(formula.NewAnd(A, B)))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Expand(tt.input); !slices.Equal(got, tt.expected) {
+				t.Errorf("Expand(%v) = %v, want %v", tt.input, got, tt.expected)
+			}
+
+			fac := formula.NewFactory()
+			got := Expand(fac.Intern(tt.input))
+			want := make([]formula.Formula, len(tt.expected))
+			for i, f := range tt.expected {
+				want[i] = fac.Intern(f)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("Expand(%v) interned = %v, want %v", tt.input, got, want)
+			}
 		})
 	}
 }
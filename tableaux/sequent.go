@@ -0,0 +1,14 @@
+package tableaux
+
+import "github.com/francodesource/propositional_tableaux/formula"
+
+// Valid decides the sequent by building the buffered analytic tableau of its premises together with its negated
+// conclusions, that is of s.Formula(). The sequent is valid if the tableau is closed; otherwise Valid returns false and
+// the assignments of the open branches, which are the counterexamples: they satisfy all the premises and falsify all
+// the conclusions. If the construction is stopped by the options, Valid returns false, the counterexamples of the
+// partial tableau and the error of BuildBufferTableaux.
+func Valid(s formula.Sequent, opts ...Option) (bool, []Assignment, error) {
+	tab, err := BuildBufferTableaux(s.Formula(), opts...)
+	counterexamples := tab.Eval()
+	return err == nil && len(counterexamples) == 0, counterexamples, err
+}
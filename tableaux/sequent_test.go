@@ -0,0 +1,50 @@
+package tableaux
+
+import (
+	"errors"
+	"github.com/francodesource/propositional_tableaux/formula"
+	"reflect"
+	"testing"
+)
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		sequent         string
+		valid           bool
+		counterexamples []Assignment
+	}{
+		{"p, (p -> q) |- q", true, nil},
+		{"(p -> q), !q |- !p", true, nil},
+		{"|- p | !p", true, nil},
+		{"p, !p |-", true, nil},
+		{"p | q |- p, q", true, nil},
+		{"(p -> q), q |- p", false, []Assignment{{"p": false, "q": true}}},
+		{"p | q |- p", false, []Assignment{{"p": false, "q": true}}},
+		{"|-", false, []Assignment{{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sequent, func(t *testing.T) {
+			s, err := formula.ParseSequent(tt.sequent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			valid, counterexamples, err := Valid(s)
+			if err != nil || valid != tt.valid || !reflect.DeepEqual(counterexamples, tt.counterexamples) {
+				t.Errorf("Valid() = %v, %v, %v, want %v, %v", valid, counterexamples, err, tt.valid,
+					tt.counterexamples)
+			}
+		})
+	}
+}
+
+// TestValid_Limit checks that a stopped construction is not valid and returns the error of the builder.
+func TestValid_Limit(t *testing.T) {
+	s, err := formula.ParseSequent("p, (p -> q) |- q")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid, _, err := Valid(s, WithMaxNodes(1)); valid || !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Valid() = %v, %v, want false and ErrLimitExceeded", valid, err)
+	}
+}
@@ -0,0 +1,146 @@
+package tableaux
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/francodesource/propositional_tableaux/formula"
+	"maps"
+	"slices"
+)
+
+// Strategy decides which formula the builders expand next on a branch, so that the shape of a tableaux only depends
+// on the formula and on the strategy.
+type Strategy interface {
+	// Choose returns the index in candidates of the formula to expand. The candidates are the alpha and beta formulas
+	// of the branch that are not expanded yet and the branch holds all its formulas, literals included, both in the
+	// order in which they were added to the branch, and the formulas added by a rule in the order returned by ApplyRule
+	// or Expand. There is always at least one candidate.
+	// The formulas are interned by the factory of the tableaux, so equal formulas are the same pointer and Formula
+	// returns the plain formula. The slices are reused by the builders: Choose must not modify or retain them.
+	Choose(candidates, branch []*formula.Interned) int
+}
+
+// StrategyFunc is a function used as a Strategy.
+type StrategyFunc func(candidates, branch []*formula.Interned) int
+
+// Choose returns fn(candidates, branch).
+func (fn StrategyFunc) Choose(candidates, branch []*formula.Interned) int {
+	return fn(candidates, branch)
+}
+
+var (
+	// InsertionOrder expands the formulas in the order in which they were added to the branch.
+	InsertionOrder Strategy = StrategyFunc(insertionOrder)
+
+	// AlphaFirst expands the alpha formulas before the beta ones, so that the branches are split as late as possible,
+	// and among the formulas of the same class the least one by formula.Compare. It is the default strategy.
+	AlphaFirst Strategy = StrategyFunc(alphaFirst)
+
+	// SmallestFirst expands the formula with the fewest nodes, as counted by formula.Size, and the first added to the
+	// branch among the smallest ones.
+	SmallestFirst Strategy = StrategyFunc(smallestFirst)
+
+	// MostFrequentLetter expands the formula with the most occurrences of the letter that occurs most often in the
+	// candidates, the first by name among the letters with the same occurrences, and the first added to the branch
+	// among the formulas with the same occurrences.
+	MostFrequentLetter Strategy = StrategyFunc(mostFrequentLetter)
+
+	// ClosingBetaFirst expands first a beta formula that closes at least one of its branches, since a component is a
+	// false constant or the complement of a formula of the branch, and otherwise chooses as AlphaFirst.
+	ClosingBetaFirst Strategy = StrategyFunc(closingBetaFirst)
+)
+
+func insertionOrder([]*formula.Interned, []*formula.Interned) int {
+	return 0
+}
+
+func alphaFirst(candidates, _ []*formula.Interned) int {
+	res := 0
+	for i, f := range candidates[1:] {
+		best := candidates[res]
+		if f.Class() < best.Class() || f.Class() == best.Class() && formula.Compare(f, best) < 0 {
+			res = i + 1
+		}
+	}
+	return res
+}
+
+// firstMax returns the index of the first candidate with the greatest score.
+func firstMax[T cmp.Ordered](candidates []*formula.Interned, score func(f *formula.Interned) T) int {
+	res, best := 0, score(candidates[0])
+	for i, f := range candidates[1:] {
+		if s := score(f); s > best {
+			res, best = i+1, s
+		}
+	}
+	return res
+}
+
+func smallestFirst(candidates, _ []*formula.Interned) int {
+	return firstMax(candidates, func(f *formula.Interned) int { return -formula.Size(f) })
+}
+
+// occurrences returns the number of occurrences of the letter in the formula.
+func occurrences(f formula.Formula, letter string) int {
+	res := 0
+	for sub := range formula.Subformulas(f, formula.PreOrder) {
+		if l, ok := sub.(formula.Letter); ok && l.Name() == letter {
+			res++
+		}
+	}
+	return res
+}
+
+func mostFrequentLetter(candidates, _ []*formula.Interned) int {
+	counts := make(map[string]int)
+	for _, f := range candidates {
+		for sub := range formula.Subformulas(f, formula.PreOrder) {
+			if l, ok := sub.(formula.Letter); ok {
+				counts[l.Name()]++
+			}
+		}
+	}
+	if len(counts) == 0 {
+		return 0 // the candidates are made of constants.
+	}
+
+	letters := slices.Sorted(maps.Keys(counts))
+	letter := letters[0]
+	for _, l := range letters[1:] {
+		if counts[l] > counts[letter] {
+			letter = l
+		}
+	}
+	return firstMax(candidates, func(f *formula.Interned) int { return occurrences(f, letter) })
+}
+
+func closingBetaFirst(candidates, branch []*formula.Interned) int {
+	formulas := make(map[*formula.Interned]bool, len(branch))
+	for _, f := range branch {
+		formulas[f] = true
+	}
+	// the components of an interned formula are interned, so they are found in the branch by pointer.
+	closes := func(f formula.Formula) bool {
+		n := f.(*formula.Interned)
+		return formula.IsConstant(n) && !formula.AsConstant(n) || formulas[n.Complement()]
+	}
+
+	for i, f := range candidates {
+		if f.Class() == formula.Beta && slices.ContainsFunc(Expand(f), closes) {
+			return i
+		}
+	}
+	return alphaFirst(candidates, branch)
+}
+
+// choose asks the strategy which of the candidates of the branch to expand next, returning nil if there is none.
+func choose(strategy Strategy, b *branch) *formula.Interned {
+	if len(b.candidates) == 0 {
+		return nil
+	}
+	i := strategy.Choose(b.candidates, b.formulas)
+	if i < 0 || i >= len(b.candidates) {
+		panic(fmt.Errorf("the strategy chose the candidate %d of %d", i, len(b.candidates)))
+	}
+	return b.candidates[i]
+}
@@ -0,0 +1,201 @@
+package tableaux
+
+import (
+	"fmt"
+	"github.com/francodesource/propositional_tableaux/formula"
+	tu "github.com/francodesource/propositional_tableaux/internal/testutil"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+var strategies = map[string]Strategy{
+	"InsertionOrder":     InsertionOrder,
+	"AlphaFirst":         AlphaFirst,
+	"SmallestFirst":      SmallestFirst,
+	"MostFrequentLetter": MostFrequentLetter,
+	"ClosingBetaFirst":   ClosingBetaFirst,
+}
+
+func TestStrategy_Choose(t *testing.T) {
+	pOrQ, rAndS, pAndQ, qAndR := formula.NewOr(tu.P, tu.Q), formula.NewAnd(tu.R, tu.S), formula.NewAnd(tu.P, tu.Q),
+		formula.NewAnd(tu.Q, tu.R)
+	qOrR := formula.NewOr(tu.Q, tu.R)
+
+	tests := []struct {
+		name       string
+		strategy   Strategy
+		candidates []formula.Formula
+		literals   []formula.Formula
+		want       int
+	}{
+		{"insertion order", InsertionOrder, []formula.Formula{pOrQ, rAndS}, nil, 0},
+		{"alpha first", AlphaFirst, []formula.Formula{pOrQ, rAndS}, nil, 1},
+		{"alpha first by formula.Compare", AlphaFirst, []formula.Formula{qAndR, pOrQ, pAndQ}, nil, 2},
+		{"beta by formula.Compare", AlphaFirst, []formula.Formula{qOrR, pOrQ}, nil, 1},
+		{"smallest first", SmallestFirst, []formula.Formula{formula.NewOr(pAndQ, tu.R), pOrQ, qOrR}, nil, 1},
+		{"most frequent letter", MostFrequentLetter,
+			[]formula.Formula{pOrQ, formula.NewOr(tu.S, tu.R), formula.NewOr(tu.R, formula.NewAnd(tu.S, tu.R))}, nil, 2},
+		{"most frequent letter by name", MostFrequentLetter, []formula.Formula{rAndS, pOrQ}, nil, 1},
+		{"only constants", MostFrequentLetter, []formula.Formula{formula.NewNot(formula.NewAnd(formula.Top{},
+			formula.Bottom{}))}, nil, 0},
+		{"closing beta first", ClosingBetaFirst, []formula.Formula{pAndQ, qOrR}, []formula.Formula{formula.NewNot(tu.R)},
+			1},
+		{"false constant", ClosingBetaFirst, []formula.Formula{pAndQ, formula.NewOr(tu.Q, formula.Bottom{})}, nil, 1},
+		{"no closing beta", ClosingBetaFirst, []formula.Formula{qOrR, pAndQ}, []formula.Formula{tu.R}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fac := formula.NewFactory()
+			intern := func(fs []formula.Formula) []*formula.Interned {
+				var res []*formula.Interned
+				for _, f := range fs {
+					res = append(res, fac.Intern(f))
+				}
+				return res
+			}
+			candidates := intern(tt.candidates)
+			branch := append(intern(tt.literals), candidates...)
+			if got := tt.strategy.Choose(candidates, branch); got != tt.want {
+				t.Errorf("Choose(%v, %v) = %v, want %v", candidates, branch, got, tt.want)
+			}
+		})
+	}
+}
+
+// countNodes returns the number of nodes of the tableaux.
+func countNodes(tab Node) int {
+	res := 1
+	for _, child := range Children(tab) {
+		res += countNodes(child)
+	}
+	return res
+}
+
+// TestWithStrategy checks that the strategy decides the shape of the tableaux: expanding the conjunction before the
+// disjunction saves a node.
+func TestWithStrategy(t *testing.T) {
+	f := formula.NewAnd(formula.NewOr(tu.P, tu.Q), formula.NewAnd(tu.R, tu.S))
+	tests := []struct {
+		name string
+		tab  Node
+		want int
+	}{
+		{"semantic default", must(BuildSemanticTableaux(f)), 5},
+		{"semantic insertion order", must(BuildSemanticTableaux(f, WithStrategy(InsertionOrder))), 6},
+		{"analytic default", must(BuildAnalyticTableaux(f)), 5},
+		{"analytic insertion order", must(BuildAnalyticTableaux(f, WithStrategy(InsertionOrder))), 6},
+		{"buffer default", must(BuildBufferTableaux(f)), 5},
+		{"buffer insertion order", must(BuildBufferTableaux(f, WithStrategy(InsertionOrder))), 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countNodes(tt.tab); got != tt.want {
+				t.Errorf("the tableaux has %d nodes, want %d:\n%v", got, tt.want, DefaultAsciiTree(tt.tab))
+			}
+		})
+	}
+
+	last := StrategyFunc(func(candidates, _ []*formula.Interned) int { return len(candidates) - 1 })
+	if got := countNodes(must(BuildSemanticTableaux(f, WithStrategy(last)))); got != 5 {
+		t.Errorf("the tableaux built with a StrategyFunc has %d nodes, want 5", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("BuildSemanticTableaux did not panic with a strategy that returns an invalid index")
+		}
+	}()
+	must(BuildSemanticTableaux(f, WithStrategy(StrategyFunc(func(candidates, _ []*formula.Interned) int {
+		return len(candidates)
+	}))))
+}
+
+// TestStrategies_TruthTables checks that every kind of tableaux finds the same assignments as the truth tables with
+// every strategy, and that building it again gives the same tableaux.
+func TestStrategies_TruthTables(t *testing.T) {
+	f := func(f formula.Formula) bool {
+		for name, strategy := range strategies {
+			builders := map[string]func() Node{
+				"semantic": func() Node { return must(BuildSemanticTableaux(f, WithStrategy(strategy))) },
+				"analytic": func() Node { return must(BuildAnalyticTableaux(f, WithStrategy(strategy))) },
+				"buffer":   func() Node { return must(BuildBufferTableaux(f, WithStrategy(strategy))) },
+			}
+			for kind, build := range builders {
+				tab := build()
+				if !compareTableauxWithTruthTables(t, f, tab) {
+					t.Errorf("%s tableaux with %s", kind, name)
+					return false
+				}
+				if fmt.Sprint(tab) != fmt.Sprint(build()) {
+					t.Errorf("%s tableaux with %s: two builds of %v are different", kind, name, f)
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		MaxCount: 50,
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(tu.GenerateRandomNAry(r, r.Intn(FormulaMaxSize/2)))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestStrategy_Candidates checks that every builder passes to the strategy distinct alpha and beta formulas of the
+// branch, interned by the same factory of the branch.
+func TestStrategy_Candidates(t *testing.T) {
+	var err error
+	check := StrategyFunc(func(candidates, branch []*formula.Interned) int {
+		inBranch := make(map[*formula.Interned]bool)
+		for _, f := range branch {
+			inBranch[f] = true
+		}
+		seen := make(map[*formula.Interned]bool)
+		for _, f := range candidates {
+			switch {
+			case seen[f]:
+				err = fmt.Errorf("%v is a candidate twice in %v", f, candidates)
+			case !inBranch[f]:
+				err = fmt.Errorf("the candidate %v is not in the branch %v", f, branch)
+			case f.Class() == formula.LiteralClass || formula.IsConstant(f):
+				err = fmt.Errorf("the candidate %v cannot be expanded", f)
+			case f.Factory() != branch[0].Factory():
+				err = fmt.Errorf("the candidate %v is interned by another factory", f)
+			}
+			seen[f] = true
+		}
+		return AlphaFirst.Choose(candidates, branch)
+	})
+
+	f := func(f formula.Formula) bool {
+		must(BuildSemanticTableaux(f, WithStrategy(check)))
+		must(BuildAnalyticTableaux(f, WithStrategy(check)))
+		must(BuildBufferTableaux(f, WithStrategy(check)))
+		if err != nil {
+			t.Errorf("%v: %v", f, err)
+			return false
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		MaxCount: 50,
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(tu.GenerateRandomNAry(r, r.Intn(FormulaMaxSize/2)))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
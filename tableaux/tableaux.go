@@ -1,3 +1,5 @@
+// Package tableaux builds the semantic, analytic and buffered analytic tableaux of propositional formulas and prints
+// them as ASCII art, with Unicode characters or as LaTeX forest trees.
 package tableaux
 
 import (
@@ -6,18 +8,19 @@ import (
 	"github.com/francodesource/propositional_tableaux/tableaux/tsets"
 	"github.com/m1gwings/treedrawer/tree"
 	"iter"
-	"maps"
 	"slices"
 	"strings"
 )
 
-// Mark represents the mark of a tableaux node. It can be Unmarked, Closed or Open.
+// Mark represents the mark of a tableaux node. It can be Unmarked, Closed or Open, or Unfinished for a leaf that was
+// not expanded since the construction was stopped by a limit or by its context.
 type Mark byte
 
 const (
 	Unmarked Mark = iota
 	Closed
 	Open
+	Unfinished
 )
 
 func (m Mark) String() string {
@@ -28,6 +31,8 @@ func (m Mark) String() string {
 		return "Closed"
 	case Open:
 		return "Open"
+	case Unfinished:
+		return "Unfinished"
 	default:
 		panic("unknown type of mark")
 	}
@@ -44,6 +49,79 @@ type Node interface {
 	Eval() []Assignment
 }
 
+// ChildrenNode is a Node that can have more than two children, like the nodes of a semantic tableaux, that expand
+// n-ary formulas at once. The nodes of this package implement it.
+type ChildrenNode interface {
+	Node
+	Children() []Node
+}
+
+// UnfinishedNode is a Node that can be an Unfinished leaf of a tableaux whose construction was stopped by a limit or
+// by its context. The nodes of this package implement it.
+type UnfinishedNode interface {
+	Node
+	IsUnfinished() bool
+}
+
+// Children returns the children of the node from left to right: the ones returned by its Children method if it is a
+// ChildrenNode, and otherwise its Left and Right children that are not nil.
+func Children(node Node) []Node {
+	if n, ok := node.(ChildrenNode); ok {
+		return n.Children()
+	}
+	var res []Node
+	for _, child := range []Node{node.Left(), node.Right()} {
+		if child != nil {
+			res = append(res, child)
+		}
+	}
+	return res
+}
+
+// IsUnfinished checks if the node is an unfinished leaf, which it can be only if it is an UnfinishedNode.
+func IsUnfinished(node Node) bool {
+	n, ok := node.(UnfinishedNode)
+	return ok && n.IsUnfinished()
+}
+
+// plainFormulas returns an iterator over the plain version of the formulas: the builders intern the formulas with a
+// formula.Factory, so that sets and visited checks do not walk them, but the nodes return them as plain formulas.
+func plainFormulas(fs iter.Seq[formula.Formula]) iter.Seq[formula.Formula] {
+	return func(yield func(formula.Formula) bool) {
+		for f := range fs {
+			if n, ok := f.(*formula.Interned); ok {
+				f = n.Formula()
+			}
+			if !yield(f) {
+				return
+			}
+		}
+	}
+}
+
+// sortedFormulas returns an iterator over the formulas of the set by class, the literals first and then the alpha and
+// the beta formulas, each in the order of formula.Compare, so that the nodes print their formulas in the same order in
+// every run, while the builders iterate the sets without sorting them.
+func sortedFormulas(set tsets.TSet) iter.Seq[formula.Formula] {
+	sorted := func(fs iter.Seq[formula.Formula]) iter.Seq[formula.Formula] {
+		return slices.Values(slices.SortedFunc(fs, formula.Compare))
+	}
+	return plainFormulas(combineIterators(sorted(set.IterLiterals()), sorted(set.IterAlpha()), sorted(set.IterBeta())))
+}
+
+// leastUnvisited returns the formula of the class among fs that is not visited and is the least by formula.Compare,
+// the one AlphaFirst expands among the formulas of the same class, or nil if there is none.
+func leastUnvisited(fs iter.Seq[formula.Formula], class formula.Classification,
+	visited map[formula.Formula]bool) formula.Formula {
+	var res formula.Formula
+	for f := range fs {
+		if f.Class() == class && !visited[f] && (res == nil || formula.Compare(f, res) < 0) {
+			res = f
+		}
+	}
+	return res
+}
+
 func combineIterators[T any](iters ...iter.Seq[T]) iter.Seq[T] {
 	return func(yield func(t T) bool) {
 		for _, it := range iters {
@@ -52,11 +130,12 @@ func combineIterators[T any](iters ...iter.Seq[T]) iter.Seq[T] {
 	}
 }
 
-// SemanticNode represents a node in a semantic tableaux.
+// SemanticNode represents a node in a semantic tableaux. A node has one child after an alpha rule and one child for
+// every branch after a beta rule: two for a binary formula, one for every operand of an n-ary one.
 type SemanticNode struct {
-	formulas    tsets.TSet
-	left, right *SemanticNode
-	mark        Mark
+	formulas tsets.TSet
+	children []*SemanticNode
+	mark     Mark
 }
 
 // IsClosed returns true if the node is marked as closed.
@@ -69,25 +148,40 @@ func (node *SemanticNode) IsOpen() bool {
 	return node.mark == Open
 }
 
+// IsUnfinished returns true if the node is marked as unfinished.
+func (node *SemanticNode) IsUnfinished() bool {
+	return node.mark == Unfinished
+}
+
 // Left returns the left child node. Returns nil if no left child exists.
 func (node *SemanticNode) Left() Node {
-	if node.left == nil {
+	if len(node.children) == 0 {
 		return nil
 	}
-	return node.left
+	return node.children[0]
 }
 
-// Right returns the right child node. Returns nil if no right child exists.
+// Right returns the right child node, which is the last one if the node has more than two children.
+// Returns nil if no right child exists.
 func (node *SemanticNode) Right() Node {
-	if node.right == nil {
+	if len(node.children) < 2 {
 		return nil
 	}
-	return node.right
+	return node.children[len(node.children)-1]
+}
+
+// Children returns the child nodes from left to right.
+func (node *SemanticNode) Children() []Node {
+	res := make([]Node, len(node.children))
+	for i, child := range node.children {
+		res[i] = child
+	}
+	return res
 }
 
 // Formulas returns an iterator over all formulas contained in the current node.
 func (node *SemanticNode) Formulas() iter.Seq[formula.Formula] {
-	return combineIterators(node.formulas.IterLiterals(), node.formulas.IterAlpha(), node.formulas.IterBeta())
+	return sortedFormulas(node.formulas)
 }
 
 func indentOf(s string, size int) string {
@@ -110,12 +204,15 @@ func (node *SemanticNode) String() string {
 	var res string
 
 	res = "{\n  values: " + node.formulas.String()
-	if node.left != nil {
-		res += "\n  left: " + indentOf(node.left.String(), 3)
-	}
-
-	if node.right != nil {
-		res += "\n  right: " + indentOf(node.right.String(), 3)
+	for i, child := range node.children {
+		switch {
+		case i == 0:
+			res += "\n  left: " + indentOf(child.String(), 3)
+		case i == len(node.children)-1:
+			res += "\n  right: " + indentOf(child.String(), 3)
+		default:
+			res += "\n  middle: " + indentOf(child.String(), 3)
+		}
 	}
 
 	if node.mark != Unmarked {
@@ -128,7 +225,7 @@ func (node *SemanticNode) String() string {
 
 // IsLeaf returns true if the node is a leaf.
 func (node *SemanticNode) IsLeaf() bool {
-	return node.left == nil && node.right == nil
+	return len(node.children) == 0
 }
 
 // Height returns the height of the subtree rooted at the current node.
@@ -137,7 +234,11 @@ func (node *SemanticNode) Height() int {
 		return 0
 	}
 
-	return 1 + max(node.left.Height(), node.right.Height())
+	res := 0
+	for _, child := range node.children {
+		res = max(res, child.Height())
+	}
+	return 1 + res
 }
 
 // MarkAsClosed marks the node as closed.
@@ -150,6 +251,11 @@ func (node *SemanticNode) MarkAsOpen() {
 	node.mark = Open
 }
 
+// MarkAsUnfinished marks the node as unfinished.
+func (node *SemanticNode) MarkAsUnfinished() {
+	node.mark = Unfinished
+}
+
 // Assignment represents a truth assignment for propositional letters. If a propositional letter is missing,
 // it can be assigned either true beta_or false, as it does not affect the evaluation of the formulas.
 type Assignment map[string]bool
@@ -189,41 +295,37 @@ func normalizeAssignment(a Assignment) string {
 	return "[" + res.String() + "]"
 }
 
-// CleanAssignments returns a new slice without assignments that are superset of some of the other assignments.
+// CleanAssignments returns a new slice without repeated assignments and without assignments that are a strict
+// superset of some of the other assignments. The result does not depend on the order of the given assignments,
+// except for the order of the result itself.
 func CleanAssignments(assignments []Assignment) []Assignment {
 	var res []Assignment
 	var occurrencies = make(map[string]bool)
 
 outer:
-	for i, a1 := range assignments {
-		isSuperSetOfAny := false
-		for j, a2 := range assignments {
-			if i != j {
-				// I need to check if the assignments are equal to avoid that two equal assignments are
-				// detected as superset one of each others and no one is added to res.
-				if maps.Equal(a1, a2) {
-					na := normalizeAssignment(a1) // I normalize it to use this as map key.
-					if !occurrencies[na] {        // This means I can add one assignment one time
-						res = append(res, a1)
-						occurrencies[na] = true
-					}
-					continue outer // Here I skip to next element
-				} else if a1.IsSupersetOf(a2) { // If it is a superset I do not add it
-					isSuperSetOfAny = true
-					break
-				}
-			}
+	for _, a1 := range assignments {
+		na := normalizeAssignment(a1) // I normalize it to use this as map key.
+		if occurrencies[na] {
+			continue // This means I add an assignment one time
 		}
-		if !isSuperSetOfAny {
-			res = append(res, a1)
+
+		for _, a2 := range assignments {
+			// an assignment with fewer letters is different, so a1 is a strict superset of it.
+			if len(a2) < len(a1) && a1.IsSupersetOf(a2) {
+				continue outer
+			}
 		}
+
+		occurrencies[na] = true
+		res = append(res, a1)
 	}
 	return res
 }
 
 func eval(node *SemanticNode) []Assignment {
 	if node.IsLeaf() {
-		if node.mark == Closed {
+		// an unfinished leaf may still be closed by the rules that were not applied.
+		if node.mark == Closed || node.mark == Unfinished {
 			return []Assignment{}
 		}
 		assignment := make(Assignment)
@@ -244,24 +346,118 @@ func eval(node *SemanticNode) []Assignment {
 	}
 
 	var res []Assignment
-	if node.left != nil {
-		res = eval(node.left)
-	}
-
-	if node.right != nil {
-		res = append(res, eval(node.right)...)
+	for _, child := range node.children {
+		res = append(res, eval(child)...)
 	}
 	return res
 }
 
-// Eval evaluates the semantic tableaux and returns a slice of assignments that satisfy the formulas.
+// Eval evaluates the semantic tableaux and returns a slice of assignments that satisfy the formulas. The unfinished
+// leaves of a tableaux whose construction was stopped give no assignments, so that the assignments of its open leaves
+// satisfy the formulas, but there can be others.
 func (node *SemanticNode) Eval() []Assignment {
 	return CleanAssignments(eval(node))
 	// It is needed to clean the assignments since different kind of tableaux can produce
 	// redundant assignments. It is important for testing that assignments does not contain redundant assignments.
 }
 
-func buildSemanticTableaux(node *SemanticNode) {
+// branch holds the formulas of the branch of the node being expanded, interned and in the order in which they were
+// added, and the candidates among them, that are the alpha and beta formulas that are not expanded yet. The builders
+// visit the tableaux depth first, so a single branch is updated while going down a path and restored with undo and
+// restore before expanding the sibling of a node.
+type branch struct {
+	formulas, candidates []*formula.Interned
+	members              map[*formula.Interned]bool
+}
+
+// mark is the state of a branch to go back to with undo.
+type mark struct {
+	formulas, candidates int
+}
+
+// removal records the positions from which a formula was removed from a branch, -1 if it was not removed.
+type removal struct {
+	f                  *formula.Interned
+	formula, candidate int
+}
+
+func newBranch() *branch {
+	return &branch{members: make(map[*formula.Interned]bool)}
+}
+
+// add adds the formulas that are not already in the branch, skipping nil and the true constants as tsets.TSet.Add.
+// The formulas must be interned.
+func (b *branch) add(fs ...formula.Formula) {
+	for _, f := range fs {
+		if f == nil || formula.IsConstant(f) && formula.AsConstant(f) {
+			continue
+		}
+		n := f.(*formula.Interned)
+		if b.members[n] {
+			continue
+		}
+		b.members[n] = true
+		b.formulas = append(b.formulas, n)
+		if !formula.IsConstant(n) && n.Class() != formula.LiteralClass {
+			b.candidates = append(b.candidates, n)
+		}
+	}
+}
+
+// mark returns the current state of the branch.
+func (b *branch) mark() mark {
+	return mark{len(b.formulas), len(b.candidates)}
+}
+
+// undo removes the formulas added after m, that must be a state of the branch taken after the last remove that
+// was not restored.
+func (b *branch) undo(m mark) {
+	for _, f := range b.formulas[m.formulas:] {
+		delete(b.members, f)
+	}
+	b.formulas = b.formulas[:m.formulas]
+	b.candidates = b.candidates[:m.candidates]
+}
+
+// remove removes the expanded formula from the candidates, and from the formulas too if all is true, since the
+// semantic tableaux remove the expanded formulas from the nodes.
+func (b *branch) remove(f *formula.Interned, all bool) removal {
+	r := removal{f, -1, slices.Index(b.candidates, f)}
+	b.candidates = slices.Delete(b.candidates, r.candidate, r.candidate+1)
+	if all {
+		r.formula = slices.Index(b.formulas, f)
+		b.formulas = slices.Delete(b.formulas, r.formula, r.formula+1)
+		delete(b.members, f)
+	}
+	return r
+}
+
+// restore puts back the formula removed by remove, after undoing the changes made after it.
+func (b *branch) restore(r removal) {
+	b.candidates = slices.Insert(b.candidates, r.candidate, r.f)
+	if r.formula >= 0 {
+		b.formulas = slices.Insert(b.formulas, r.formula, r.f)
+		b.members[r.f] = true
+	}
+}
+
+// retry makes the formulas of the branch among fs candidates again, in the order of the branch, so that the rule of
+// a formula added again to the branch by a rule after it was expanded is applied again.
+func (b *branch) retry(fs iter.Seq[formula.Formula]) {
+	again := make(map[formula.Formula]bool)
+	for f := range fs {
+		again[f] = true
+	}
+	for _, f := range b.formulas {
+		if again[f] && !formula.IsConstant(f) && f.Class() != formula.LiteralClass {
+			b.candidates = append(b.candidates, f)
+		}
+	}
+}
+
+// buildSemanticTableaux expands the node at the given depth, whose formulas are the ones of the branch, choosing the
+// formula to expand with the strategy of the construction.
+func buildSemanticTableaux(node *SemanticNode, b *branch, depth int, c *construction) {
 
 	if node.formulas.HasOnlyLiterals() && node.formulas.HasComplementaryLiterals() {
 		node.MarkAsClosed()
@@ -269,83 +465,118 @@ func buildSemanticTableaux(node *SemanticNode) {
 	}
 
 	// Here the condition is that the set is composed of all literals alpha_and
-	// there is not a complementary pair of literals
-	if node.formulas.HasOnlyLiterals() {
+	// there is not a complementary pair of literals. The set can also be empty when it contained only ⊤.
+	if !node.formulas.HasAlpha() && !node.formulas.HasBeta() {
 		node.MarkAsOpen()
 		return
 	}
 
 	// If it reaches here U(l) contains non-literals
-	// First check for alpha formulas
-	if node.formulas.HasAlpha() {
-		for alpha := range node.formulas.IterAlpha() {
-			left, right := ApplyRule(alpha)
-			newSet := tsets.RemoveAlpha(node.formulas, alpha)
-			hasComplement := newSet.Add(left, right)
-
-			node.left = &SemanticNode{
-				formulas: newSet,
-			}
-
-			if !hasComplement {
-				buildSemanticTableaux(node.left)
-			} else {
-				node.left.MarkAsClosed()
-			}
-
-			return
-		}
-	} else if node.formulas.HasBeta() {
-		for beta := range node.formulas.IterBeta() {
-			left, right := ApplyRule(beta)
+	f := choose(c.strategy, b)
+	components := Expand(f)
+	children := len(components)
+	if f.Class() == formula.Alpha {
+		children = 1
+	}
+	if !c.expand(depth, children) {
+		node.MarkAsUnfinished()
+		return
+	}
 
-			leftSet := tsets.RemoveBeta(node.formulas, beta)
-			hasLeftComplement := leftSet.Add(left)
+	r := b.remove(f, true)
+	defer b.restore(r)
+	m := b.mark()
 
-			rightSet := tsets.RemoveBeta(node.formulas, beta)
-			hasRightComplement := rightSet.Add(right)
+	if f.Class() == formula.Alpha {
+		newSet := tsets.RemoveAlpha(node.formulas, f)
+		hasComplement := newSet.Add(components...)
 
-			node.left = &SemanticNode{
-				formulas: leftSet,
-			}
+		child := &SemanticNode{
+			formulas: newSet,
+		}
+		node.children = []*SemanticNode{child}
 
-			node.right = &SemanticNode{
-				formulas: rightSet,
-			}
+		if !hasComplement {
+			b.add(components...)
+			buildSemanticTableaux(child, b, depth+1, c)
+			b.undo(m)
+		} else {
+			child.MarkAsClosed()
+		}
+		return
+	}
 
-			if !hasLeftComplement {
-				buildSemanticTableaux(node.left)
-			} else {
-				node.left.MarkAsClosed()
-			}
+	// every branch gets one of the formulas of the rule, that are two for a binary formula and more for an
+	// n-ary one.
+	for _, component := range components {
+		set := tsets.RemoveBeta(node.formulas, f)
+		hasComplement := set.Add(component)
 
-			if !hasRightComplement {
-				buildSemanticTableaux(node.right)
-			} else {
-				node.right.MarkAsClosed()
-			}
+		child := &SemanticNode{
+			formulas: set,
+		}
+		node.children = append(node.children, child)
 
-			return
+		if !hasComplement {
+			b.add(component)
+			buildSemanticTableaux(child, b, depth+1, c)
+			b.undo(m)
+		} else {
+			child.MarkAsClosed()
 		}
 	}
 }
 
-// BuildSemanticTableaux builds a semantic tableaux for the given formula and returns the root node.
-func BuildSemanticTableaux(f formula.Formula) *SemanticNode {
+// BuildSemanticTableaux builds a semantic tableaux for the given formula and returns the root node. The formula to
+// expand in every node is chosen by the strategy of the options, AlphaFirst if there is none. If the construction is
+// stopped by a limit or by the context of the options, it returns the partial tableaux, whose unexpanded leaves are
+// marked as Unfinished, and an error wrapping ErrLimitExceeded or the error of the context.
+func BuildSemanticTableaux(f formula.Formula, opts ...Option) (*SemanticNode, error) {
+	c, cancel := newConstruction(opts)
+	defer cancel()
+
 	node := &SemanticNode{
 		formulas: tsets.NewTSet(),
 	}
+	f = formula.NewFactory().Intern(f)
 	node.formulas.Add(f)
-	buildSemanticTableaux(node)
+	b := newBranch()
+	b.add(f)
+	buildSemanticTableaux(node, b, 1, c)
 
-	return node
+	return node, c.err
 }
 
 // FormulaDrawer is a function that takes a formula and returns a string representation.
 type FormulaDrawer func(f formula.Formula) string
 
-// MarkDrawer is a function that takes a boolean indicating if the node is open and returns a string representation.
-type MarkDrawer func(open bool) string
+// Abbreviated returns a FormulaDrawer that writes the formulas with fd, after replacing their subformulas that have an
+// abbreviation with its name, as formula.Abbreviations.Abbreviate does: for example, with the abbreviations returned
+// by formula.ParseAbbreviated, AsciiTree(tableaux, Abbreviated(UnicodeFormula, abbreviations), md) prints A instead
+// of its definition (p ∧ q).
+func Abbreviated(fd FormulaDrawer, abbreviations formula.Abbreviations) FormulaDrawer {
+	return func(f formula.Formula) string {
+		return fd(abbreviations.Abbreviate(f, fd))
+	}
+}
+
+// MarkDrawer is a function that takes the mark of a leaf, Open, Closed or Unfinished, and returns a string
+// representation.
+type MarkDrawer func(mark Mark) string
+
+// leafMark returns the mark of the leaf.
+func leafMark(leaf Node) Mark {
+	switch {
+	case leaf.IsOpen():
+		return Open
+	case leaf.IsClosed():
+		return Closed
+	case IsUnfinished(leaf):
+		return Unfinished
+	default:
+		return Unmarked
+	}
+}
 
 func formulasString(fs iter.Seq[formula.Formula], fd FormulaDrawer) string {
 	var sb strings.Builder
@@ -374,19 +605,13 @@ func asciiTree(tableaux Node, t *tree.Tree, fd FormulaDrawer, md MarkDrawer) {
 	lenValue := len([]rune(value)) // using this to have correct size of Unicode strings
 	// Adding a separator
 	if tableaux.IsLeaf() {
-		value += "\n" + strings.Repeat("-", lenValue) + "\n" + centerWRT(md(tableaux.IsOpen()), lenValue)
+		value += "\n" + strings.Repeat("-", lenValue) + "\n" + centerWRT(md(leafMark(tableaux)), lenValue)
 	}
 
 	t.SetVal(tree.NodeString(value))
 
-	if tableaux.Left() != nil {
-		left := t.AddChild(tree.NodeString(""))
-		asciiTree(tableaux.Left(), left, fd, md)
-	}
-
-	if tableaux.Right() != nil {
-		right := t.AddChild(tree.NodeString(""))
-		asciiTree(tableaux.Right(), right, fd, md)
+	for _, child := range Children(tableaux) {
+		asciiTree(child, t.AddChild(tree.NodeString("")), fd, md)
 	}
 }
 
@@ -398,20 +623,28 @@ func AsciiTree(tableaux Node, fd FormulaDrawer, md MarkDrawer) *tree.Tree {
 	return res
 }
 
-// DefaultAsciiTree return an ASCII art representation of the tableaux where everything is represented as an ascii character.
-func DefaultAsciiTree(tableaux Node) *tree.Tree {
-	fd := func(f formula.Formula) string {
-		return f.String()
-	}
+// AsciiFormula is a FormulaDrawer that writes the formula with ASCII characters, like (p & !q), except for the
+// constants ⊤ and ⊥, as String does.
+func AsciiFormula(f formula.Formula) string {
+	return f.String()
+}
 
-	md := func(open bool) string {
-		if open {
-			return "OPEN"
-		}
+// AsciiMark is a MarkDrawer that writes OPEN for an open leaf, CLOSE for a closed one and UNFINISHED for an unfinished
+// one.
+func AsciiMark(mark Mark) string {
+	switch mark {
+	case Open:
+		return "OPEN"
+	case Unfinished:
+		return "UNFINISHED"
+	default:
 		return "CLOSE"
 	}
+}
 
-	return AsciiTree(tableaux, fd, md)
+// DefaultAsciiTree return an ASCII art representation of the tableaux where everything is represented as an ascii character.
+func DefaultAsciiTree(tableaux Node) *tree.Tree {
+	return AsciiTree(tableaux, AsciiFormula, AsciiMark)
 }
 
 func unicodeOperator(op formula.Operator) string {
@@ -430,37 +663,81 @@ func unicodeOperator(op formula.Operator) string {
 		return "↔"
 	case formula.Xor:
 		return "⊕"
+	case formula.ConverseImplies:
+		return "←"
+	case formula.NonImplies:
+		return "↛"
 	default:
 		panic("unknown operator")
 	}
 }
-func unicodeFormula(f formula.Formula) string {
+
+// UnicodeFormula is a FormulaDrawer that writes the formula with Unicode symbols, like (p ∧ ¬q).
+func UnicodeFormula(f formula.Formula) string {
 	switch f := f.(type) {
 	case formula.Letter:
 		return f.Name()
+	case formula.Top:
+		return "⊤"
+	case formula.Bottom:
+		return "⊥"
 	case formula.Not:
-		return "¬" + unicodeFormula(f.Negated())
+		return "¬" + UnicodeFormula(f.Negated())
 	case formula.Binary:
 		return fmt.Sprintf("(%s %s %s)",
-			unicodeFormula(f.Left()), unicodeOperator(f.Op()), unicodeFormula(f.Right()))
+			UnicodeFormula(f.Left()), unicodeOperator(f.Op()), UnicodeFormula(f.Right()))
+	case formula.NAry:
+		return "(" + joinOperands(formula.Operands(f), UnicodeFormula, " "+unicodeOperator(f.Op())+" ") + ")"
+	case formula.Ite:
+		return "ite(" + joinOperands(formula.Operands(f), UnicodeFormula, ", ") + ")"
+	case formula.Cardinality:
+		return fmt.Sprintf("%v(%d; %s)", f.Kind(), f.Bound(), joinOperands(f.Operands(), UnicodeFormula, ", "))
+	case formula.Custom:
+		return customFormula(f, f.Connective().Unicode, UnicodeFormula, "(", ")")
 	default:
 		panic(fmt.Errorf("%T is not a formula", f))
 	}
 }
 
-// UnicodeAsciiTree returns an ascii art representation of the tableaux where the formulas and the marks are represented
-// using Unicode characters. A closed leaf is a filled circle, an open leaf is an empty circle.
-func UnicodeAsciiTree(tableaux Node) *tree.Tree {
-	md := func(open bool) string {
-		if open {
-			return "○"
-		}
+// customFormula writes a registered connective with the given symbol and the operands written with fd: between
+// its two operands if it is binary, like (p ⊼ q), and before them otherwise, like maj(p, q, r).
+func customFormula(f formula.Custom, symbol string, fd FormulaDrawer, op, cp string) string {
+	if f.Len() == 2 {
+		return op + joinOperands(f.Operands(), fd, " "+symbol+" ") + cp
+	}
+	return symbol + op + joinOperands(f.Operands(), fd, ", ") + cp
+}
+
+// joinOperands writes the operands with fd, separated by sep.
+func joinOperands(operands []formula.Formula, fd FormulaDrawer, sep string) string {
+	strs := make([]string, len(operands))
+	for i, operand := range operands {
+		strs[i] = fd(operand)
+	}
+	return strings.Join(strs, sep)
+}
+
+// UnicodeMark is a MarkDrawer that writes an empty circle for an open leaf, a filled circle for a closed one and a
+// dotted circle for an unfinished one.
+func UnicodeMark(mark Mark) string {
+	switch mark {
+	case Open:
+		return "○"
+	case Unfinished:
+		return "◌"
+	default:
 		return "●"
 	}
-	return AsciiTree(tableaux, unicodeFormula, md)
 }
 
-var latexOperators = [7]string{
+// UnicodeAsciiTree returns an ascii art representation of the tableaux where the formulas and the marks are represented
+// using Unicode characters. A closed leaf is a filled circle, an open leaf is an empty circle and an unfinished leaf
+// is a dotted circle.
+func UnicodeAsciiTree(tableaux Node) *tree.Tree {
+	return AsciiTree(tableaux, UnicodeFormula, UnicodeMark)
+}
+
+var latexOperators = [OperatorsCount]string{
 	`\land`,
 	`\lor`,
 	`\to`,
@@ -468,23 +745,39 @@ var latexOperators = [7]string{
 	`\downarrow`,
 	`\leftrightarrow`,
 	`\oplus`,
+	`\leftarrow`,
+	`\nrightarrow`,
 }
 
-func formulaTex(f formula.Formula) string {
+// TexFormula is a FormulaDrawer that writes the formula as LaTeX math, like \left(p \land \neg q\right).
+func TexFormula(f formula.Formula) string {
 	switch f := f.(type) {
 	case formula.Letter:
 		return f.Name()
+	case formula.Top:
+		return `\top`
+	case formula.Bottom:
+		return `\bot`
 	case formula.Not:
-		return `\neg ` + formulaTex(f.Negated())
+		return `\neg ` + TexFormula(f.Negated())
 	case formula.Binary:
 		return fmt.Sprintf(`\left(%s %s %s\right)`,
-			formulaTex(f.Left()), latexOperators[f.Op()], formulaTex(f.Right()))
+			TexFormula(f.Left()), latexOperators[f.Op()], TexFormula(f.Right()))
+	case formula.NAry:
+		return `\left(` + joinOperands(formula.Operands(f), TexFormula, " "+latexOperators[f.Op()]+" ") + `\right)`
+	case formula.Ite:
+		return `\mathrm{ite}\left(` + joinOperands(formula.Operands(f), TexFormula, ", ") + `\right)`
+	case formula.Cardinality:
+		return fmt.Sprintf(`\mathrm{%v}\left(%d; %s\right)`, f.Kind(), f.Bound(),
+			joinOperands(f.Operands(), TexFormula, ", "))
+	case formula.Custom:
+		return customFormula(f, f.Connective().LaTeX, TexFormula, `\left(`, `\right)`)
 	default:
 		panic(fmt.Errorf("%T is  not a formula", f))
 	}
 }
 
-func formulasTexString(fs iter.Seq[formula.Formula]) string {
+func formulasTexString(fs iter.Seq[formula.Formula], fd FormulaDrawer) string {
 	sb := strings.Builder{}
 	first := true
 	for f := range fs {
@@ -493,35 +786,34 @@ func formulasTexString(fs iter.Seq[formula.Formula]) string {
 		} else {
 			first = false
 		}
-		sb.WriteString(formulaTex(f))
+		sb.WriteString(fd(f))
 	}
 	return fmt.Sprintf(`{$\left\{%s\right\}$}`, sb.String())
 }
-func markTexString(open bool) string {
-	if open {
+func markTexString(mark Mark) string {
+	switch mark {
+	case Open:
 		return `\odot`
+	case Unfinished:
+		return `\cdots`
+	default:
+		return `\times`
 	}
-	return `\times`
 }
 
-func texForestTree(tableaux Node, il int) string {
+func texForestTree(tableaux Node, il int, fd FormulaDrawer) string {
 	const indentSize = 3
-	res := formulasTexString(tableaux.Formulas())
+	res := formulasTexString(tableaux.Formulas(), fd)
 	nlFlag := false
 	if tableaux.IsLeaf() {
 		return fmt.Sprintf(strings.Repeat(" ", (il)*indentSize)+"["+
-			`\shortstack{%s\\$%s$}`, res, markTexString(tableaux.IsOpen())) + "]"
+			`\shortstack{%s\\$%s$}`, res, markTexString(leafMark(tableaux))) + "]"
 	} else {
 		res = strings.Repeat(" ", (il)*indentSize) + "[" + res
 	}
 
-	if tableaux.Left() != nil {
-		res += "\n" + strings.Repeat(" ", (il+1)*indentSize) + texForestTree(tableaux.Left(), il+1)
-		nlFlag = true
-	}
-
-	if tableaux.Right() != nil {
-		res += "\n" + strings.Repeat(" ", (il+1)*indentSize) + texForestTree(tableaux.Right(), il+1)
+	for _, child := range Children(tableaux) {
+		res += "\n" + strings.Repeat(" ", (il+1)*indentSize) + texForestTree(child, il+1, fd)
 		nlFlag = true
 	}
 	if nlFlag {
@@ -530,9 +822,16 @@ func texForestTree(tableaux Node, il int) string {
 	return res + "]"
 }
 
-// TexForestTree returns a LaTeX forest representation of the tableaux.
+// TexForestTree returns a LaTeX forest representation of the tableaux, where an open leaf is marked with \odot, a
+// closed one with \times and an unfinished one with \cdots.
 func TexForestTree(tableaux Node) string {
-	t := texForestTree(tableaux, 0)
+	return TexForestTreeWith(tableaux, TexFormula)
+}
+
+// TexForestTreeWith returns a LaTeX forest representation of the tableaux where the formulas are written by fd,
+// which must return LaTeX math, like TexFormula.
+func TexForestTreeWith(tableaux Node, fd FormulaDrawer) string {
+	t := texForestTree(tableaux, 0, fd)
 	format := fmt.Sprintf(`
 \begin{forest}
 	for tree={
@@ -7,6 +7,7 @@ import (
 	"maps"
 	"math/big"
 	"math/rand"
+	"os"
 	"reflect"
 	"slices"
 	"strings"
@@ -36,6 +37,14 @@ var (
 	)
 )
 
+// must returns the tableaux built by a builder that cannot be stopped, since it has no limits and no context.
+func must[T Node](tab T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return tab
+}
+
 func normalizeAssignments(assignments []Assignment) string {
 	assignmentsString := make([]string, 0, len(assignments))
 	for _, a := range assignments {
@@ -87,7 +96,7 @@ func TestBuildSemanticTableaux(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tab := BuildSemanticTableaux(tt.f)
+			tab := must(BuildSemanticTableaux(tt.f))
 			got := tab.Eval()
 
 			if len(got) != len(tt.want) {
@@ -117,7 +126,7 @@ func TestBuildSemanticTableaux2(t *testing.T) {
 		formula.NewBiconditional(tu.P1, formula.NewXor(tu.R, tu.S)))
 
 	f := formula.NewAnd(inner, formula.NewNot(inner))
-	tab := BuildSemanticTableaux(f)
+	tab := must(BuildSemanticTableaux(f))
 	assignment := tab.Eval()
 
 	if len(assignment) > 0 {
@@ -129,6 +138,53 @@ func TestBuildSemanticTableaux2(t *testing.T) {
 	}
 }
 
+// TestBuildSemanticTableaux_NAry checks that the semantic tableaux expands the n-ary formulas at once: an n-ary alpha
+// formula in a single node and an n-ary beta formula in one branch for every operand.
+func TestBuildSemanticTableaux_NAry(t *testing.T) {
+	tests := []struct {
+		name string
+		f    formula.Formula
+		want [][]formula.Formula // want has the formulas of the children of the root.
+	}{
+		{
+			"alpha",
+			formula.NewNAry(formula.And, tu.P, tu.Q, formula.NewNot(tu.R), tu.S),
+			[][]formula.Formula{{tu.P, tu.Q, formula.NewNot(tu.R), tu.S}},
+		},
+		{
+			"beta",
+			formula.NewNAry(formula.Or, tu.P, tu.Q, tu.R),
+			[][]formula.Formula{{tu.P}, {tu.Q}, {tu.R}},
+		},
+		{
+			"negated alpha",
+			formula.NewNot(formula.NewNAry(formula.And, tu.P, tu.Q, tu.R)),
+			[][]formula.Formula{{formula.NewNot(tu.P)}, {formula.NewNot(tu.Q)}, {formula.NewNot(tu.R)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab := must(BuildSemanticTableaux(tt.f))
+			children := tab.Children()
+			if len(children) != len(tt.want) {
+				t.Fatalf("the root has %d children, want %d", len(children), len(tt.want))
+			}
+
+			for i, child := range children {
+				got := slices.SortedFunc(child.Formulas(), formula.Compare)
+				want := slices.SortedFunc(slices.Values(tt.want[i]), formula.Compare)
+				if !slices.Equal(got, want) {
+					t.Errorf("child %d = %v, want %v", i, got, want)
+				}
+				if !child.IsLeaf() || !child.IsOpen() {
+					t.Errorf("child %d is not an open leaf", i)
+				}
+			}
+		})
+	}
+}
+
 // TestBuildSemanticTableaux_Tautologies checks that given a negated tautology the semantic tableaux produces a
 // closed tableaux, which means that Eval() produces 0 assignments.
 func TestBuildSemanticTableaux_Tautologies(t *testing.T) {
@@ -188,7 +244,7 @@ func TestBuildSemanticTableaux_Tautologies(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tab := BuildSemanticTableaux(formula.NewNot(tt.f))
+			tab := must(BuildSemanticTableaux(formula.NewNot(tt.f)))
 			if len(tab.Eval()) > 0 {
 				t.Errorf("tableaux found assignments %v for tautology %v", tab.Eval(), tt.f)
 			}
@@ -197,6 +253,54 @@ func TestBuildSemanticTableaux_Tautologies(t *testing.T) {
 
 }
 
+// TestBuildTableaux_Constants checks that every kind of tableaux closes on false constants and ignores true ones.
+func TestBuildTableaux_Constants(t *testing.T) {
+	builders := []struct {
+		name  string
+		build func(formula.Formula) Node
+	}{
+		{"semantic", func(f formula.Formula) Node { return must(BuildSemanticTableaux(f)) }},
+		{"analytic", func(f formula.Formula) Node { return must(BuildAnalyticTableaux(f)) }},
+		{"buffer", func(f formula.Formula) Node { return must(BuildBufferTableaux(f)) }},
+	}
+
+	tests := []struct {
+		name        string
+		f           formula.Formula
+		satisfiable bool
+	}{
+		{"top", formula.NewTop(), true},
+		{"bottom", formula.NewBottom(), false},
+		{"negated top", formula.NewNot(formula.NewTop()), false},
+		{"negated bottom", formula.NewNot(formula.NewBottom()), true},
+		{"and with bottom", formula.NewAnd(tu.P, formula.NewBottom()), false},
+		{"or with bottom", formula.NewOr(tu.P, formula.NewBottom()), true},
+		{"implies bottom", formula.NewImplies(tu.P, formula.NewBottom()), true},
+		{"top implies bottom", formula.NewImplies(formula.NewTop(), formula.NewBottom()), false},
+		{"xor with top", formula.NewXor(tu.P, formula.NewTop()), true},
+		{"biconditional of constants", formula.NewBiconditional(formula.NewTop(), formula.NewBottom()), false},
+	}
+
+	for _, b := range builders {
+		for _, tt := range tests {
+			t.Run(b.name+"/"+tt.name, func(t *testing.T) {
+				tab := b.build(tt.f)
+				assignments := tab.Eval()
+
+				if sat := len(assignments) > 0; sat != tt.satisfiable {
+					t.Fatalf("satisfiable = %v, want %v", sat, tt.satisfiable)
+				}
+
+				for _, a := range assignments {
+					if !evaluate(tt.f, a) {
+						t.Errorf("assignment %v does not satisfy %v", a, tt.f)
+					}
+				}
+			})
+		}
+	}
+}
+
 /*
 	Property based testing
 */
@@ -212,6 +316,10 @@ func allLetters(f formula.Formula, letters map[string]struct{}) {
 	case formula.Binary:
 		allLetters(f.Left(), letters)
 		allLetters(f.Right(), letters)
+	case formula.NAry, formula.Ite, formula.Cardinality, formula.Custom:
+		for _, operand := range formula.Operands(f) {
+			allLetters(operand, letters)
+		}
 	}
 }
 
@@ -219,6 +327,10 @@ func evaluate(f formula.Formula, assignment Assignment) bool {
 	switch f := f.(type) {
 	case formula.Letter:
 		return assignment[f.Name()]
+	case formula.Top:
+		return true
+	case formula.Bottom:
+		return false
 	case formula.Not:
 		return !evaluate(f.Negated(), assignment)
 	case formula.Binary:
@@ -237,9 +349,50 @@ func evaluate(f formula.Formula, assignment Assignment) bool {
 			return evaluate(f.Left(), assignment) == evaluate(f.Right(), assignment)
 		case formula.Xor:
 			return evaluate(f.Left(), assignment) != evaluate(f.Right(), assignment)
+		case formula.ConverseImplies:
+			return evaluate(f.Left(), assignment) || !evaluate(f.Right(), assignment)
+		case formula.NonImplies:
+			return evaluate(f.Left(), assignment) && !evaluate(f.Right(), assignment)
 		default:
 			panic("unreachable")
 		}
+	case formula.NAry:
+		// a conjunction is true if no operand is false, a disjunction if some operand is true and an exclusive
+		// disjunction if an odd number of operands is true.
+		res := f.Op() == formula.And
+		for _, operand := range f.Operands() {
+			switch value := evaluate(operand, assignment); f.Op() {
+			case formula.And:
+				res = res && value
+			case formula.Or:
+				res = res || value
+			default:
+				res = res != value
+			}
+		}
+		return res
+	case formula.Ite:
+		if evaluate(f.Condition(), assignment) {
+			return evaluate(f.Then(), assignment)
+		}
+		return evaluate(f.Else(), assignment)
+	case formula.Cardinality:
+		trues := 0
+		for _, operand := range f.Operands() {
+			if evaluate(operand, assignment) {
+				trues++
+			}
+		}
+		switch f.Kind() {
+		case formula.AtMost:
+			return trues <= f.Bound()
+		case formula.AtLeast:
+			return trues >= f.Bound()
+		default:
+			return trues == f.Bound()
+		}
+	case formula.Custom:
+		return evaluate(f.Definition(), assignment)
 	default:
 		panic(fmt.Errorf("%T is not a formula", f))
 	}
@@ -300,7 +453,7 @@ func compareTableauxWithTruthTables(t *testing.T, f formula.Formula, tab Node) b
 // the one obtained by calculating all the truth-tables
 func TestBuildSemanticTableaux_TruthTables(t *testing.T) {
 	f := func(f formula.Formula) bool {
-		tab := BuildSemanticTableaux(f)
+		tab := must(BuildSemanticTableaux(f))
 
 		return compareTableauxWithTruthTables(t, f, tab)
 	}
@@ -308,7 +461,7 @@ func TestBuildSemanticTableaux_TruthTables(t *testing.T) {
 	maxSize := FormulaMaxSize
 	config := &quick.Config{
 		Values: func(values []reflect.Value, r *rand.Rand) {
-			values[0] = reflect.ValueOf(formula.GenerateRandom(r, r.Intn(maxSize)))
+			values[0] = reflect.ValueOf(tu.GenerateRandomNAry(r, r.Intn(maxSize)))
 		},
 	}
 
@@ -320,7 +473,7 @@ func TestBuildSemanticTableaux_TruthTables(t *testing.T) {
 // TestBuildSemanticTableaux_Assignments checks that the assignments obtained by a semantic tableaux, satisfy the formula.
 func TestBuildSemanticTableaux_Assignments(t *testing.T) {
 	f := func(f formula.Formula) bool {
-		tab := BuildSemanticTableaux(f)
+		tab := must(BuildSemanticTableaux(f))
 		assignments := tab.Eval()
 
 		for _, a := range assignments {
@@ -346,6 +499,90 @@ func TestBuildSemanticTableaux_Assignments(t *testing.T) {
 	}
 }
 
+// TestBuildTableaux_RegisteredConnectives checks that every kind of tableaux expands the registered connectives with
+// their rules: the tableaux are open exactly when the formula is satisfiable, and their assignments satisfy it.
+func TestBuildTableaux_RegisteredConnectives(t *testing.T) {
+	f := func(f formula.Formula) bool {
+		tabs := map[string]Node{
+			"semantic": must(BuildSemanticTableaux(f)),
+			"analytic": must(BuildAnalyticTableaux(f)),
+			"buffer":   must(BuildBufferTableaux(f)),
+		}
+
+		for name, tab := range tabs {
+			if !compareTableauxWithTruthTables(t, f, tab) {
+				return false
+			}
+			for _, a := range tab.Eval() {
+				if ok, err := formula.Eval(f, a); !ok || err != nil {
+					t.Errorf("%s tableaux: assignment %v does not satisfy %v: %v", name, a, f, err)
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			operands := make([]formula.Formula, 3)
+			for i := range operands {
+				operands[i] = formula.GenerateRandom(r, r.Intn(4)+1)
+			}
+			var f formula.Formula = formula.NewCustom(maj, operands[0], formula.NewCustom(stroke, operands[1],
+				operands[2]), operands[2])
+			if r.Intn(2) == 0 {
+				f = formula.NewNot(f)
+			}
+			values[0] = reflect.ValueOf(f)
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+
+	// the beta rule of maj splits the branch in three.
+	if children := must(BuildSemanticTableaux(formula.NewCustom(maj, tu.P, tu.Q, tu.R))).Children(); len(children) != 3 {
+		t.Errorf("the semantic tableaux of maj(p, q, r) has %d children, want 3", len(children))
+	}
+}
+
+// TestTableaux_EvalCrossCheck checks with formula.Eval that every assignment produced by any kind of tableaux
+// satisfies the formula, even though the assignments are cleaned of the letters that do not matter.
+func TestTableaux_EvalCrossCheck(t *testing.T) {
+	f := func(f formula.Formula) bool {
+		tabs := map[string]Node{
+			"semantic": must(BuildSemanticTableaux(f)),
+			"analytic": must(BuildAnalyticTableaux(f)),
+			"buffer":   must(BuildBufferTableaux(f)),
+		}
+
+		for name, tab := range tabs {
+			for _, a := range tab.Eval() {
+				if ok, err := formula.Eval(f, a); !ok || err != nil {
+					t.Errorf("%s tableaux: assignment %v does not satisfy %v: %v", name, a, f, err)
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	maxSize := FormulaMaxSize
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(tu.GenerateRandomNAry(r, r.Intn(maxSize)))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
+
 // testTableauxMarks this function tests that every leaf of the tableaux is either only literals and marks as closed
 // or open, or it is mark as closed. The last option is for the case where the algorithm find a formula and its
 // complement and so stops.
@@ -371,21 +608,17 @@ func testTableauxMarks(t *testing.T, tab Node) bool {
 		return res
 	}
 
-	var left, right = true, true
-	if tab.Left() != nil {
-		left = testTableauxMarks(t, tab.Left())
-	}
-
-	if tab.Right() != nil {
-		right = testTableauxMarks(t, tab.Right())
+	res := true
+	for _, child := range Children(tab) {
+		res = testTableauxMarks(t, child) && res
 	}
 
-	return left && right
+	return res
 }
 
 func TestSemanticTableaux_Marks(t *testing.T) {
 	f := func(f formula.Formula) bool {
-		tab := BuildSemanticTableaux(f)
+		tab := must(BuildSemanticTableaux(f))
 
 		res := testTableauxMarks(t, tab)
 
@@ -399,7 +632,7 @@ func TestSemanticTableaux_Marks(t *testing.T) {
 
 	config := &quick.Config{
 		Values: func(values []reflect.Value, r *rand.Rand) {
-			values[0] = reflect.ValueOf(formula.GenerateRandom(r, r.Intn(maxSize)))
+			values[0] = reflect.ValueOf(tu.GenerateRandomNAry(r, r.Intn(maxSize)))
 		},
 	}
 
@@ -420,56 +653,248 @@ var hugeFormula = formula.Parse("!!(((!!!(!!!(!y | !(v !& !!q)) & !z) & !!z) !&
 
 func BenchmarkBuildSemanticTableaux_Small(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		BuildSemanticTableaux(smallFormula)
+		must(BuildSemanticTableaux(smallFormula))
 	}
 }
 
 func BenchmarkBuildAnalyticTableaux_Small(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		BuildAnalyticTableaux(smallFormula)
+		must(BuildAnalyticTableaux(smallFormula))
 	}
 }
 
 func BenchmarkBuildBufferedTableaux_Small(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		BuildBufferTableaux(smallFormula)
+		must(BuildBufferTableaux(smallFormula))
 	}
 }
 
 func BenchmarkBuildSemanticTableaux_Big(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
-		BuildSemanticTableaux(bigFormula)
+		must(BuildSemanticTableaux(bigFormula))
 	}
 }
 
 func BenchmarkBuildAnalyticTableaux_Big(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		BuildAnalyticTableaux(bigFormula)
+		must(BuildAnalyticTableaux(bigFormula))
 	}
 }
 
 func BenchmarkBuildBufferedTableaux_Big(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		BuildBufferTableaux(bigFormula)
+		must(BuildBufferTableaux(bigFormula))
 	}
 }
 
 func BenchmarkBuildSemanticTableaux_Huge(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
-		BuildSemanticTableaux(hugeFormula)
+		must(BuildSemanticTableaux(hugeFormula))
 	}
 }
 
 func BenchmarkBuildAnalyticTableaux_Huge(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		BuildAnalyticTableaux(bigFormula)
+		must(BuildAnalyticTableaux(bigFormula))
 	}
 }
 
 func BenchmarkBuildBufferedTableaux_Huge(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		BuildBufferTableaux(bigFormula)
+		must(BuildBufferTableaux(bigFormula))
+	}
+}
+
+// TestPrintedFormulas_RoundTrip checks that the Unicode and LaTeX representations of a formula are parsed back to the same formula.
+func TestPrintedFormulas_RoundTrip(t *testing.T) {
+	printers := map[string]func(formula.Formula) string{
+		"unicode": UnicodeFormula,
+		"latex":   TexFormula,
+	}
+
+	for name, toString := range printers {
+		t.Run(name, func(t *testing.T) {
+			f := func(f formula.Formula) bool {
+				got, err := formula.ParseE(toString(f))
+				if err != nil || got != f {
+					t.Errorf("%q parsed as %v, %v; want %v", toString(f), got, err, f)
+					return false
+				}
+				return true
+			}
+			config := &quick.Config{Values: func(values []reflect.Value, r *rand.Rand) {
+				values[0] = reflect.ValueOf(formula.GenerateRandom(r, r.Intn(FormulaMaxSize)+1))
+			},
+			}
+
+			if err := quick.Check(f, config); err != nil {
+				t.Errorf("%v", err)
+			}
+		})
+	}
+}
+
+func TestPrintedFormulas_RegisteredConnectives(t *testing.T) {
+	f := formula.NewNot(formula.NewCustom(maj, tu.P, formula.NewCustom(stroke, tu.Q, tu.R), tu.S))
+	tests := []struct {
+		name     string
+		toString func(formula.Formula) string
+		want     string
+	}{
+		{"unicode", UnicodeFormula, "¬maj(P, (Q ⊼ R), S)"},
+		{"latex", TexFormula, `\neg \mathrm{maj}\left(P, \left(Q \barwedge R\right), S\right)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.toString(f); got != tt.want {
+				t.Errorf("%s(%v) = %v, want %v", tt.name, f, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanAssignments(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []Assignment
+		want  []Assignment
+	}{
+		{"empty", nil, nil},
+		{"repeated", []Assignment{{"p": true}, {"p": true}}, []Assignment{{"p": true}}},
+		{"superset", []Assignment{{"p": true, "q": false}, {"p": true}}, []Assignment{{"p": true}}},
+		{
+			// a repeated superset must be removed whatever the order.
+			"repeated superset",
+			[]Assignment{{"p": true, "q": false}, {"p": true, "q": false}, {"p": true}, {"q": true}},
+			[]Assignment{{"p": true}, {"q": true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 2 {
+				if got := CleanAssignments(tt.input); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("CleanAssignments(%v) = %v, want %v", tt.input, got, tt.want)
+				}
+				slices.Reverse(tt.input)
+				slices.Reverse(tt.want)
+			}
+		})
+	}
+}
+
+// TestRender_Deterministic checks that building and printing a tableaux always gives the same output.
+func TestRender_Deterministic(t *testing.T) {
+	f := formula.GenerateRandom(rand.New(rand.NewSource(3)), 25)
+	builders := map[string]func(formula.Formula) Node{
+		"semantic": func(f formula.Formula) Node { return must(BuildSemanticTableaux(f)) },
+		"analytic": func(f formula.Formula) Node { return must(BuildAnalyticTableaux(f)) },
+		"buffer":   func(f formula.Formula) Node { return must(BuildBufferTableaux(f)) },
+	}
+
+	render := func(node Node) string {
+		return fmt.Sprint(node) + DefaultAsciiTree(node).String() + UnicodeAsciiTree(node).String() + TexForestTree(node)
+	}
+
+	for name, build := range builders {
+		t.Run(name, func(t *testing.T) {
+			want := render(build(f))
+			for range 10 {
+				if got := render(build(f)); got != want {
+					t.Fatalf("the output changed between two builds of %v", f)
+				}
+			}
+		})
+	}
+}
+
+// outsideNode is a Node that implements neither ChildrenNode nor UnfinishedNode, like a node of a tableaux defined
+// outside the package.
+type outsideNode struct {
+	Node
+}
+
+func wrapOutside(node Node) Node {
+	if node == nil {
+		return nil
+	}
+	return outsideNode{node}
+}
+
+func (n outsideNode) Left() Node {
+	return wrapOutside(n.Node.Left())
+}
+
+func (n outsideNode) Right() Node {
+	return wrapOutside(n.Node.Right())
+}
+
+// TestNode_OptionalInterfaces checks that the renderers, Children and IsUnfinished accept a Node that does not
+// implement the optional interfaces.
+func TestNode_OptionalInterfaces(t *testing.T) {
+	tab := must(BuildAnalyticTableaux(simpleUnsat))
+	if got, want := DefaultAsciiTree(outsideNode{tab}).String(), DefaultAsciiTree(tab).String(); got != want {
+		t.Errorf("DefaultAsciiTree(outsideNode) = \n%v\nwant\n%v", got, want)
+	}
+	if got, want := len(Children(outsideNode{tab})), len(tab.Children()); got != want {
+		t.Errorf("Children(outsideNode) has %d nodes, want %d", got, want)
+	}
+
+	stopped, _ := BuildAnalyticTableaux(simpleUnsat, WithMaxNodes(1))
+	if !IsUnfinished(stopped) || IsUnfinished(outsideNode{stopped}) {
+		t.Errorf("IsUnfinished(stopped) = %v, IsUnfinished(outsideNode) = %v, want true and false",
+			IsUnfinished(stopped), IsUnfinished(outsideNode{stopped}))
+	}
+}
+
+// TestUnicodeAsciiTree_Golden compares the printed tableaux with testdata/semantic.golden.
+func TestUnicodeAsciiTree_Golden(t *testing.T) {
+	want, err := os.ReadFile("testdata/semantic.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tab := must(BuildSemanticTableaux(formula.Parse("(q | p) & !(p & !q)")))
+	if got := UnicodeAsciiTree(tab).String(); got != string(want) {
+		t.Errorf("got\n%v\nwant\n%s", got, want)
+	}
+}
+
+// TestAbbreviated checks that the renderers print the names of the definitions used in the input.
+func TestAbbreviated(t *testing.T) {
+	f, abbreviations, err := formula.ParseAbbreviated("let A = (p & q); def N(X) = !X; N(A) | (A -> r)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tab := must(BuildSemanticTableaux(f))
+
+	tests := []struct {
+		name string
+		got  string
+		want []string
+	}{
+		{"ascii", AsciiTree(tab, Abbreviated(AsciiFormula, abbreviations), AsciiMark).String(),
+			[]string{"{(N(A) | (A -> r))}", "{N(A)}", "{(A -> r)}"}},
+		{"unicode", AsciiTree(tab, Abbreviated(UnicodeFormula, abbreviations), UnicodeMark).String(),
+			[]string{"{(N(A) ∨ (A → r))}", "{N(A)}", "{(A → r)}"}},
+		{"latex", TexForestTreeWith(tab, Abbreviated(TexFormula, abbreviations)),
+			[]string{`{$\left\{\left(N(A) \lor \left(A \to r\right)\right)\right\}$}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, want := range tt.want {
+				if !strings.Contains(tt.got, want) {
+					t.Errorf("%s does not contain %s", tt.got, want)
+				}
+			}
+		})
+	}
+
+	if got, want := TexForestTreeWith(tab, TexFormula), TexForestTree(tab); got != want {
+		t.Errorf("TexForestTreeWith(TexFormula) = %s, want %s", got, want)
 	}
 }
@@ -32,7 +32,12 @@ func asByte(b bool) byte {
 }
 
 // HasComplementOf returns true if the set contains the complement of the given formula f.
+// A false constant (⊥ or ¬⊤) is considered to be complementary to any set.
 func (s TSet) HasComplementOf(f formula.Formula) bool {
+	if formula.IsConstant(f) {
+		return !formula.AsConstant(f)
+	}
+
 	switch f.Class() {
 	case formula.LiteralClass:
 		return s.literals.HasComplementaryOf(f)
@@ -45,9 +50,17 @@ func (s TSet) HasComplementOf(f formula.Formula) bool {
 }
 
 // addOne add an element to the set. If the element is already contained, it will be overwritten.
-// If f is nil, it will not be added.
-// Returns 1 if this set contains the complement of f, 0 otherwise.
+// If f is nil or a true constant (⊤ or ¬⊥), it will not be added.
+// Returns 1 if this set contains the complement of f or if f is a false constant (⊥ or ¬⊤), 0 otherwise.
 func (s TSet) addOne(f formula.Formula) byte {
+	if f != nil && formula.IsConstant(f) {
+		if formula.AsConstant(f) {
+			return 0
+		}
+		s.literals.Add(f)
+		return 1
+	}
+
 	if f != nil {
 		switch f.Class() {
 		case formula.LiteralClass:
@@ -67,8 +80,8 @@ func (s TSet) addOne(f formula.Formula) byte {
 	return 0
 }
 
-// Add adds all the passed elements to the set s and returns true if s contains the complement of at least one element,
-// false otherwise.
+// Add adds all the passed elements to the set s and returns true if s contains the complement of at least one element
+// or if a false constant was added, false otherwise.
 func (s TSet) Add(fs ...formula.Formula) bool {
 	var flag byte = 0
 	for _, f := range fs {
@@ -92,10 +105,11 @@ func (s TSet) HasOnlyLiterals() bool {
 	return s.literals.Len() > 0 && s.alphaFormulas.Len() == 0 && s.betaFormulas.Len() == 0
 }
 
-// HasComplementaryLiterals returns true if the set contains at least a pair of complementary literals, false otherwise.
+// HasComplementaryLiterals returns true if the set contains at least a pair of complementary literals
+// or a false constant (⊥ or ¬⊤), false otherwise.
 func (s TSet) HasComplementaryLiterals() bool {
 	for literal := range s.literals.Iter() {
-		if s.literals.HasComplementaryOf(literal) {
+		if s.HasComplementOf(literal) {
 			return true
 		}
 	}
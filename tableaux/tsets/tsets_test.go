@@ -208,6 +208,24 @@ func TestTSet_Add2(t *testing.T) {
 			value: []formula.Formula{formula.NewOr(tu.Q, tu.P), tu.T},
 			want:  true,
 		},
+		{
+			name:  "true constants",
+			set:   NewTSet(),
+			value: []formula.Formula{formula.NewTop(), formula.NewNot(formula.NewBottom())},
+			want:  false,
+		},
+		{
+			name:  "false constant",
+			set:   NewTSet(),
+			value: []formula.Formula{formula.NewBottom()},
+			want:  true,
+		},
+		{
+			name:  "negated true constant",
+			set:   NewTSet(),
+			value: []formula.Formula{tu.P, formula.NewNot(formula.NewTop())},
+			want:  true,
+		},
 	}
 
 	for _, tt := range tests {
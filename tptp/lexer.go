@@ -0,0 +1,123 @@
+package tptp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind is the kind of a TPTP token.
+type tokenKind int
+
+const (
+	eof          tokenKind = iota
+	lowerWord              // lowerWord is a word starting with a lowercase letter, like fof or p1.
+	upperWord              // upperWord is a word starting with an uppercase letter, which is a variable in TPTP.
+	dollarWord             // dollarWord is a word starting with $, like $true.
+	singleQuoted           // singleQuoted is a quoted atom or file name, without the quotes.
+	number                 // number is an integer, used in the annotations.
+	punct                  // punct is a punctuation mark or a connective.
+)
+
+type token struct {
+	kind         tokenKind
+	text         string
+	line, column int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case eof:
+		return "end of file"
+	case singleQuoted:
+		return "'" + t.text + "'"
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// puncts are the punctuation marks and connectives, longest first so that the lexer prefers them.
+var puncts = []string{
+	"<=>", "<~>", "=>", "<=", "~|", "~&", "!=", ":=",
+	"~", "&", "|", "(", ")", ",", ".", "[", "]", ":", "!", "?", "=", ">", "*", "+", "-",
+}
+
+func isLower(c byte) bool { return 'a' <= c && c <= 'z' }
+func isUpper(c byte) bool { return 'A' <= c && c <= 'Z' }
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+func isAlnum(c byte) bool { return isLower(c) || isUpper(c) || isDigit(c) || c == '_' }
+
+// lex splits a TPTP file into tokens, skipping whitespace, % line comments and /* */ block comments.
+func lex(file, input string) ([]token, error) {
+	var res []token
+	line, lineStart := 1, 0
+
+	for i := 0; i < len(input); {
+		c := input[i]
+		column := i - lineStart
+		errorf := func(format string, args ...any) error {
+			return &SyntaxError{File: file, Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+		}
+
+		switch {
+		case c == '\n':
+			i++
+			line, lineStart = line+1, i
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '%':
+			for i < len(input) && input[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(input[i:], "/*"):
+			end := strings.Index(input[i+2:], "*/")
+			if end < 0 {
+				return nil, errorf("unterminated comment")
+			}
+			for _, ch := range input[i : i+2+end+2] {
+				if ch == '\n' {
+					line++
+				}
+			}
+			i += 2 + end + 2
+			lineStart = strings.LastIndex(input[:i], "\n") + 1
+		case isAlnum(c) || c == '$':
+			start := i
+			i++
+			for i < len(input) && isAlnum(input[i]) {
+				i++
+			}
+			kind := lowerWord
+			switch {
+			case c == '$':
+				kind = dollarWord
+			case isUpper(c) || c == '_':
+				kind = upperWord
+			case isDigit(c):
+				kind = number
+			}
+			res = append(res, token{kind, input[start:i], line, column})
+		case c == '\'':
+			end := strings.IndexAny(input[i+1:], "'\n")
+			if end < 0 || input[i+1+end] != '\'' {
+				return nil, errorf("unterminated quoted atom")
+			}
+			res = append(res, token{singleQuoted, input[i+1 : i+1+end], line, column})
+			i += end + 2
+		default:
+			found := false
+			for _, p := range puncts {
+				if strings.HasPrefix(input[i:], p) {
+					res = append(res, token{punct, p, line, column})
+					i += len(p)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, errorf("unexpected character %q", c)
+			}
+		}
+	}
+
+	return append(res, token{eof, "", line, len(input) - lineStart}), nil
+}
@@ -0,0 +1,450 @@
+// Package tptp reads propositional problems in the TPTP format, like the SYN and PUZ problems of the TPTP library,
+// and decides them with the tableaux, reporting the result as an SZS status.
+//
+// The annotated formulas can be fof, cnf or tff, with the connectives ~, &, |, =>, <=, <=>, <~>, ~| and ~&, the
+// constants $true and $false and propositional atoms, which become letters. As in TPTP, & and | can be chained,
+// while the other connectives need parentheses. Quantifiers, variables, terms and equality are rejected, since the
+// formulas are not propositional, and tff type declarations are skipped.
+package tptp
+
+import (
+	"fmt"
+	"github.com/francodesource/propositional_tableaux/formula"
+	"github.com/francodesource/propositional_tableaux/tableaux"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Problem is a propositional TPTP problem: the conjecture must follow from the premises.
+type Problem struct {
+	Name string // Name is the name of the problem file without extension.
+	// Premises contains the formulas with role axiom, hypothesis, definition, assumption, lemma, theorem,
+	// corollary, plain and negated_conjecture, in order.
+	Premises []formula.Formula
+	// Conjecture is the conjunction of the formulas with role conjecture, or nil if there are none.
+	Conjecture formula.Formula
+}
+
+// SyntaxError is the error returned when a TPTP file is malformed or not propositional.
+type SyntaxError struct {
+	File   string // File is the name of the file containing the error.
+	Line   int    // Line is the line of the error, starting from 1.
+	Column int    // Column is the position of the error in its line in bytes, starting from 0.
+	Msg    string // Msg describes the error.
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("tptp: %s:%d:%d %s", e.File, e.Line, e.Column, e.Msg)
+}
+
+// Read reads a problem from r. The included files are resolved relative to dir, which is usually the root of the
+// TPTP library.
+func Read(r io.Reader, dir string) (*Problem, error) {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	rd := &reader{dir: dir, problem: &Problem{}}
+	if err := rd.read("<input>", string(input), nil); err != nil {
+		return nil, err
+	}
+	return rd.problem, nil
+}
+
+// ReadFile reads the problem in the given file. The included files are resolved relative to dir, which is usually
+// the root of the TPTP library.
+func ReadFile(path, dir string) (*Problem, error) {
+	input, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rd := &reader{dir: dir, problem: &Problem{Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}}
+	if err := rd.read(path, string(input), nil); err != nil {
+		return nil, err
+	}
+	return rd.problem, nil
+}
+
+// reader holds the state of the reading of a problem and of its included files.
+type reader struct {
+	dir       string
+	including []string // including contains the files being read, to detect cyclic includes.
+	problem   *Problem
+}
+
+// premiseRoles are the roles of the formulas that are premises.
+var premiseRoles = []string{
+	"axiom", "hypothesis", "definition", "assumption", "lemma", "theorem", "corollary", "plain", "negated_conjecture",
+}
+
+// read reads the annotated formulas of a file. If selection is not nil, only the formulas with a name in it are
+// added to the problem, like in include('file', [names]).
+func (r *reader) read(file, input string, selection []string) error {
+	tokens, err := lex(file, input)
+	if err != nil {
+		return err
+	}
+	p := &parser{file: file, tokens: tokens}
+	r.including = append(r.including, file)
+	defer func() { r.including = r.including[:len(r.including)-1] }()
+
+	for p.peek().kind != eof {
+		t := p.next()
+		if t.kind != lowerWord {
+			return p.errorf(t, "expected an annotated formula or an include, found %v", t)
+		}
+
+		switch t.text {
+		case "include":
+			if err := r.include(p); err != nil {
+				return err
+			}
+		case "fof", "cnf", "tff":
+			name, role, f, err := p.annotated()
+			if err != nil {
+				return err
+			}
+			if f == nil || selection != nil && !slices.Contains(selection, name) {
+				continue
+			}
+			switch {
+			case role.text == "conjecture":
+				if r.problem.Conjecture == nil {
+					r.problem.Conjecture = f
+				} else {
+					r.problem.Conjecture = formula.NewAnd(r.problem.Conjecture, f)
+				}
+			case slices.Contains(premiseRoles, role.text):
+				r.problem.Premises = append(r.problem.Premises, f)
+			default:
+				return p.errorf(role, "unsupported role %q", role.text)
+			}
+		default:
+			return p.errorf(t, "unsupported %q, expected fof, cnf, tff or include", t.text)
+		}
+	}
+	return nil
+}
+
+// include reads an include directive, after the include keyword, and the included file.
+func (r *reader) include(p *parser) error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	name := p.next()
+	if name.kind != singleQuoted {
+		return p.errorf(name, "expected a quoted file name, found %v", name)
+	}
+
+	var selection []string
+	if p.peek().text == "," {
+		p.next()
+		if err := p.expect("["); err != nil {
+			return err
+		}
+		for {
+			t := p.next()
+			if t.kind != lowerWord && t.kind != singleQuoted && t.kind != number {
+				return p.errorf(t, "expected a formula name, found %v", t)
+			}
+			selection = append(selection, t.text)
+			if p.peek().text != "," {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return err
+	}
+	if err := p.expect("."); err != nil {
+		return err
+	}
+
+	path := filepath.Join(r.dir, name.text)
+	if slices.Contains(r.including, path) {
+		return p.errorf(name, "cyclic include of %s", name.text)
+	}
+	input, err := os.ReadFile(path)
+	if err != nil {
+		return p.errorf(name, "cannot include %s: %v", name.text, err)
+	}
+	return r.read(path, string(input), selection)
+}
+
+type parser struct {
+	file   string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != eof {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	return &SyntaxError{File: p.file, Line: t.line, Column: t.column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// expect consumes the next token, which must be the given punctuation mark.
+func (p *parser) expect(text string) error {
+	if t := p.next(); t.kind != punct || t.text != text {
+		return p.errorf(t, "expected %q, found %v", text, t)
+	}
+	return nil
+}
+
+// annotated parses an annotated formula after the fof, cnf or tff keyword, and returns its name, its role and its
+// formula, which is nil for tff type declarations. The annotations after the formula are skipped.
+func (p *parser) annotated() (string, token, formula.Formula, error) {
+	if err := p.expect("("); err != nil {
+		return "", token{}, nil, err
+	}
+	name := p.next()
+	if name.kind != lowerWord && name.kind != singleQuoted && name.kind != number {
+		return "", token{}, nil, p.errorf(name, "expected a formula name, found %v", name)
+	}
+	if err := p.expect(","); err != nil {
+		return "", token{}, nil, err
+	}
+	role := p.next()
+	if role.kind != lowerWord {
+		return "", token{}, nil, p.errorf(role, "expected a role, found %v", role)
+	}
+	if err := p.expect(","); err != nil {
+		return "", token{}, nil, err
+	}
+
+	var f formula.Formula
+	if role.text == "type" {
+		if err := p.skip(); err != nil {
+			return "", token{}, nil, err
+		}
+	} else {
+		var err error
+		if f, err = p.formula(); err != nil {
+			return "", token{}, nil, err
+		}
+		if p.peek().text == "," {
+			if err := p.skip(); err != nil {
+				return "", token{}, nil, err
+			}
+		}
+	}
+
+	if err := p.expect(")"); err != nil {
+		return "", token{}, nil, err
+	}
+	if err := p.expect("."); err != nil {
+		return "", token{}, nil, err
+	}
+	return name.text, role, f, nil
+}
+
+// skip skips the tokens up to the parenthesis that closes the annotated formula, excluded.
+func (p *parser) skip() error {
+	depth := 0
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == eof:
+			return p.errorf(t, "unexpected end of file")
+		case t.text == "(" || t.text == "[":
+			depth++
+		case t.text == ")" || t.text == "]":
+			if depth == 0 {
+				return nil
+			}
+			depth--
+		}
+		p.next()
+	}
+}
+
+// binaryConnectives maps the binary connectives to a function building the formula.
+var binaryConnectives = map[string]func(l, r formula.Formula) formula.Formula{
+	"&":   func(l, r formula.Formula) formula.Formula { return formula.NewAnd(l, r) },
+	"|":   func(l, r formula.Formula) formula.Formula { return formula.NewOr(l, r) },
+	"=>":  func(l, r formula.Formula) formula.Formula { return formula.NewImplies(l, r) },
+	"<=":  func(l, r formula.Formula) formula.Formula { return formula.NewConverseImplies(l, r) },
+	"<=>": func(l, r formula.Formula) formula.Formula { return formula.NewBiconditional(l, r) },
+	"<~>": func(l, r formula.Formula) formula.Formula { return formula.NewXor(l, r) },
+	"~|":  func(l, r formula.Formula) formula.Formula { return formula.NewNor(l, r) },
+	"~&":  func(l, r formula.Formula) formula.Formula { return formula.NewNand(l, r) },
+}
+
+// formula parses a unitary formula, a chain of & or | or a binary formula with another connective.
+func (p *parser) formula() (formula.Formula, error) {
+	left, err := p.unitary()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.peek()
+	build, ok := binaryConnectives[op.text]
+	if op.kind != punct || !ok {
+		return left, nil
+	}
+
+	operands := []formula.Formula{left}
+	for {
+		p.next()
+		right, err := p.unitary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+
+		// only & and | can be chained, and the chains become n-ary formulas as in the formula parser.
+		if next := p.peek(); next.text != op.text || (op.text != "&" && op.text != "|") {
+			if _, ok := binaryConnectives[next.text]; ok && next.kind == punct {
+				return nil, p.errorf(next, "%q after %q needs parentheses", next.text, op.text)
+			}
+			switch op.text {
+			case "&":
+				return formula.Conjunction(operands...), nil
+			case "|":
+				return formula.Disjunction(operands...), nil
+			}
+			return build(operands[0], operands[1]), nil
+		}
+	}
+}
+
+// unitary parses a negation, a parenthesized formula, a constant or an atom.
+func (p *parser) unitary() (formula.Formula, error) {
+	t := p.next()
+
+	switch t.kind {
+	case punct:
+		switch t.text {
+		case "~":
+			f, err := p.unitary()
+			if err != nil {
+				return nil, err
+			}
+			return formula.NewNot(f), nil
+		case "(":
+			f, err := p.formula()
+			if err != nil {
+				return nil, err
+			}
+			return f, p.expect(")")
+		case "!", "?":
+			return nil, p.errorf(t, "quantified formulas are not propositional")
+		}
+	case dollarWord:
+		switch t.text {
+		case "$true":
+			return formula.NewTop(), nil
+		case "$false":
+			return formula.NewBottom(), nil
+		}
+		return nil, p.errorf(t, "unsupported %s", t.text)
+	case upperWord:
+		return nil, p.errorf(t, "variables are not propositional: %s", t.text)
+	case lowerWord, singleQuoted:
+		if t.kind == singleQuoted && (t.text == "" || strings.IndexFunc(t.text, func(r rune) bool {
+			return r > 127 || !isAlnum(byte(r))
+		}) >= 0) {
+			return nil, p.errorf(t, "the atom %v is not a valid letter", t)
+		}
+		switch next := p.peek(); next.text {
+		case "(":
+			return nil, p.errorf(next, "terms are not propositional: %s(...)", t.text)
+		case "=", "!=":
+			return nil, p.errorf(next, "equality is not propositional")
+		}
+		return formula.NewLetter(t.text), nil
+	}
+
+	return nil, p.errorf(t, "expected a formula, found %v", t)
+}
+
+// Formula returns the formula whose unsatisfiability solves the problem: the conjunction of the premises and of the
+// negation of the conjecture, if any. A problem without premises and conjecture is ⊤.
+func (p *Problem) Formula() formula.Formula {
+	fs := slices.Clone(p.Premises)
+	if p.Conjecture != nil {
+		fs = append(fs, formula.NewNot(p.Conjecture))
+	}
+	if len(fs) == 0 {
+		return formula.NewTop()
+	}
+
+	res := fs[0]
+	for _, f := range fs[1:] {
+		res = formula.NewAnd(res, f)
+	}
+	return res
+}
+
+// Status is an SZS status, the result of a problem in the TPTP world.
+type Status int
+
+const (
+	Theorem            Status = iota // Theorem means that the conjecture follows from the premises.
+	CounterSatisfiable               // CounterSatisfiable means that some model of the premises falsifies the conjecture.
+	Unsatisfiable                    // Unsatisfiable means that a problem without conjecture has no models.
+	Satisfiable                      // Satisfiable means that a problem without conjecture has a model.
+	Unknown                          // Unknown means that the construction of the tableau was stopped.
+)
+
+func (s Status) String() string {
+	switch s {
+	case Theorem:
+		return "Theorem"
+	case CounterSatisfiable:
+		return "CounterSatisfiable"
+	case Unsatisfiable:
+		return "Unsatisfiable"
+	case Satisfiable:
+		return "Satisfiable"
+	case Unknown:
+		return "Unknown"
+	default:
+		panic(fmt.Errorf("unknown Status %d", int(s)))
+	}
+}
+
+// Line returns the SZS status line of the given problem, like "% SZS status Theorem for SYN001+1".
+func (s Status) Line(problem string) string {
+	return fmt.Sprintf("%% SZS status %v for %s", s, problem)
+}
+
+// Status decides the problem with the buffered analytic tableaux of its Formula, built with the given options. If the
+// problem has a conjecture the status is Theorem or CounterSatisfiable, otherwise it is Unsatisfiable or Satisfiable.
+// If the construction is stopped by the options, the status is Unknown and the error is the one of
+// tableaux.BuildBufferTableaux.
+func (p *Problem) Status(opts ...tableaux.Option) (Status, error) {
+	tab, err := tableaux.BuildBufferTableaux(p.Formula(), opts...)
+	if err != nil {
+		return Unknown, err
+	}
+	satisfiable := len(tab.Eval()) > 0
+
+	switch {
+	case p.Conjecture != nil && satisfiable:
+		return CounterSatisfiable, nil
+	case p.Conjecture != nil:
+		return Theorem, nil
+	case satisfiable:
+		return Satisfiable, nil
+	default:
+		return Unsatisfiable, nil
+	}
+}
@@ -0,0 +1,185 @@
+package tptp
+
+import (
+	"errors"
+	"github.com/francodesource/propositional_tableaux/tableaux"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		premises   []string
+		conjecture string
+	}{
+		{
+			name:       "fof",
+			input:      "fof(a1, axiom, p => q).\nfof(a2, hypothesis, p).\nfof(c, conjecture, q).\n",
+			premises:   []string{"(p -> q)", "p"},
+			conjecture: "q",
+		},
+		{
+			name:     "cnf",
+			input:    "cnf(c1, axiom, p | ~q | r).\ncnf(c2, negated_conjecture, ~p).",
+			premises: []string{"(p | !q | r)", "!p"},
+		},
+		{
+			name:     "tff with types",
+			input:    "tff(p_type, type, p: $o).\ntff(a, axiom, p & $true & ~$false).",
+			premises: []string{"(p & ⊤ & !⊥)"},
+		},
+		{
+			name:     "connectives",
+			input:    "fof(a, axiom, ((p <= q) <=> (p <~> q)) ~| (p ~& q)).",
+			premises: []string{"(((p <- q) <-> (p ^ q)) !| (p !& q))"},
+		},
+		{
+			name: "comments, quoted atoms and annotations",
+			input: "% a comment\n/* a block\ncomment */\nfof('a 1', axiom, 'p1' & ~(q_2), file('x.p', a), [status(thm)]).\n" +
+				"fof(2, conjecture, ~ ~p1).",
+			premises:   []string{"(p1 & !q_2)"},
+			conjecture: "!!p1",
+		},
+		{
+			name:       "more conjectures",
+			input:      "fof(c1, conjecture, p).\nfof(c2, conjecture, q).",
+			conjecture: "(p & q)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.input), "")
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			var premises []string
+			for _, f := range got.Premises {
+				premises = append(premises, f.String())
+			}
+			if strings.Join(premises, ", ") != strings.Join(tt.premises, ", ") {
+				t.Errorf("Premises = %v, want %v", premises, tt.premises)
+			}
+			conjecture := ""
+			if got.Conjecture != nil {
+				conjecture = got.Conjecture.String()
+			}
+			if conjecture != tt.conjecture {
+				t.Errorf("Conjecture = %v, want %v", conjecture, tt.conjecture)
+			}
+		})
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		line, column int
+		msg          string
+	}{
+		{"quantifier", "fof(a, axiom, ![X]: p(X)).", 1, 14, "quantified formulas are not propositional"},
+		{"term", "fof(a, axiom, p(a)).", 1, 15, "terms are not propositional: p(...)"},
+		{"equality", "fof(a, axiom, a = b).", 1, 16, "equality is not propositional"},
+		{"variable", "cnf(a, axiom, X).", 1, 14, "variables are not propositional: X"},
+		{"mixed connectives", "fof(a, axiom,\np & q | r).", 2, 6, `"|" after "&" needs parentheses`},
+		{"chained implication", "fof(a, axiom, p => q => r).", 1, 21, `"=>" after "=>" needs parentheses`},
+		{"unknown role", "fof(a, question, p).", 1, 7, `unsupported role "question"`},
+		{"thf", "thf(a, axiom, p).", 1, 0, `unsupported "thf", expected fof, cnf, tff or include`},
+		{"invalid atom", "fof(a, axiom, 'p q').", 1, 14, "the atom 'p q' is not a valid letter"},
+		{"missing dot", "fof(a, axiom, p)", 1, 16, `expected ".", found end of file`},
+		{"unterminated comment", "/* fof(a, axiom, p).", 1, 0, "unterminated comment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.input), "")
+			want := SyntaxError{"<input>", tt.line, tt.column, tt.msg}
+			var se *SyntaxError
+			if !errors.As(err, &se) || *se != want {
+				t.Errorf("Read() error = %v, want %v", err, &want)
+			}
+		})
+	}
+}
+
+func TestReadFile_Include(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Axioms/SYN000+0.ax": "fof(ax1, axiom, p => q).\nfof(ax2, axiom, q => r).\nfof(ax3, axiom, ~r).\n",
+		"Axioms/LOOP.ax":     "include('Axioms/LOOP.ax').\n",
+		"Problems/SYN000+1.p": "include('Axioms/SYN000+0.ax', [ax1, ax2]).\n" +
+			"fof(h, hypothesis, p).\nfof(c, conjecture, r).\n",
+		"Problems/LOOP.p": "include('Axioms/LOOP.ax').\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := ReadFile(filepath.Join(dir, "Problems/SYN000+1.p"), dir)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if p.Name != "SYN000+1" || len(p.Premises) != 3 || p.Premises[1].String() != "(q -> r)" {
+		t.Errorf("ReadFile() = %+v", p)
+	}
+	if status, err := p.Status(); err != nil || status.Line(p.Name) != "% SZS status Theorem for SYN000+1" {
+		t.Errorf("Status() = %v, %v", status, err)
+	}
+
+	var se *SyntaxError
+	if _, err := ReadFile(filepath.Join(dir, "Problems/LOOP.p"), dir); !errors.As(err, &se) ||
+		se.Msg != "cyclic include of Axioms/LOOP.ax" {
+		t.Errorf("ReadFile() error = %v, want a cyclic include", err)
+	}
+	if _, err := Read(strings.NewReader("include('missing.ax')."), dir); !errors.As(err, &se) ||
+		!strings.HasPrefix(se.Msg, "cannot include missing.ax") {
+		t.Errorf("Read() error = %v, want a missing include", err)
+	}
+}
+
+func TestProblem_Status(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Status
+	}{
+		{"theorem", "fof(a, axiom, p => q).\nfof(b, axiom, p).\nfof(c, conjecture, q).", Theorem},
+		{"valid conjecture", "fof(c, conjecture, (p => q) | (q => p)).", Theorem},
+		{"counter satisfiable", "fof(a, axiom, p => q).\nfof(c, conjecture, p).", CounterSatisfiable},
+		{"satisfiable", "cnf(a, axiom, p | q).\ncnf(b, axiom, ~p).", Satisfiable},
+		{"unsatisfiable", "cnf(a, axiom, p).\ncnf(b, negated_conjecture, ~p).", Unsatisfiable},
+		{"empty", "", Satisfiable},
+		{"false", "fof(a, axiom, $false).", Unsatisfiable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Read(strings.NewReader(tt.input), "")
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if got, err := p.Status(); err != nil || got != tt.want {
+				t.Errorf("Status() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	p, err := Read(strings.NewReader(tests[0].input), "")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got, err := p.Status(tableaux.WithMaxNodes(1)); got != Unknown || !errors.Is(err, tableaux.ErrLimitExceeded) {
+		t.Errorf("Status(WithMaxNodes(1)) = %v, %v, want Unknown and ErrLimitExceeded", got, err)
+	}
+}
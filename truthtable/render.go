@@ -0,0 +1,130 @@
+package truthtable
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/francodesource/propositional_tableaux/formula"
+	"github.com/francodesource/propositional_tableaux/tableaux"
+	"strings"
+	"unicode/utf8"
+)
+
+// valueString returns the string used to print a truth value in a table.
+func valueString(value bool) string {
+	if value {
+		return "T"
+	}
+	return "F"
+}
+
+// header returns the titles of the columns of the table: first the letters, then the subformulas.
+func (t *Table) header(fd tableaux.FormulaDrawer) []string {
+	res := make([]string, 0, len(t.Letters)+len(t.Columns))
+	for _, letter := range t.Letters {
+		res = append(res, fd(formula.NewLetter(letter)))
+	}
+	for _, column := range t.Columns {
+		res = append(res, fd(column))
+	}
+	return res
+}
+
+// records returns the values of every row of the table as strings, in the same order of header.
+func (t *Table) records() [][]string {
+	res := make([][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		record := make([]string, 0, len(t.Letters)+len(row.Values))
+		for _, letter := range t.Letters {
+			record = append(record, valueString(row.Assignment[letter]))
+		}
+		for _, value := range row.Values {
+			record = append(record, valueString(value))
+		}
+		res[i] = record
+	}
+	return res
+}
+
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+}
+
+// Text returns the table as plain text, where the formulas in the header are printed with the given FormulaDrawer.
+func (t *Table) Text(fd tableaux.FormulaDrawer) string {
+	header := t.header(fd)
+	widths := make([]int, len(header))
+	for i, title := range header {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+
+	line := func(cells []string) string {
+		padded := make([]string, len(cells))
+		for i, cell := range cells {
+			padded[i] = pad(cell, widths[i])
+		}
+		return strings.TrimRight(strings.Join(padded, " | "), " ")
+	}
+
+	separators := make([]string, len(header))
+	for i, width := range widths {
+		separators[i] = strings.Repeat("-", width)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(line(header) + "\n")
+	sb.WriteString(strings.Join(separators, "-+-") + "\n")
+	for _, record := range t.records() {
+		sb.WriteString(line(record) + "\n")
+	}
+	return sb.String()
+}
+
+// String returns the table as plain text, with the formulas printed in ascii.
+func (t *Table) String() string {
+	return t.Text(formula.Formula.String)
+}
+
+// Markdown returns the table as a Markdown table, with the formulas printed in code spans.
+func (t *Table) Markdown() string {
+	header := t.header(func(f formula.Formula) string {
+		return "`" + strings.ReplaceAll(f.String(), "|", `\|`) + "`"
+	})
+
+	var sb strings.Builder
+	sb.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	sb.WriteString(strings.Repeat("|:-:", len(header)) + "|\n")
+	for _, record := range t.records() {
+		sb.WriteString("| " + strings.Join(record, " | ") + " |\n")
+	}
+	return sb.String()
+}
+
+// CSV returns the table in CSV format, with a header record containing the formulas printed in ascii.
+func (t *Table) CSV() string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	// writing to a strings.Builder never fails, so the error can be ignored.
+	_ = w.Write(t.header(formula.Formula.String))
+	_ = w.WriteAll(t.records())
+
+	return sb.String()
+}
+
+// TexTabular returns the table as a LaTeX tabular environment, with a vertical rule between letters and subformulas.
+func (t *Table) TexTabular() string {
+	header := t.header(func(f formula.Formula) string {
+		return "$" + tableaux.TexFormula(f) + "$"
+	})
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\\begin{tabular}{%s|%s}\n",
+		strings.Repeat("c", len(t.Letters)), strings.Repeat("c", len(t.Columns))))
+	sb.WriteString("\t" + strings.Join(header, " & ") + ` \\` + "\n")
+	sb.WriteString("\t\\hline\n")
+	for _, record := range t.records() {
+		sb.WriteString("\t" + strings.Join(record, " & ") + ` \\` + "\n")
+	}
+	sb.WriteString(`\end{tabular}`)
+	return sb.String()
+}
@@ -0,0 +1,155 @@
+package truthtable
+
+import (
+	"fmt"
+	"github.com/francodesource/propositional_tableaux/formula"
+	"github.com/francodesource/propositional_tableaux/tableaux"
+	"slices"
+)
+
+// MaxLetters is the maximum number of letters a Table can have, since a table with n letters has 2^n rows.
+const MaxLetters = 20
+
+// Verdict is the classification of a formula according to its truth table.
+type Verdict int
+
+const (
+	Contradiction Verdict = iota // Contradiction is a formula that is false in every row.
+	Contingent                   // Contingent is a formula that is true in some rows and false in others.
+	Valid                        // Valid is a formula that is true in every row.
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Contradiction:
+		return "contradiction"
+	case Contingent:
+		return "contingent"
+	case Valid:
+		return "valid"
+	default:
+		panic(fmt.Errorf("unknown Verdict %d", int(v)))
+	}
+}
+
+// Satisfiable returns true if the formula is true in at least one row.
+func (v Verdict) Satisfiable() bool {
+	return v != Contradiction
+}
+
+// Row is a row of a truth table: an assignment of all the letters of the table and the value of every column under it.
+type Row struct {
+	Assignment tableaux.Assignment
+	Values     []bool // Values[i] is the value of the i-th column of the table.
+}
+
+// Table is the truth table of one or more formulas.
+type Table struct {
+	Formulas []formula.Formula // Formulas are the formulas the table was built for.
+	Letters  []string          // Letters are the letters of the formulas in lexicographic order.
+	Columns  []formula.Formula // Columns are the subformulas of the formulas that are not letters, inner ones first.
+	Rows     []Row             // Rows are ordered from all letters true to all letters false, as in binary counting.
+	columns  map[formula.Formula]int
+}
+
+// New builds the truth table of the given formulas, storing the plain formula of the interned ones.
+// It returns an error if the formulas have more than MaxLetters letters.
+func New(fs ...formula.Formula) (*Table, error) {
+	t := &Table{columns: make(map[formula.Formula]int)}
+	for _, f := range fs {
+		t.Formulas = append(t.Formulas, plain(f))
+	}
+
+	for _, f := range t.Formulas {
+		for _, letter := range formula.Letters(f) {
+			if !slices.Contains(t.Letters, letter) {
+				t.Letters = append(t.Letters, letter)
+			}
+		}
+
+		for sub := range formula.Subformulas(f, formula.PostOrder) {
+			if _, ok := t.columns[sub]; !ok && !isLetter(sub) {
+				t.columns[sub] = len(t.Columns)
+				t.Columns = append(t.Columns, sub)
+			}
+		}
+	}
+	slices.Sort(t.Letters)
+
+	if len(t.Letters) > MaxLetters {
+		return nil, fmt.Errorf("truthtable: %d letters exceed the maximum of %d", len(t.Letters), MaxLetters)
+	}
+
+	n := len(t.Letters)
+	t.Rows = make([]Row, 0, 1<<n)
+	for i := 0; i < 1<<n; i++ {
+		assignment := make(tableaux.Assignment, n)
+		for j, letter := range t.Letters {
+			// the first letter is the most significant bit, and a 0 bit means true.
+			assignment[letter] = i&(1<<(n-1-j)) == 0
+		}
+
+		values := make([]bool, len(t.Columns))
+		for j, column := range t.Columns {
+			values[j] = formula.PartialEval(column, assignment) == formula.True
+		}
+
+		t.Rows = append(t.Rows, Row{Assignment: assignment, Values: values})
+	}
+
+	return t, nil
+}
+
+// plain returns the plain formula of an interned formula, and the formula itself otherwise, since the columns are
+// the plain subformulas returned by formula.Subformulas.
+func plain(f formula.Formula) formula.Formula {
+	if n, ok := f.(*formula.Interned); ok {
+		return n.Formula()
+	}
+	return f
+}
+
+func isLetter(f formula.Formula) bool {
+	_, ok := f.(formula.Letter)
+	return ok
+}
+
+// Value returns the value of f in the given row. The formula must be a letter or one of the columns of the table,
+// plain or interned, otherwise it panics.
+func (t *Table) Value(row int, f formula.Formula) bool {
+	f = plain(f)
+	if letter, ok := f.(formula.Letter); ok {
+		if value, ok := t.Rows[row].Assignment[letter.Name()]; ok {
+			return value
+		}
+	} else if i, ok := t.columns[f]; ok {
+		return t.Rows[row].Values[i]
+	}
+
+	panic(fmt.Errorf("%v is not a column of the truth table", f))
+}
+
+// Models returns the assignments of the rows where the i-th formula of the table is true.
+func (t *Table) Models(i int) []tableaux.Assignment {
+	var res []tableaux.Assignment
+	for row := range t.Rows {
+		if t.Value(row, t.Formulas[i]) {
+			res = append(res, t.Rows[row].Assignment)
+		}
+	}
+	return res
+}
+
+// Verdict returns whether the i-th formula of the table is valid, contingent or a contradiction.
+func (t *Table) Verdict(i int) Verdict {
+	models := len(t.Models(i))
+
+	switch models {
+	case 0:
+		return Contradiction
+	case len(t.Rows):
+		return Valid
+	default:
+		return Contingent
+	}
+}
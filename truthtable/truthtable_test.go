@@ -0,0 +1,265 @@
+package truthtable
+
+import (
+	"fmt"
+	"github.com/francodesource/propositional_tableaux/formula"
+	"github.com/francodesource/propositional_tableaux/tableaux"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestNew(t *testing.T) {
+	f := formula.Parse("!(p & q) -> q")
+	table, err := New(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"p", "q"}; !reflect.DeepEqual(table.Letters, want) {
+		t.Errorf("letters = %v, want %v", table.Letters, want)
+	}
+
+	wantColumns := []string{"(p & q)", "!(p & q)", "(!(p & q) -> q)"}
+	if len(table.Columns) != len(wantColumns) {
+		t.Fatalf("columns = %v, want %v", table.Columns, wantColumns)
+	}
+	for i, column := range table.Columns {
+		if column.String() != wantColumns[i] {
+			t.Errorf("column %d = %v, want %v", i, column, wantColumns[i])
+		}
+	}
+
+	wantRows := []Row{
+		{tableaux.Assignment{"p": true, "q": true}, []bool{true, false, true}},
+		{tableaux.Assignment{"p": true, "q": false}, []bool{false, true, false}},
+		{tableaux.Assignment{"p": false, "q": true}, []bool{false, true, true}},
+		{tableaux.Assignment{"p": false, "q": false}, []bool{false, true, false}},
+	}
+	if !reflect.DeepEqual(table.Rows, wantRows) {
+		t.Errorf("rows = %v, want %v", table.Rows, wantRows)
+	}
+}
+
+func TestNew_TooManyLetters(t *testing.T) {
+	f := formula.Formula(formula.NewLetter("p0"))
+	for i := 1; i <= MaxLetters; i++ {
+		f = formula.NewAnd(f, formula.NewLetter(fmt.Sprintf("p%d", i)))
+	}
+
+	if _, err := New(f); err == nil {
+		t.Errorf("expected an error for %d letters", MaxLetters+1)
+	}
+}
+
+func TestTable_Verdict(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Verdict
+	}{
+		{"excluded middle", "p | !p", Valid},
+		{"contradiction", "p & !p", Contradiction},
+		{"contingent", "p -> q", Contingent},
+		{"letter", "p", Contingent},
+		{"top", "T", Valid},
+		{"bottom", "F & p", Contradiction},
+		{"peirce", "((p -> q) -> p) -> p", Valid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := New(formula.Parse(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := table.Verdict(0); got != tt.want {
+				t.Errorf("Verdict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNew_Interned checks that a truth table built from interned formulas has the same verdicts and values as the one
+// built from the plain formulas.
+func TestNew_Interned(t *testing.T) {
+	tests := []string{"p", "p & q", "(p & q) | !p", "p <-> !q", "T", "ite(p, q, r)"}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			f := formula.Parse(input)
+			interned := formula.NewFactory().Intern(f)
+			plain, err := New(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			table, err := New(interned)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := table.Verdict(0), plain.Verdict(0); got != want {
+				t.Errorf("Verdict() = %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(table.Columns, plain.Columns) {
+				t.Errorf("Columns = %v, want %v", table.Columns, plain.Columns)
+			}
+			for row := range table.Rows {
+				if table.Value(row, interned) != plain.Value(row, f) {
+					t.Errorf("row %d: the interned %v has a different value", row, f)
+				}
+			}
+		})
+	}
+}
+
+func TestTable_MultipleFormulas(t *testing.T) {
+	f, g := formula.Parse("p -> q"), formula.Parse("!q -> !p")
+	table, err := New(f, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for row := range table.Rows {
+		if table.Value(row, f) != table.Value(row, g) {
+			t.Errorf("row %d: %v and %v have different values", row, f, g)
+		}
+	}
+
+	if len(table.Models(0)) != 3 || len(table.Models(1)) != 3 {
+		t.Errorf("expected 3 models, got %v and %v", table.Models(0), table.Models(1))
+	}
+}
+
+func TestTable_Render(t *testing.T) {
+	table, err := New(formula.Parse("p | !q"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "text",
+			got:  table.String(),
+			want: "p | q | !q | (p | !q)\n" +
+				"--+---+----+---------\n" +
+				"T | T | F  | T\n" +
+				"T | F | T  | T\n" +
+				"F | T | F  | F\n" +
+				"F | F | T  | T\n",
+		},
+		{
+			name: "unicode text",
+			got:  table.Text(tableaux.UnicodeFormula),
+			want: "p | q | ¬q | (p ∨ ¬q)\n" +
+				"--+---+----+---------\n" +
+				"T | T | F  | T\n" +
+				"T | F | T  | T\n" +
+				"F | T | F  | F\n" +
+				"F | F | T  | T\n",
+		},
+		{
+			name: "markdown",
+			got:  table.Markdown(),
+			want: "| `p` | `q` | `!q` | `(p \\| !q)` |\n" +
+				"|:-:|:-:|:-:|:-:|\n" +
+				"| T | T | F | T |\n" +
+				"| T | F | T | T |\n" +
+				"| F | T | F | F |\n" +
+				"| F | F | T | T |\n",
+		},
+		{
+			name: "csv",
+			got:  table.CSV(),
+			want: "p,q,!q,(p | !q)\n" +
+				"T,T,F,T\n" +
+				"T,F,T,T\n" +
+				"F,T,F,F\n" +
+				"F,F,T,T\n",
+		},
+		{
+			name: "latex",
+			got:  table.TexTabular(),
+			want: "\\begin{tabular}{cc|cc}\n" +
+				"\t$p$ & $q$ & $\\neg q$ & $\\left(p \\lor \\neg q\\right)$ \\\\\n" +
+				"\t\\hline\n" +
+				"\tT & T & F & T \\\\\n" +
+				"\tT & F & T & T \\\\\n" +
+				"\tF & T & F & F \\\\\n" +
+				"\tF & F & T & T \\\\\n" +
+				"\\end{tabular}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTable_TableauxCompare checks that the truth table and the semantic tableaux agree on satisfiability, and that
+// every assignment found by the tableaux is contained in a model of the truth table.
+func TestTable_TableauxCompare(t *testing.T) {
+	f := func(f formula.Formula) bool {
+		table, err := New(f)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+
+		tab, _ := tableaux.BuildSemanticTableaux(f)
+		assignments := tab.Eval()
+		if table.Verdict(0).Satisfiable() != (len(assignments) > 0) {
+			t.Errorf("%v: truth table verdict %v, tableaux assignments %v", f, table.Verdict(0), assignments)
+			return false
+		}
+
+		models := table.Models(0)
+		for _, a := range assignments {
+			found := false
+			for _, model := range models {
+				found = found || model.IsSupersetOf(a)
+			}
+			if !found {
+				t.Errorf("%v: tableaux assignment %v is not in the truth table", f, a)
+				return false
+			}
+		}
+		return true
+	}
+
+	config := &quick.Config{
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = reflect.ValueOf(formula.GenerateRandom(r, r.Intn(40)))
+		},
+	}
+
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTable_NoLetters(t *testing.T) {
+	table, err := New(formula.Parse("T -> F"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(table.Rows) != 1 || table.Verdict(0) != Contradiction {
+		t.Errorf("unexpected table\n%s", table)
+	}
+
+	if !strings.HasPrefix(table.String(), "⊤ | ⊥ | (⊤ -> ⊥)\n") {
+		t.Errorf("unexpected header\n%s", table)
+	}
+}